@@ -0,0 +1,13 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package archive
+
+import "fmt"
+
+// String returns a human-readable description of the regression, e.g.
+// "ns/op +25.0% (100 -> 125)".
+func (r Regression) String() string {
+	return fmt.Sprintf("%s %+.1f%% (%v -> %v)", r.Metric, 100*(r.After-r.Before)/r.Before, r.Before, r.After)
+}
@@ -11,15 +11,538 @@
 package archive
 
 import (
+	"time"
+
 	"github.com/vanadium/services/ben"
 	v23 "v.io/v23"
 	"v.io/v23/context"
 	"v.io/v23/rpc"
+	"v.io/v23/security/access"
+	"v.io/v23/vdl"
+	vdltime "v.io/v23/vdlroot/time"
 )
 
 var initializeVDLCalled = false
 var _ = initializeVDL() // Must be first; see initializeVDL comments for details.
 
+// Hold type definitions in package-level variables, for better performance.
+// Declare and initialize with default values here so that the initializeVDL
+// method will be considered ready to initialize before any of the type
+// definitions that appear below.
+//
+//nolint:unused
+var (
+	vdlTypeStruct1 *vdl.Type = nil
+	vdlTypeStruct2 *vdl.Type = nil
+	vdlTypeStruct3 *vdl.Type = nil
+	vdlTypeStruct4 *vdl.Type = nil
+	vdlTypeString5 *vdl.Type = nil
+	vdlTypeStruct6 *vdl.Type = nil
+	vdlTypeList7   *vdl.Type = nil
+	vdlTypeList8   *vdl.Type = nil
+)
+
+// Type definitions
+// ================
+// Regression describes a metric of a newly archived benchmark run that is
+// worse than the results previously archived for the same benchmark (i.e., the
+// same benchmark name, scenario and uploader).
+type Regression struct {
+	Name   string  // Name of the microbenchmark.
+	Metric string  // The metric that regressed: "ns/op", "allocs/op" or "B/op".
+	Before float64 // Median of the previously archived results.
+	After  float64 // The newly archived result.
+	PValue float64 // Probability of a result at least as bad as After if there was no regression, or 0 if there were too few previous results to compute it.
+}
+
+func (Regression) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/ben/archive.Regression"`
+}) {
+}
+
+func (x Regression) VDLIsZero() bool { //nolint:gocyclo
+	return x == Regression{}
+}
+
+func (x Regression) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct1); err != nil {
+		return err
+	}
+	if x.Name != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Name); err != nil {
+			return err
+		}
+	}
+	if x.Metric != "" {
+		if err := enc.NextFieldValueString(1, vdl.StringType, x.Metric); err != nil {
+			return err
+		}
+	}
+	if x.Before != 0 {
+		if err := enc.NextFieldValueFloat(2, vdl.Float64Type, x.Before); err != nil {
+			return err
+		}
+	}
+	if x.After != 0 {
+		if err := enc.NextFieldValueFloat(3, vdl.Float64Type, x.After); err != nil {
+			return err
+		}
+	}
+	if x.PValue != 0 {
+		if err := enc.NextFieldValueFloat(4, vdl.Float64Type, x.PValue); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *Regression) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = Regression{}
+	if err := dec.StartValue(vdlTypeStruct1); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct1 {
+			index = vdlTypeStruct1.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Name = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Metric = value
+			}
+		case 2:
+			switch value, err := dec.ReadValueFloat(64); {
+			case err != nil:
+				return err
+			default:
+				x.Before = value
+			}
+		case 3:
+			switch value, err := dec.ReadValueFloat(64); {
+			case err != nil:
+				return err
+			default:
+				x.After = value
+			}
+		case 4:
+			switch value, err := dec.ReadValueFloat(64); {
+			case err != nil:
+				return err
+			default:
+				x.PValue = value
+			}
+		}
+	}
+}
+
+// UploadSelector selects archived uploads by the fields that are set, all of
+// which must match.
+type UploadSelector struct {
+	Upload   string // ID of an upload, as presented by the web interface.
+	Uploader string // Identity of the uploader.
+	Label    string // Label of the scenario of the upload.
+}
+
+func (UploadSelector) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/ben/archive.UploadSelector"`
+}) {
+}
+
+func (x UploadSelector) VDLIsZero() bool { //nolint:gocyclo
+	return x == UploadSelector{}
+}
+
+func (x UploadSelector) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct2); err != nil {
+		return err
+	}
+	if x.Upload != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Upload); err != nil {
+			return err
+		}
+	}
+	if x.Uploader != "" {
+		if err := enc.NextFieldValueString(1, vdl.StringType, x.Uploader); err != nil {
+			return err
+		}
+	}
+	if x.Label != "" {
+		if err := enc.NextFieldValueString(2, vdl.StringType, x.Label); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *UploadSelector) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = UploadSelector{}
+	if err := dec.StartValue(vdlTypeStruct2); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct2 {
+			index = vdlTypeStruct2.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Upload = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Uploader = value
+			}
+		case 2:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Label = value
+			}
+		}
+	}
+}
+
+// UploadNotification summarizes newly archived benchmark results, as delivered
+// to the hooks of an archiver: JSON-encoded in the body of a POST request to
+// an HTTP endpoint, or to the Notify method of an UploadSubscriber.
+type UploadNotification struct {
+	Url         string // URL that can be used to browse the uploaded results.
+	Uploader    string // Identity of the uploader.
+	Scenario    ben.Scenario
+	Code        ben.SourceCode
+	SourceId    string // ID of Code, as presented by the web interface.
+	UploadTime  time.Time
+	Benchmarks  []string     // Names of the uploaded microbenchmarks.
+	Runs        int32        // Number of uploaded runs, including repeated runs of a microbenchmark.
+	Regressions []Regression // Regressions detected in the uploaded results.
+}
+
+func (UploadNotification) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/ben/archive.UploadNotification"`
+}) {
+}
+
+func (x UploadNotification) VDLIsZero() bool { //nolint:gocyclo
+	if x.Url != "" {
+		return false
+	}
+	if x.Uploader != "" {
+		return false
+	}
+	if !x.Scenario.VDLIsZero() {
+		return false
+	}
+	if x.Code != "" {
+		return false
+	}
+	if x.SourceId != "" {
+		return false
+	}
+	if !x.UploadTime.IsZero() {
+		return false
+	}
+	if len(x.Benchmarks) != 0 {
+		return false
+	}
+	if x.Runs != 0 {
+		return false
+	}
+	if len(x.Regressions) != 0 {
+		return false
+	}
+	return true
+}
+
+func (x UploadNotification) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct3); err != nil {
+		return err
+	}
+	if x.Url != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Url); err != nil {
+			return err
+		}
+	}
+	if x.Uploader != "" {
+		if err := enc.NextFieldValueString(1, vdl.StringType, x.Uploader); err != nil {
+			return err
+		}
+	}
+	if !x.Scenario.VDLIsZero() {
+		if err := enc.NextField(2); err != nil {
+			return err
+		}
+		if err := x.Scenario.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if x.Code != "" {
+		if err := enc.NextFieldValueString(3, vdlTypeString5, string(x.Code)); err != nil {
+			return err
+		}
+	}
+	if x.SourceId != "" {
+		if err := enc.NextFieldValueString(4, vdl.StringType, x.SourceId); err != nil {
+			return err
+		}
+	}
+	if !x.UploadTime.IsZero() {
+		if err := enc.NextField(5); err != nil {
+			return err
+		}
+		var wire vdltime.Time
+		if err := vdltime.TimeFromNative(&wire, x.UploadTime); err != nil {
+			return err
+		}
+		if err := wire.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if len(x.Benchmarks) != 0 {
+		if err := enc.NextField(6); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList1(enc, x.Benchmarks); err != nil {
+			return err
+		}
+	}
+	if x.Runs != 0 {
+		if err := enc.NextFieldValueInt(7, vdl.Int32Type, int64(x.Runs)); err != nil {
+			return err
+		}
+	}
+	if len(x.Regressions) != 0 {
+		if err := enc.NextField(8); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList2(enc, x.Regressions); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonList1(enc vdl.Encoder, x []string) error {
+	if err := enc.StartValue(vdlTypeList7); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for _, elem := range x {
+		if err := enc.NextEntryValueString(vdl.StringType, elem); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonList2(enc vdl.Encoder, x []Regression) error {
+	if err := enc.StartValue(vdlTypeList8); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for _, elem := range x {
+		if err := enc.NextEntry(false); err != nil {
+			return err
+		}
+		if err := elem.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *UploadNotification) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = UploadNotification{}
+	if err := dec.StartValue(vdlTypeStruct3); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct3 {
+			index = vdlTypeStruct3.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Url = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Uploader = value
+			}
+		case 2:
+			if err := x.Scenario.VDLRead(dec); err != nil {
+				return err
+			}
+		case 3:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Code = ben.SourceCode(value)
+			}
+		case 4:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.SourceId = value
+			}
+		case 5:
+			var wire vdltime.Time
+			if err := wire.VDLRead(dec); err != nil {
+				return err
+			}
+			if err := vdltime.TimeToNative(wire, &x.UploadTime); err != nil {
+				return err
+			}
+		case 6:
+			if err := vdlReadAnonList1(dec, &x.Benchmarks); err != nil {
+				return err
+			}
+		case 7:
+			switch value, err := dec.ReadValueInt(32); {
+			case err != nil:
+				return err
+			default:
+				x.Runs = int32(value)
+			}
+		case 8:
+			if err := vdlReadAnonList2(dec, &x.Regressions); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonList1(dec vdl.Decoder, x *[]string) error {
+	if err := dec.StartValue(vdlTypeList7); err != nil {
+		return err
+	}
+	if len := dec.LenHint(); len > 0 {
+		*x = make([]string, 0, len)
+	} else {
+		*x = nil
+	}
+	for {
+		switch done, elem, err := dec.NextEntryValueString(); {
+		case err != nil:
+			return err
+		case done:
+			return dec.FinishValue()
+		default:
+			*x = append(*x, elem)
+		}
+	}
+}
+
+func vdlReadAnonList2(dec vdl.Decoder, x *[]Regression) error {
+	if err := dec.StartValue(vdlTypeList8); err != nil {
+		return err
+	}
+	if len := dec.LenHint(); len > 0 {
+		*x = make([]Regression, 0, len)
+	} else {
+		*x = nil
+	}
+	for {
+		switch done, err := dec.NextEntry(); {
+		case err != nil:
+			return err
+		case done:
+			return dec.FinishValue()
+		default:
+			var elem Regression
+			if err := elem.VDLRead(dec); err != nil {
+				return err
+			}
+			*x = append(*x, elem)
+		}
+	}
+}
+
 // Interface definitions
 // =====================
 
@@ -31,11 +554,17 @@ type BenchmarkArchiverClientMethods interface {
 	// Archive saves results in 'runs' under the assumption that the
 	// benchmarks were run on a machine whose configuration is defined by
 	// 'scenario' and the were built from source code described by 'code'
-	// (a commit hash of a repository, the manifest of a jiri project etc.)
+	// (a commit hash of a repository, the manifest of a jiri project, a
+	// ben.Source encoded by its SourceCode method etc.)
 	//
 	// Returns a URL that can be used to browse the uploaded benchmark
-	// results.
-	Archive(_ *context.T, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run, _ ...rpc.CallOpt) (string, error)
+	// results and the regressions detected by comparing 'runs' to the
+	// previously archived results of the same benchmarks.
+	Archive(_ *context.T, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run, _ ...rpc.CallOpt) (url string, regressions []Regression, _ error)
+	// Delete removes the results of the uploads matching 'selector', at
+	// least one field of which must be set, and returns the number of
+	// uploads removed.
+	Delete(_ *context.T, selector UploadSelector, _ ...rpc.CallOpt) (uploads int32, _ error)
 }
 
 // BenchmarkArchiverClientStub embeds BenchmarkArchiverClientMethods and is a
@@ -53,8 +582,13 @@ type implBenchmarkArchiverClientStub struct {
 	name string
 }
 
-func (c implBenchmarkArchiverClientStub) Archive(ctx *context.T, i0 ben.Scenario, i1 ben.SourceCode, i2 []ben.Run, opts ...rpc.CallOpt) (o0 string, err error) {
-	err = v23.GetClient(ctx).Call(ctx, c.name, "Archive", []interface{}{i0, i1, i2}, []interface{}{&o0}, opts...)
+func (c implBenchmarkArchiverClientStub) Archive(ctx *context.T, i0 ben.Scenario, i1 ben.SourceCode, i2 []ben.Run, opts ...rpc.CallOpt) (o0 string, o1 []Regression, err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "Archive", []interface{}{i0, i1, i2}, []interface{}{&o0, &o1}, opts...)
+	return
+}
+
+func (c implBenchmarkArchiverClientStub) Delete(ctx *context.T, i0 UploadSelector, opts ...rpc.CallOpt) (o0 int32, err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "Delete", []interface{}{i0}, []interface{}{&o0}, opts...)
 	return
 }
 
@@ -66,11 +600,17 @@ type BenchmarkArchiverServerMethods interface {
 	// Archive saves results in 'runs' under the assumption that the
 	// benchmarks were run on a machine whose configuration is defined by
 	// 'scenario' and the were built from source code described by 'code'
-	// (a commit hash of a repository, the manifest of a jiri project etc.)
+	// (a commit hash of a repository, the manifest of a jiri project, a
+	// ben.Source encoded by its SourceCode method etc.)
 	//
 	// Returns a URL that can be used to browse the uploaded benchmark
-	// results.
-	Archive(_ *context.T, _ rpc.ServerCall, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run) (string, error)
+	// results and the regressions detected by comparing 'runs' to the
+	// previously archived results of the same benchmarks.
+	Archive(_ *context.T, _ rpc.ServerCall, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run) (url string, regressions []Regression, _ error)
+	// Delete removes the results of the uploads matching 'selector', at
+	// least one field of which must be set, and returns the number of
+	// uploads removed.
+	Delete(_ *context.T, _ rpc.ServerCall, selector UploadSelector) (uploads int32, _ error)
 }
 
 // BenchmarkArchiverServerStubMethods is the server interface containing
@@ -108,10 +648,14 @@ type implBenchmarkArchiverServerStub struct {
 	gs   *rpc.GlobState
 }
 
-func (s implBenchmarkArchiverServerStub) Archive(ctx *context.T, call rpc.ServerCall, i0 ben.Scenario, i1 ben.SourceCode, i2 []ben.Run) (string, error) {
+func (s implBenchmarkArchiverServerStub) Archive(ctx *context.T, call rpc.ServerCall, i0 ben.Scenario, i1 ben.SourceCode, i2 []ben.Run) (string, []Regression, error) {
 	return s.impl.Archive(ctx, call, i0, i1, i2)
 }
 
+func (s implBenchmarkArchiverServerStub) Delete(ctx *context.T, call rpc.ServerCall, i0 UploadSelector) (int32, error) {
+	return s.impl.Delete(ctx, call, i0)
+}
+
 func (s implBenchmarkArchiverServerStub) Globber() *rpc.GlobState {
 	return s.gs
 }
@@ -131,15 +675,141 @@ var descBenchmarkArchiver = rpc.InterfaceDesc{
 	Methods: []rpc.MethodDesc{
 		{
 			Name: "Archive",
-			Doc:  "// Archive saves results in 'runs' under the assumption that the\n// benchmarks were run on a machine whose configuration is defined by\n// 'scenario' and the were built from source code described by 'code'\n// (a commit hash of a repository, the manifest of a jiri project etc.)\n//\n// Returns a URL that can be used to browse the uploaded benchmark\n// results.",
+			Doc:  "// Archive saves results in 'runs' under the assumption that the\n// benchmarks were run on a machine whose configuration is defined by\n// 'scenario' and the were built from source code described by 'code'\n// (a commit hash of a repository, the manifest of a jiri project, a\n// ben.Source encoded by its SourceCode method etc.)\n//\n// Returns a URL that can be used to browse the uploaded benchmark\n// results and the regressions detected by comparing 'runs' to the\n// previously archived results of the same benchmarks.",
 			InArgs: []rpc.ArgDesc{
 				{Name: "scenario", Doc: ``}, // ben.Scenario
 				{Name: "code", Doc: ``},     // ben.SourceCode
 				{Name: "runs", Doc: ``},     // []ben.Run
 			},
 			OutArgs: []rpc.ArgDesc{
-				{Name: "", Doc: ``}, // string
+				{Name: "url", Doc: ``},         // string
+				{Name: "regressions", Doc: ``}, // []Regression
 			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Write"))},
+		},
+		{
+			Name: "Delete",
+			Doc:  "// Delete removes the results of the uploads matching 'selector', at\n// least one field of which must be set, and returns the number of\n// uploads removed.",
+			InArgs: []rpc.ArgDesc{
+				{Name: "selector", Doc: ``}, // UploadSelector
+			},
+			OutArgs: []rpc.ArgDesc{
+				{Name: "uploads", Doc: ``}, // int32
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Admin"))},
+		},
+	},
+}
+
+// UploadSubscriberClientMethods is the client interface
+// containing UploadSubscriber methods.
+//
+// UploadSubscriber is the interface of the services that archivers notify of
+// newly archived benchmark results, e.g. to comment on the changes that they
+// were built from.
+type UploadSubscriberClientMethods interface {
+	// Notify is called after the results described by 'n' have been
+	// archived. It may be called more than once for the same results if
+	// an earlier call failed.
+	Notify(_ *context.T, n UploadNotification, _ ...rpc.CallOpt) error
+}
+
+// UploadSubscriberClientStub embeds UploadSubscriberClientMethods and is a
+// placeholder for additional management operations.
+type UploadSubscriberClientStub interface {
+	UploadSubscriberClientMethods
+}
+
+// UploadSubscriberClient returns a client stub for UploadSubscriber.
+func UploadSubscriberClient(name string) UploadSubscriberClientStub {
+	return implUploadSubscriberClientStub{name}
+}
+
+type implUploadSubscriberClientStub struct {
+	name string
+}
+
+func (c implUploadSubscriberClientStub) Notify(ctx *context.T, i0 UploadNotification, opts ...rpc.CallOpt) (err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "Notify", []interface{}{i0}, nil, opts...)
+	return
+}
+
+// UploadSubscriberServerMethods is the interface a server writer
+// implements for UploadSubscriber.
+//
+// UploadSubscriber is the interface of the services that archivers notify of
+// newly archived benchmark results, e.g. to comment on the changes that they
+// were built from.
+type UploadSubscriberServerMethods interface {
+	// Notify is called after the results described by 'n' have been
+	// archived. It may be called more than once for the same results if
+	// an earlier call failed.
+	Notify(_ *context.T, _ rpc.ServerCall, n UploadNotification) error
+}
+
+// UploadSubscriberServerStubMethods is the server interface containing
+// UploadSubscriber methods, as expected by rpc.Server.
+// There is no difference between this interface and UploadSubscriberServerMethods
+// since there are no streaming methods.
+type UploadSubscriberServerStubMethods UploadSubscriberServerMethods
+
+// UploadSubscriberServerStub adds universal methods to UploadSubscriberServerStubMethods.
+type UploadSubscriberServerStub interface {
+	UploadSubscriberServerStubMethods
+	// DescribeInterfaces the UploadSubscriber interfaces.
+	Describe__() []rpc.InterfaceDesc
+}
+
+// UploadSubscriberServer returns a server stub for UploadSubscriber.
+// It converts an implementation of UploadSubscriberServerMethods into
+// an object that may be used by rpc.Server.
+func UploadSubscriberServer(impl UploadSubscriberServerMethods) UploadSubscriberServerStub {
+	stub := implUploadSubscriberServerStub{
+		impl: impl,
+	}
+	// Initialize GlobState; always check the stub itself first, to handle the
+	// case where the user has the Glob method defined in their VDL source.
+	if gs := rpc.NewGlobState(stub); gs != nil {
+		stub.gs = gs
+	} else if gs := rpc.NewGlobState(impl); gs != nil {
+		stub.gs = gs
+	}
+	return stub
+}
+
+type implUploadSubscriberServerStub struct {
+	impl UploadSubscriberServerMethods
+	gs   *rpc.GlobState
+}
+
+func (s implUploadSubscriberServerStub) Notify(ctx *context.T, call rpc.ServerCall, i0 UploadNotification) error {
+	return s.impl.Notify(ctx, call, i0)
+}
+
+func (s implUploadSubscriberServerStub) Globber() *rpc.GlobState {
+	return s.gs
+}
+
+func (s implUploadSubscriberServerStub) Describe__() []rpc.InterfaceDesc {
+	return []rpc.InterfaceDesc{UploadSubscriberDesc}
+}
+
+// UploadSubscriberDesc describes the UploadSubscriber interface.
+var UploadSubscriberDesc rpc.InterfaceDesc = descUploadSubscriber
+
+// descUploadSubscriber hides the desc to keep godoc clean.
+var descUploadSubscriber = rpc.InterfaceDesc{
+	Name:    "UploadSubscriber",
+	PkgPath: "v.io/x/ref/services/ben/archive",
+	Doc:     "// UploadSubscriber is the interface of the services that archivers notify of\n// newly archived benchmark results, e.g. to comment on the changes that they\n// were built from.",
+	Methods: []rpc.MethodDesc{
+		{
+			Name: "Notify",
+			Doc:  "// Notify is called after the results described by 'n' have been\n// archived. It may be called more than once for the same results if\n// an earlier call failed.",
+			InArgs: []rpc.ArgDesc{
+				{Name: "n", Doc: ``}, // UploadNotification
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Write"))},
 		},
 	},
 }
@@ -163,5 +833,20 @@ func initializeVDL() struct{} {
 	}
 	initializeVDLCalled = true
 
+	// Register types.
+	vdl.Register((*Regression)(nil))
+	vdl.Register((*UploadSelector)(nil))
+	vdl.Register((*UploadNotification)(nil))
+
+	// Initialize type definitions.
+	vdlTypeStruct1 = vdl.TypeOf((*Regression)(nil)).Elem()
+	vdlTypeStruct2 = vdl.TypeOf((*UploadSelector)(nil)).Elem()
+	vdlTypeStruct3 = vdl.TypeOf((*UploadNotification)(nil)).Elem()
+	vdlTypeStruct4 = vdl.TypeOf((*ben.Scenario)(nil)).Elem()
+	vdlTypeString5 = vdl.TypeOf((*ben.SourceCode)(nil))
+	vdlTypeStruct6 = vdl.TypeOf((*vdltime.Time)(nil)).Elem()
+	vdlTypeList7 = vdl.TypeOf((*[]string)(nil))
+	vdlTypeList8 = vdl.TypeOf((*[]Regression)(nil))
+
 	return struct{}{}
 }
@@ -0,0 +1,191 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	v23 "v.io/v23"
+	"v.io/v23/context"
+	"v.io/v23/security"
+	"v.io/v23/vom"
+)
+
+// UploadPath is the path of the HTTP endpoint of the archiver that accepts the
+// output of "go test -bench" (see the gobench package), with the label and
+// the description of the source code of the results in the label and source
+// query parameters.
+//
+// Since HTTP requests do not carry blessings, uploads are authenticated by
+// SignRequest, which adds the blessings of the uploader and a signature of the
+// request made with their private key in the following headers.
+const UploadPath = "/api/v1/upload"
+
+// LoginPath is the path of the HTTP endpoint of the archiver that starts a
+// session of the web interface, for archivers that require requests to it to
+// be authenticated. A POST request authenticated by SignRequest returns a
+// LoginResult with a URL which, when opened in a browser, authenticates the
+// browser's requests with the blessings that signed the POST request.
+const LoginPath = "/api/v1/login"
+
+const (
+	BlessingsHeader = "X-Vanadium-Blessings" // Base64 VOM-encoded security.Blessings.
+	SignatureHeader = "X-Vanadium-Signature" // Base64 VOM-encoded security.Signature.
+	TimestampHeader = "X-Vanadium-Timestamp" // RFC 3339 time at which the request was signed.
+
+	// MaxClockSkew is how far the time in the TimestampHeader of a
+	// request can be from the time at which it is received.
+	MaxClockSkew = 5 * time.Minute
+)
+
+// UploadResult is the JSON-encoded response to an upload.
+type UploadResult struct {
+	URL         string       // URL of the archived results.
+	Regressions []Regression // Regressions detected in the uploaded results.
+	Error       string       `json:",omitempty"`
+}
+
+// LoginResult is the JSON-encoded response to a login request.
+type LoginResult struct {
+	URL   string // URL that starts a session of the web interface.
+	Error string `json:",omitempty"`
+}
+
+// Upload archives the output of "go test -bench" with the archiver serving
+// HTTP at server (e.g. "https://benarchd.example.com"), authenticated with the
+// default blessings of the principal of ctx.
+func Upload(ctx *context.T, server, label string, code ben.SourceCode, output []byte) (UploadResult, error) {
+	q := url.Values{}
+	q.Set("label", label)
+	q.Set("source", string(code))
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(server, "/")+UploadPath+"?"+q.Encode(), bytes.NewReader(output))
+	if err != nil {
+		return UploadResult{}, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	var result UploadResult
+	err = do(ctx, req, output, &result, &result.Error)
+	return result, err
+}
+
+// Login returns a URL that starts a session of the web interface of the
+// archiver serving HTTP at server, authenticated with the default blessings
+// of the principal of ctx. The URL can be used once, within a few minutes.
+func Login(ctx *context.T, server string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(server, "/")+LoginPath, nil)
+	if err != nil {
+		return "", err
+	}
+	var result LoginResult
+	err = do(ctx, req, nil, &result, &result.Error)
+	return result.URL, err
+}
+
+// do sends req, with the given body, signed by the default blessings of the
+// principal of ctx and decodes the JSON response into result, whose error
+// message is errmsg.
+func do(ctx *context.T, req *http.Request, body []byte, result interface{}, errmsg *string) error {
+	p := v23.GetPrincipal(ctx)
+	blessings, _ := p.BlessingStore().Default()
+	if err := SignRequest(p, blessings, req, body, time.Now()); err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%v: invalid response: %v", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v: %v", resp.Status, *errmsg)
+	}
+	return nil
+}
+
+// SignRequest adds the headers that authenticate a request with the given
+// blessings of p, where body is the body of req.
+func SignRequest(p security.Principal, blessings security.Blessings, req *http.Request, body []byte, now time.Time) error {
+	timestamp := now.UTC().Format(time.RFC3339)
+	sig, err := p.Sign(requestMessage(req, timestamp, body))
+	if err != nil {
+		return err
+	}
+	encBlessings, err := vom.Encode(blessings)
+	if err != nil {
+		return err
+	}
+	encSig, err := vom.Encode(sig)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(BlessingsHeader, base64.URLEncoding.EncodeToString(encBlessings))
+	req.Header.Set(SignatureHeader, base64.URLEncoding.EncodeToString(encSig))
+	req.Header.Set(TimestampHeader, timestamp)
+	return nil
+}
+
+// VerifyRequest returns the blessings that a request was signed with by
+// SignRequest, where body is the body of req. The blessings must still be
+// authorized, e.g. by creating a security.Call for them.
+func VerifyRequest(req *http.Request, body []byte, now time.Time) (security.Blessings, error) {
+	var (
+		blessings security.Blessings
+		sig       security.Signature
+	)
+	timestamp := req.Header.Get(TimestampHeader)
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return blessings, fmt.Errorf("invalid or missing %v header", TimestampHeader)
+	}
+	if skew := now.Sub(t); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return blessings, fmt.Errorf("request signed at %v, which is too far from %v", t, now.UTC().Format(time.RFC3339))
+	}
+	if err := decodeHeader(req, BlessingsHeader, &blessings); err != nil {
+		return blessings, err
+	}
+	if err := decodeHeader(req, SignatureHeader, &sig); err != nil {
+		return blessings, err
+	}
+	if blessings.IsZero() || !sig.Verify(blessings.PublicKey(), requestMessage(req, timestamp, body)) {
+		return security.Blessings{}, fmt.Errorf("invalid signature")
+	}
+	return blessings, nil
+}
+
+func decodeHeader(req *http.Request, header string, v interface{}) error {
+	data, err := base64.URLEncoding.DecodeString(req.Header.Get(header))
+	if err == nil && len(data) > 0 {
+		err = vom.Decode(data, v)
+	}
+	if err != nil || len(data) == 0 {
+		return fmt.Errorf("invalid or missing %v header", header)
+	}
+	return nil
+}
+
+// requestMessage returns the message signed to authenticate a request, which
+// covers the method, path and query of the request, the time at which it was
+// signed and its body.
+func requestMessage(req *http.Request, timestamp string, body []byte) []byte {
+	hash := sha256.Sum256(body)
+	var buf bytes.Buffer
+	for _, field := range []string{"benarchd request", req.Method, req.URL.RequestURI(), timestamp} {
+		buf.WriteString(field)
+		buf.WriteByte(0)
+	}
+	buf.Write(hash[:])
+	return buf.Bytes()
+}
@@ -5,9 +5,12 @@
 package ben
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
@@ -23,6 +26,106 @@ func (c SourceCode) ID() string {
 	return hex.EncodeToString(hash[:])
 }
 
+// Source returns the structured description encoded in c by
+// Source.SourceCode, or false if c is an opaque description.
+func (c SourceCode) Source() (Source, bool) {
+	if !strings.HasPrefix(string(c), "{") {
+		return Source{}, false
+	}
+	var src Source
+	dec := json.NewDecoder(strings.NewReader(string(c)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&src); err != nil || dec.More() {
+		return Source{}, false
+	}
+	return src, true
+}
+
+// Commits returns the hashes of the commits in c: those of the Source encoded
+// in it and its projects or, for an opaque description that is its own ID,
+// the description itself since that is typically a commit hash.
+func (c SourceCode) Commits() []string {
+	src, ok := c.Source()
+	if !ok {
+		if s := string(c); s == c.ID() {
+			return []string{s}
+		}
+		return nil
+	}
+	var commits []string
+	if src.Commit != "" {
+		commits = append(commits, src.Commit)
+	}
+	for _, p := range src.Projects {
+		if p.Commit != "" {
+			commits = append(commits, p.Commit)
+		}
+	}
+	return commits
+}
+
+// SourceCode encodes s in a SourceCode, from which it is returned by
+// SourceCode.Source.
+func (s Source) SourceCode() SourceCode {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		// Source only has fields that can be encoded.
+		panic(err)
+	}
+	return SourceCode(strings.TrimSpace(buf.String()))
+}
+
+// CommitURL returns the URL at which a repository browser presents the commit
+// of the repository at the given URL, or "" if the URL of the repository is
+// not an HTTP(S) URL.
+func CommitURL(repository, commit string) string {
+	if commit == "" || !(strings.HasPrefix(repository, "https://") || strings.HasPrefix(repository, "http://")) {
+		return ""
+	}
+	repository = strings.TrimSuffix(strings.TrimSuffix(repository, "/"), ".git")
+	switch {
+	case strings.Contains(repository, ".googlesource.com/"):
+		return repository + "/+/" + commit
+	case strings.Contains(repository, "://bitbucket.org/"):
+		return repository + "/commits/" + commit
+	default:
+		// GitHub, GitLab, Gitea and others.
+		return repository + "/commit/" + commit
+	}
+}
+
+// String returns a compact description of the known fields of e, e.g.
+// "go1.22.1 GOMAXPROCS=8 cores=8 memory=16GiB ci=nightly".
+func (e Environment) String() string {
+	var fields []string
+	if e.GoVersion != "" {
+		fields = append(fields, e.GoVersion)
+	}
+	if e.GoMaxProcs > 0 {
+		fields = append(fields, fmt.Sprintf("GOMAXPROCS=%d", e.GoMaxProcs))
+	}
+	if e.Cores > 0 {
+		fields = append(fields, fmt.Sprintf("cores=%d", e.Cores))
+	}
+	if e.MemoryBytes > 0 {
+		fields = append(fields, fmt.Sprintf("memory=%.4gGiB", float64(e.MemoryBytes)/(1<<30)))
+	}
+	if e.Kernel != "" {
+		fields = append(fields, "kernel="+e.Kernel)
+	}
+	if e.CpuGovernor != "" {
+		fields = append(fields, "governor="+e.CpuGovernor)
+	}
+	var tags []string
+	for name, value := range e.Tags {
+		tags = append(tags, name+"="+value)
+	}
+	sort.Strings(tags)
+	return strings.Join(append(fields, tags...), " ")
+}
+
 // PrettyTime returns a string representing r.NanoSecsPerOp in a more
 // human-friendly form, similar to time.Duration.String.
 func (r Run) PrettyTime() string {
@@ -27,11 +27,17 @@ var _ = initializeVDL() // Must be first; see initializeVDL comments for details
 //
 //nolint:unused
 var (
-	vdlTypeStruct1 *vdl.Type = nil
-	vdlTypeStruct2 *vdl.Type = nil
-	vdlTypeStruct3 *vdl.Type = nil
-	vdlTypeString4 *vdl.Type = nil
-	vdlTypeStruct5 *vdl.Type = nil
+	vdlTypeStruct1  *vdl.Type = nil
+	vdlTypeStruct2  *vdl.Type = nil
+	vdlTypeStruct3  *vdl.Type = nil
+	vdlTypeMap4     *vdl.Type = nil
+	vdlTypeStruct5  *vdl.Type = nil
+	vdlTypeString6  *vdl.Type = nil
+	vdlTypeStruct7  *vdl.Type = nil
+	vdlTypeStruct8  *vdl.Type = nil
+	vdlTypeList9    *vdl.Type = nil
+	vdlTypeStruct10 *vdl.Type = nil
+	vdlTypeMap11    *vdl.Type = nil
 )
 
 // Type definitions
@@ -203,11 +209,232 @@ func (x *Os) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 	}
 }
 
+// Environment describes the configuration of the machine and the toolchain
+// with which the microbenchmarks were run. Fields are zero if unknown.
+type Environment struct {
+	GoVersion   string // Version of the Go toolchain, e.g. "go1.22.1".
+	GoMaxProcs  uint32 // GOMAXPROCS, if it was the same for all microbenchmarks.
+	Cores       uint32 // Number of CPU cores.
+	MemoryBytes uint64 // Size of the RAM.
+	Kernel      string // Version of the kernel, e.g. "5.15.0-91-generic".
+	CpuGovernor string // Governor of the CPU frequency, e.g. "performance".
+	// Arbitrary key/value pairs assigned by the uploader, e.g. "ci": "nightly".
+	Tags map[string]string
+}
+
+func (Environment) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/ben.Environment"`
+}) {
+}
+
+func (x Environment) VDLIsZero() bool { //nolint:gocyclo
+	if x.GoVersion != "" {
+		return false
+	}
+	if x.GoMaxProcs != 0 {
+		return false
+	}
+	if x.Cores != 0 {
+		return false
+	}
+	if x.MemoryBytes != 0 {
+		return false
+	}
+	if x.Kernel != "" {
+		return false
+	}
+	if x.CpuGovernor != "" {
+		return false
+	}
+	if len(x.Tags) != 0 {
+		return false
+	}
+	return true
+}
+
+func (x Environment) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct3); err != nil {
+		return err
+	}
+	if x.GoVersion != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.GoVersion); err != nil {
+			return err
+		}
+	}
+	if x.GoMaxProcs != 0 {
+		if err := enc.NextFieldValueUint(1, vdl.Uint32Type, uint64(x.GoMaxProcs)); err != nil {
+			return err
+		}
+	}
+	if x.Cores != 0 {
+		if err := enc.NextFieldValueUint(2, vdl.Uint32Type, uint64(x.Cores)); err != nil {
+			return err
+		}
+	}
+	if x.MemoryBytes != 0 {
+		if err := enc.NextFieldValueUint(3, vdl.Uint64Type, x.MemoryBytes); err != nil {
+			return err
+		}
+	}
+	if x.Kernel != "" {
+		if err := enc.NextFieldValueString(4, vdl.StringType, x.Kernel); err != nil {
+			return err
+		}
+	}
+	if x.CpuGovernor != "" {
+		if err := enc.NextFieldValueString(5, vdl.StringType, x.CpuGovernor); err != nil {
+			return err
+		}
+	}
+	if len(x.Tags) != 0 {
+		if err := enc.NextField(6); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonMap1(enc, x.Tags); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonMap1(enc vdl.Encoder, x map[string]string) error {
+	if err := enc.StartValue(vdlTypeMap4); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for key, elem := range x {
+		if err := enc.NextEntryValueString(vdl.StringType, key); err != nil {
+			return err
+		}
+		if err := enc.WriteValueString(vdl.StringType, elem); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *Environment) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = Environment{}
+	if err := dec.StartValue(vdlTypeStruct3); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct3 {
+			index = vdlTypeStruct3.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.GoVersion = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueUint(32); {
+			case err != nil:
+				return err
+			default:
+				x.GoMaxProcs = uint32(value)
+			}
+		case 2:
+			switch value, err := dec.ReadValueUint(32); {
+			case err != nil:
+				return err
+			default:
+				x.Cores = uint32(value)
+			}
+		case 3:
+			switch value, err := dec.ReadValueUint(64); {
+			case err != nil:
+				return err
+			default:
+				x.MemoryBytes = value
+			}
+		case 4:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Kernel = value
+			}
+		case 5:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.CpuGovernor = value
+			}
+		case 6:
+			if err := vdlReadAnonMap1(dec, &x.Tags); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonMap1(dec vdl.Decoder, x *map[string]string) error {
+	if err := dec.StartValue(vdlTypeMap4); err != nil {
+		return err
+	}
+	var tmpMap map[string]string
+	if len := dec.LenHint(); len > 0 {
+		tmpMap = make(map[string]string, len)
+	}
+	for {
+		switch done, key, err := dec.NextEntryValueString(); {
+		case err != nil:
+			return err
+		case done:
+			*x = tmpMap
+			return dec.FinishValue()
+		default:
+			var elem string
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				elem = value
+			}
+			if tmpMap == nil {
+				tmpMap = make(map[string]string)
+			}
+			tmpMap[key] = elem
+		}
+	}
+}
+
 // Scenario encapsulates the conditions on the machine on which the microbenchmarks were run.
 type Scenario struct {
 	Cpu   Cpu
 	Os    Os
 	Label string // Arbitrary string label assigned by the uploader.
+	// Env may change between runs of the same microbenchmarks, e.g. when
+	// the Go toolchain is upgraded, and unlike the fields above it does not
+	// identify them.
+	Env Environment
 }
 
 func (Scenario) VDLReflect(struct {
@@ -216,11 +443,23 @@ func (Scenario) VDLReflect(struct {
 }
 
 func (x Scenario) VDLIsZero() bool { //nolint:gocyclo
-	return x == Scenario{}
+	if x.Cpu != (Cpu{}) {
+		return false
+	}
+	if x.Os != (Os{}) {
+		return false
+	}
+	if x.Label != "" {
+		return false
+	}
+	if !x.Env.VDLIsZero() {
+		return false
+	}
+	return true
 }
 
 func (x Scenario) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
-	if err := enc.StartValue(vdlTypeStruct3); err != nil {
+	if err := enc.StartValue(vdlTypeStruct5); err != nil {
 		return err
 	}
 	if x.Cpu != (Cpu{}) {
@@ -244,6 +483,14 @@ func (x Scenario) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
 			return err
 		}
 	}
+	if !x.Env.VDLIsZero() {
+		if err := enc.NextField(3); err != nil {
+			return err
+		}
+		if err := x.Env.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
 	if err := enc.NextField(-1); err != nil {
 		return err
 	}
@@ -252,7 +499,7 @@ func (x Scenario) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
 
 func (x *Scenario) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 	*x = Scenario{}
-	if err := dec.StartValue(vdlTypeStruct3); err != nil {
+	if err := dec.StartValue(vdlTypeStruct5); err != nil {
 		return err
 	}
 	decType := dec.Type()
@@ -264,8 +511,8 @@ func (x *Scenario) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 		case index == -1:
 			return dec.FinishValue()
 		}
-		if decType != vdlTypeStruct3 {
-			index = vdlTypeStruct3.FieldIndexByName(decType.Field(index).Name)
+		if decType != vdlTypeStruct5 {
+			index = vdlTypeStruct5.FieldIndexByName(decType.Field(index).Name)
 			if index == -1 {
 				if err := dec.SkipValue(); err != nil {
 					return err
@@ -289,6 +536,10 @@ func (x *Scenario) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 			default:
 				x.Label = value
 			}
+		case 3:
+			if err := x.Env.VDLRead(dec); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -298,7 +549,8 @@ func (x *Scenario) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 //
 // Typically it would be the commit hash of a git repository or the contents of
 // a manifest of a jiri (https://github.com/vanadium/go.jiri) project and not
-// the complete source code itself.
+// the complete source code itself. It may also be a Source, encoded by
+// Source.SourceCode, which archivers can interpret.
 type SourceCode string
 
 func (SourceCode) VDLReflect(struct {
@@ -311,7 +563,7 @@ func (x SourceCode) VDLIsZero() bool { //nolint:gocyclo
 }
 
 func (x SourceCode) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
-	if err := enc.WriteValueString(vdlTypeString4, string(x)); err != nil {
+	if err := enc.WriteValueString(vdlTypeString6, string(x)); err != nil {
 		return err
 	}
 	return nil
@@ -327,6 +579,302 @@ func (x *SourceCode) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 	return nil
 }
 
+// Project describes the state of one of the repositories of a Source.
+type Project struct {
+	Name       string // Name of the project, e.g. its path in a jiri manifest.
+	Repository string
+	Commit     string
+	Branch     string
+	Dirty      bool
+}
+
+func (Project) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/ben.Project"`
+}) {
+}
+
+func (x Project) VDLIsZero() bool { //nolint:gocyclo
+	return x == Project{}
+}
+
+func (x Project) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct7); err != nil {
+		return err
+	}
+	if x.Name != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Name); err != nil {
+			return err
+		}
+	}
+	if x.Repository != "" {
+		if err := enc.NextFieldValueString(1, vdl.StringType, x.Repository); err != nil {
+			return err
+		}
+	}
+	if x.Commit != "" {
+		if err := enc.NextFieldValueString(2, vdl.StringType, x.Commit); err != nil {
+			return err
+		}
+	}
+	if x.Branch != "" {
+		if err := enc.NextFieldValueString(3, vdl.StringType, x.Branch); err != nil {
+			return err
+		}
+	}
+	if x.Dirty {
+		if err := enc.NextFieldValueBool(4, vdl.BoolType, x.Dirty); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *Project) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = Project{}
+	if err := dec.StartValue(vdlTypeStruct7); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct7 {
+			index = vdlTypeStruct7.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Name = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Repository = value
+			}
+		case 2:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Commit = value
+			}
+		case 3:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Branch = value
+			}
+		case 4:
+			switch value, err := dec.ReadValueBool(); {
+			case err != nil:
+				return err
+			default:
+				x.Dirty = value
+			}
+		}
+	}
+}
+
+// Source is a structured description of the state of the source code used to
+// build the microbenchmarks.
+type Source struct {
+	Repository string // URL of the repository, e.g. "https://github.com/vanadium/core".
+	Commit     string // Hash of the commit that was built.
+	Branch     string // Branch of the commit, if known.
+	Dirty      bool   // Whether the source code had uncommitted changes.
+	// Projects of a build from multiple repositories (e.g., those in a
+	// jiri manifest), in addition to or instead of Repository.
+	Projects []Project
+}
+
+func (Source) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/ben.Source"`
+}) {
+}
+
+func (x Source) VDLIsZero() bool { //nolint:gocyclo
+	if x.Repository != "" {
+		return false
+	}
+	if x.Commit != "" {
+		return false
+	}
+	if x.Branch != "" {
+		return false
+	}
+	if x.Dirty {
+		return false
+	}
+	if len(x.Projects) != 0 {
+		return false
+	}
+	return true
+}
+
+func (x Source) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct8); err != nil {
+		return err
+	}
+	if x.Repository != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Repository); err != nil {
+			return err
+		}
+	}
+	if x.Commit != "" {
+		if err := enc.NextFieldValueString(1, vdl.StringType, x.Commit); err != nil {
+			return err
+		}
+	}
+	if x.Branch != "" {
+		if err := enc.NextFieldValueString(2, vdl.StringType, x.Branch); err != nil {
+			return err
+		}
+	}
+	if x.Dirty {
+		if err := enc.NextFieldValueBool(3, vdl.BoolType, x.Dirty); err != nil {
+			return err
+		}
+	}
+	if len(x.Projects) != 0 {
+		if err := enc.NextField(4); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList2(enc, x.Projects); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonList2(enc vdl.Encoder, x []Project) error {
+	if err := enc.StartValue(vdlTypeList9); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for _, elem := range x {
+		if err := enc.NextEntry(false); err != nil {
+			return err
+		}
+		if err := elem.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *Source) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = Source{}
+	if err := dec.StartValue(vdlTypeStruct8); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct8 {
+			index = vdlTypeStruct8.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Repository = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Commit = value
+			}
+		case 2:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Branch = value
+			}
+		case 3:
+			switch value, err := dec.ReadValueBool(); {
+			case err != nil:
+				return err
+			default:
+				x.Dirty = value
+			}
+		case 4:
+			if err := vdlReadAnonList2(dec, &x.Projects); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonList2(dec vdl.Decoder, x *[]Project) error {
+	if err := dec.StartValue(vdlTypeList9); err != nil {
+		return err
+	}
+	if len := dec.LenHint(); len > 0 {
+		*x = make([]Project, 0, len)
+	} else {
+		*x = nil
+	}
+	for {
+		switch done, err := dec.NextEntry(); {
+		case err != nil:
+			return err
+		case done:
+			return dec.FinishValue()
+		default:
+			var elem Project
+			if err := elem.VDLRead(dec); err != nil {
+				return err
+			}
+			*x = append(*x, elem)
+		}
+	}
+}
+
 // Run encapsulates the results of a single microbenchmark run.
 type Run struct {
 	Name              string // Name of the microbenchmark. <package>.Benchmark<Name> in Go.
@@ -336,6 +884,9 @@ type Run struct {
 	AllocedBytesPerOp uint64  // Size of memory allocations per iteration.
 	MegaBytesPerSec   float64 // Throughput in MB/s.
 	Parallelism       uint32  // For Go, the GOMAXPROCS used during benchmark execution
+	// Custom metrics reported by the microbenchmark, keyed by their unit,
+	// e.g. "p99-ns" or "msgs/s" (reported by b.ReportMetric in Go).
+	Metrics map[string]float64
 }
 
 func (Run) VDLReflect(struct {
@@ -344,11 +895,35 @@ func (Run) VDLReflect(struct {
 }
 
 func (x Run) VDLIsZero() bool { //nolint:gocyclo
-	return x == Run{}
+	if x.Name != "" {
+		return false
+	}
+	if x.Iterations != 0 {
+		return false
+	}
+	if x.NanoSecsPerOp != 0 {
+		return false
+	}
+	if x.AllocsPerOp != 0 {
+		return false
+	}
+	if x.AllocedBytesPerOp != 0 {
+		return false
+	}
+	if x.MegaBytesPerSec != 0 {
+		return false
+	}
+	if x.Parallelism != 0 {
+		return false
+	}
+	if len(x.Metrics) != 0 {
+		return false
+	}
+	return true
 }
 
 func (x Run) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
-	if err := enc.StartValue(vdlTypeStruct5); err != nil {
+	if err := enc.StartValue(vdlTypeStruct10); err != nil {
 		return err
 	}
 	if x.Name != "" {
@@ -386,15 +961,44 @@ func (x Run) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
 			return err
 		}
 	}
+	if len(x.Metrics) != 0 {
+		if err := enc.NextField(7); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonMap3(enc, x.Metrics); err != nil {
+			return err
+		}
+	}
 	if err := enc.NextField(-1); err != nil {
 		return err
 	}
 	return enc.FinishValue()
 }
 
+func vdlWriteAnonMap3(enc vdl.Encoder, x map[string]float64) error {
+	if err := enc.StartValue(vdlTypeMap11); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for key, elem := range x {
+		if err := enc.NextEntryValueString(vdl.StringType, key); err != nil {
+			return err
+		}
+		if err := enc.WriteValueFloat(vdl.Float64Type, elem); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
 func (x *Run) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 	*x = Run{}
-	if err := dec.StartValue(vdlTypeStruct5); err != nil {
+	if err := dec.StartValue(vdlTypeStruct10); err != nil {
 		return err
 	}
 	decType := dec.Type()
@@ -406,8 +1010,8 @@ func (x *Run) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 		case index == -1:
 			return dec.FinishValue()
 		}
-		if decType != vdlTypeStruct5 {
-			index = vdlTypeStruct5.FieldIndexByName(decType.Field(index).Name)
+		if decType != vdlTypeStruct10 {
+			index = vdlTypeStruct10.FieldIndexByName(decType.Field(index).Name)
 			if index == -1 {
 				if err := dec.SkipValue(); err != nil {
 					return err
@@ -465,6 +1069,41 @@ func (x *Run) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 			default:
 				x.Parallelism = uint32(value)
 			}
+		case 7:
+			if err := vdlReadAnonMap3(dec, &x.Metrics); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonMap3(dec vdl.Decoder, x *map[string]float64) error {
+	if err := dec.StartValue(vdlTypeMap11); err != nil {
+		return err
+	}
+	var tmpMap map[string]float64
+	if len := dec.LenHint(); len > 0 {
+		tmpMap = make(map[string]float64, len)
+	}
+	for {
+		switch done, key, err := dec.NextEntryValueString(); {
+		case err != nil:
+			return err
+		case done:
+			*x = tmpMap
+			return dec.FinishValue()
+		default:
+			var elem float64
+			switch value, err := dec.ReadValueFloat(64); {
+			case err != nil:
+				return err
+			default:
+				elem = value
+			}
+			if tmpMap == nil {
+				tmpMap = make(map[string]float64)
+			}
+			tmpMap[key] = elem
 		}
 	}
 }
@@ -491,16 +1130,25 @@ func initializeVDL() struct{} {
 	// Register types.
 	vdl.Register((*Cpu)(nil))
 	vdl.Register((*Os)(nil))
+	vdl.Register((*Environment)(nil))
 	vdl.Register((*Scenario)(nil))
 	vdl.Register((*SourceCode)(nil))
+	vdl.Register((*Project)(nil))
+	vdl.Register((*Source)(nil))
 	vdl.Register((*Run)(nil))
 
 	// Initialize type definitions.
 	vdlTypeStruct1 = vdl.TypeOf((*Cpu)(nil)).Elem()
 	vdlTypeStruct2 = vdl.TypeOf((*Os)(nil)).Elem()
-	vdlTypeStruct3 = vdl.TypeOf((*Scenario)(nil)).Elem()
-	vdlTypeString4 = vdl.TypeOf((*SourceCode)(nil))
-	vdlTypeStruct5 = vdl.TypeOf((*Run)(nil)).Elem()
+	vdlTypeStruct3 = vdl.TypeOf((*Environment)(nil)).Elem()
+	vdlTypeMap4 = vdl.TypeOf((*map[string]string)(nil))
+	vdlTypeStruct5 = vdl.TypeOf((*Scenario)(nil)).Elem()
+	vdlTypeString6 = vdl.TypeOf((*SourceCode)(nil))
+	vdlTypeStruct7 = vdl.TypeOf((*Project)(nil)).Elem()
+	vdlTypeStruct8 = vdl.TypeOf((*Source)(nil)).Elem()
+	vdlTypeList9 = vdl.TypeOf((*[]Project)(nil))
+	vdlTypeStruct10 = vdl.TypeOf((*Run)(nil)).Elem()
+	vdlTypeMap11 = vdl.TypeOf((*map[string]float64)(nil))
 
 	return struct{}{}
 }
@@ -10,16 +10,54 @@ Command benarchd implements a benchmark archival daemon.
 
 It provides a Vanadium RPC service to accept results to archive (typically
 provided by the benup tool - https://godoc.org/v.io/x/ref/services/ben/benup)
-and a web-interface to browse the results.
+and a web-interface to browse the results. The results are also available in
+JSON and CSV formats from the /api/v1/benchmarks, /api/v1/runs and
+/api/v1/sources HTTP endpoints.
 
-A SQL database is used for persistent storage, configured via the --store flag.
+The output of "go test -bench" can also be POSTed to the /api/v1/upload HTTP
+endpoint, with requests authenticated by Vanadium blessings as described in
+https://godoc.org/github.com/vanadium/services/ben/archive#UploadPath Multiple
+runs of a benchmark in one upload (e.g. from "go test -bench -count") are
+samples of it: their median is used to detect regressions, and their variance is
+reported with the results.
+
+A SQL database or a journal file is used for persistent storage, configured via
+the --store flag. Runs older than --retention-keep-all are periodically
+downsampled to one run per benchmark and --retention-interval, and callers with
+blessings matching --admin may delete uploads via the Delete RPC method.
+
+Reading, uploading and deleting results can be restricted by a policy file,
+configured via the --policy flag, as described in
+https://godoc.org/github.com/vanadium/services/ben/benarchd/internal#ReadPolicy
+If reading results requires authentication, browsers are authenticated by
+opening a URL obtained as described in
+https://godoc.org/github.com/vanadium/services/ben/archive#LoginPath
+
+Archived uploads, and the regressions they exhibit, can be announced to the HTTP
+endpoints and Vanadium RPC subscribers configured via the --hooks flag, as
+described in
+https://godoc.org/github.com/vanadium/services/ben/archive#UploadNotification
+Failed deliveries are retried with exponential backoff, and every attempt is
+recorded in the delivery log configured via the --hook-log flag.
+
+The schema of SQL stores is versioned, and migrated to the latest version when
+benarchd starts. The migrate command applies or previews the migrations.
 
 Usage:
 
 	benarchd [flags]
+	benarchd [flags] <command>
+
+The benarchd commands are:
+
+	migrate     Migrates the schema of a SQL store to the latest version
+	help        Display help for commands or topics
 
 The benarchd flags are:
 
+	-admin=
+	  Comma-separated list of blessing patterns of the clients authorized to delete
+	  archived uploads, in addition to those authorized by --policy.
 	-assets=
 	  If set, the directory containing assets (template definitions, css,
 	  javascript files etc.) to use in the web interface. If not set, compiled-in
@@ -28,16 +66,54 @@ The benarchd flags are:
 	  The address of the HTTP server to advertise externally, typically used if the
 	  HTTP server is running behind a proxy or on a machine that is unaware of its
 	  publicly accessible hostname/IP address. If empty, derived from --http.
+	-hook-attempts=5
+	  Maximum number of attempts to deliver a notification to each of --hooks.
+	-hook-backoff=1s
+	  Delay before retrying a failed delivery to one of --hooks, which doubles with
+	  each further retry.
+	-hook-log=
+	  If set, the file that a JSON-encoded record of every attempt to deliver a
+	  notification to --hooks is appended to.
+	-hooks=
+	  Comma-separated list of hooks notified of archived uploads: HTTP(S) URLs to
+	  POST a JSON-encoded UploadNotification to, signed like uploads to
+	  /api/v1/upload, and Vanadium object names of UploadSubscriber servers.
 	-http=127.0.0.1:0
 	  Address on which to serve HTTP requests
 	-name=
 	  Vanadium object name to export this service under
+	-policy=
+	  If set, the JSON file with the policy that authorizes reading, uploading and
+	  deleting results. If not set, anyone can read results, clients with any
+	  recognizable blessing can upload them and only --admin clients can delete
+	  them.
+	-regression-history=10
+	  Number of previously archived runs of a benchmark that new runs are compared
+	  to in order to detect regressions. Zero disables regression detection.
+	-regression-max-pvalue=0.05
+	  If non-zero, regressions are only flagged if the probability of the new
+	  result given the distribution of the previously archived runs is at most this
+	  value.
+	-regression-threshold=0.1
+	  Minimum relative increase in ns/op, allocs/op or B/op over the median of the
+	  previously archived runs that is flagged as a regression.
+	-retention-interval=24h0m0s
+	  Length of the intervals that runs older than --retention-keep-all are
+	  downsampled to.
+	-retention-keep-all=0s
+	  Age up to which all archived runs are kept. Older runs are downsampled to the
+	  run with the median ns/op per benchmark and --retention-interval. Zero
+	  disables downsampling.
 	-store=
 	  Specification of the persistent store to use. Format: <engine>:<parameters>,
-	  where <engine> can be 'sqlite3', 'mysql' or 'sqlconfig'. For 'sqlconfig',
-	  <parameters> is a path to a file containing a JSON-serialized SqlConfig
-	  (https://godoc.org/v.io/x/lib/dbutil#SqlConfig), for the others it is the
-	  data source name (e.g., filename for sqlite3)
+	  where <engine> can be 'sqlite3', 'mysql', 'sqlconfig', 'file' or 'memory'.
+	  For 'sqlconfig', <parameters> is a path to a file containing a
+	  JSON-serialized SqlConfig (https://godoc.org/v.io/x/lib/dbutil#SqlConfig),
+	  for 'file' it is the path to a journal of the archived results (which
+	  requires neither cgo nor a database server), for 'memory' it is ignored and
+	  results are lost when the process exits (the parameters and ':' can be
+	  omitted), and for the others it is the data source name (e.g., filename for
+	  sqlite3)
 
 The global flags are:
 
@@ -122,5 +198,102 @@ The global flags are:
 	  comma-separated list of regexppattern=N settings for file pathname-filtered
 	  logging (without the .go suffix).  E.g. foo/bar/baz.go is matched by patterns
 	  foo/bar/baz or fo.*az or oo/ba or b.z but not by foo/bar/baz.go or fo*az
+
+# Benarchd migrate - Migrates the schema of a SQL store to the latest version
+
+Command migrate applies the migrations of the schema of the SQL store specified
+by --store that have not been applied yet, in order, each in a transaction, and
+exits. With --dry-run, it only prints them.
+
+Usage:
+
+	benarchd migrate [flags]
+
+The benarchd migrate flags are:
+
+	-dry-run=false
+	  If set, only print the migrations that would be applied, and their SQL
+	  statements.
+	-store=
+	  Specification of the SQL store to migrate, in the format of the --store flag
+	  of benarchd.
+
+	-admin=
+	  Comma-separated list of blessing patterns of the clients authorized to delete
+	  archived uploads, in addition to those authorized by --policy.
+	-assets=
+	  If set, the directory containing assets (template definitions, css,
+	  javascript files etc.) to use in the web interface. If not set, compiled-in
+	  assets will be used instead.
+	-exthttp=
+	  The address of the HTTP server to advertise externally, typically used if the
+	  HTTP server is running behind a proxy or on a machine that is unaware of its
+	  publicly accessible hostname/IP address. If empty, derived from --http.
+	-hook-attempts=5
+	  Maximum number of attempts to deliver a notification to each of --hooks.
+	-hook-backoff=1s
+	  Delay before retrying a failed delivery to one of --hooks, which doubles with
+	  each further retry.
+	-hook-log=
+	  If set, the file that a JSON-encoded record of every attempt to deliver a
+	  notification to --hooks is appended to.
+	-hooks=
+	  Comma-separated list of hooks notified of archived uploads: HTTP(S) URLs to
+	  POST a JSON-encoded UploadNotification to, signed like uploads to
+	  /api/v1/upload, and Vanadium object names of UploadSubscriber servers.
+	-http=127.0.0.1:0
+	  Address on which to serve HTTP requests
+	-name=
+	  Vanadium object name to export this service under
+	-policy=
+	  If set, the JSON file with the policy that authorizes reading, uploading and
+	  deleting results. If not set, anyone can read results, clients with any
+	  recognizable blessing can upload them and only --admin clients can delete
+	  them.
+	-regression-history=10
+	  Number of previously archived runs of a benchmark that new runs are compared
+	  to in order to detect regressions. Zero disables regression detection.
+	-regression-max-pvalue=0.05
+	  If non-zero, regressions are only flagged if the probability of the new
+	  result given the distribution of the previously archived runs is at most this
+	  value.
+	-regression-threshold=0.1
+	  Minimum relative increase in ns/op, allocs/op or B/op over the median of the
+	  previously archived runs that is flagged as a regression.
+	-retention-interval=24h0m0s
+	  Length of the intervals that runs older than --retention-keep-all are
+	  downsampled to.
+	-retention-keep-all=0s
+	  Age up to which all archived runs are kept. Older runs are downsampled to the
+	  run with the median ns/op per benchmark and --retention-interval. Zero
+	  disables downsampling.
+
+# Benarchd help - Display help for commands or topics
+
+Help with no args displays the usage of the parent command.
+
+Help with args displays the usage of the specified sub-command or help topic.
+
+"help ..." recursively displays help for all commands and topics.
+
+Usage:
+
+	benarchd help [flags] [command/topic ...]
+
+[command/topic ...] optionally identifies a specific sub-command or help topic.
+
+The benarchd help flags are:
+
+	-style=compact
+	  The formatting style for help output:
+	     compact   - Good for compact cmdline output.
+	     full      - Good for cmdline output, shows all global flags.
+	     godoc     - Good for godoc processing.
+	     shortonly - Only output short description.
+	  Override the default by setting the CMDLINE_STYLE environment variable.
+	-width=<terminal width>
+	  Format output to this target width in runes, or unlimited if width < 0.
+	  Defaults to the terminal width if available.  Override the default by setting
+	  the CMDLINE_WIDTH environment variable.
 */
 package main
@@ -0,0 +1,317 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vanadium/services/ben"
+)
+
+// The API provides the data browsable via the web interface in JSON and CSV
+// formats, for consumption by scripts and dashboards. Its endpoints are:
+//
+//	/api/v1/benchmarks?q=<query>  The benchmarks matching a query (see ParseQuery).
+//	/api/v1/runs?id=<id>          The runs of the benchmark with the given ID.
+//	/api/v1/sources?s=<id>        The description of the source code with the given ID,
+//	                              and its structured form (see ben.Source), if any.
+//
+// Results are uploaded to archive.UploadPath, which is served by the handler
+// returned by NewUploadHandler.
+//
+// Results are JSON-encoded, unless the format=csv parameter is provided.
+// Lists of benchmarks and runs are paginated using the offset and limit
+// parameters; the URL of the next page, if any, is provided in the "Next"
+// field of JSON results and in the Link header.
+const (
+	apiPrefix       = "/api/v1/"
+	apiDefaultLimit = 100
+	apiMaxLimit     = 1000
+)
+
+// APIRun is a run of a benchmark, as presented by the API.
+type APIRun struct {
+	Run          ben.Run
+	SourceCodeID string
+	UploadTime   time.Time
+	UploadID     string
+	// Stats of the samples of the benchmark in the upload, i.e., of all
+	// the runs with the same UploadID.
+	Stats Stats
+	// Environment recorded with the upload (see ben.Scenario.Env).
+	Environment ben.Environment
+}
+
+func (h *handler) api(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apiError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method))
+		return
+	}
+	offset, limit, err := apiPage(r)
+	if err != nil {
+		apiError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	switch endpoint := strings.TrimPrefix(r.URL.Path, apiPrefix); endpoint {
+	case "benchmarks":
+		query, err := ParseQuery(strings.TrimSpace(r.FormValue("q")))
+		if err != nil {
+			apiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		itr := h.store.Benchmarks(query)
+		defer itr.Close()
+		var bms []Benchmark
+		more, err := paginate(itr, offset, limit, func() error {
+			bms = append(bms, itr.Value())
+			return itr.Err()
+		})
+		if err != nil {
+			apiError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		apiBenchmarks(w, r, bms, nextPage(r, more, offset+limit))
+	case "runs":
+		id := strings.TrimSpace(r.FormValue("id"))
+		if len(id) == 0 {
+			apiError(w, r, http.StatusBadRequest, fmt.Errorf("id must be specified"))
+			return
+		}
+		bm, itr := h.store.Runs(id)
+		defer itr.Close()
+		var runs []APIRun
+		more, err := paginate(itr, offset, limit, func() error {
+			run, code, uploaded := itr.Value()
+			runs = append(runs, APIRun{run, code, uploaded, itr.UploadID(), itr.Stats(), itr.Environment()})
+			return itr.Err()
+		})
+		if err != nil {
+			apiError(w, r, apiStatus(err), err)
+			return
+		}
+		apiRuns(w, r, bm, runs, nextPage(r, more, offset+limit))
+	case "sources":
+		id := strings.TrimSpace(r.FormValue("s"))
+		code, err := h.store.DescribeSource(id)
+		if err != nil {
+			apiError(w, r, apiStatus(err), err)
+			return
+		}
+		apiSource(w, r, id, code)
+	default:
+		apiError(w, r, http.StatusNotFound, fmt.Errorf("no API endpoint %q", endpoint))
+	}
+}
+
+// apiPage returns the offset and limit parameters of the request.
+func apiPage(r *http.Request) (offset, limit int, err error) {
+	limit = apiDefaultLimit
+	if s := r.FormValue("offset"); len(s) > 0 {
+		if offset, err = strconv.Atoi(s); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset %q", s)
+		}
+	}
+	if s := r.FormValue("limit"); len(s) > 0 {
+		if limit, err = strconv.Atoi(s); err != nil || limit <= 0 || limit > apiMaxLimit {
+			return 0, 0, fmt.Errorf("invalid limit %q, must be between 1 and %d", s, apiMaxLimit)
+		}
+	}
+	return offset, limit, nil
+}
+
+// paginate skips the first offset items of itr and calls value for each of
+// the next limit items. It returns true if itr has more items.
+func paginate(itr Iterator, offset, limit int, value func() error) (bool, error) {
+	for i := 0; i < offset+limit; i++ {
+		if !itr.Advance() {
+			return false, itr.Err()
+		}
+		if i < offset {
+			continue
+		}
+		if err := value(); err != nil {
+			return false, err
+		}
+	}
+	more := itr.Advance()
+	return more, itr.Err()
+}
+
+// nextPage returns the URL of the page of results starting at offset if
+// more is true, or the empty string otherwise.
+func nextPage(r *http.Request, more bool, offset int) string {
+	if !more {
+		return ""
+	}
+	u := *r.URL
+	q := u.Query()
+	q.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}
+
+func apiStatus(err error) int {
+	if err == ErrNotFound {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func apiCSV(r *http.Request) bool { return r.FormValue("format") == "csv" }
+
+func apiError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if apiCSV(r) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		fmt.Fprintln(w, "ERROR:", err)
+		return
+	}
+	apiJSON(w, status, struct{ Error string }{err.Error()})
+}
+
+func apiJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v) //nolint:errcheck
+}
+
+func apiLink(w http.ResponseWriter, next string) {
+	if len(next) > 0 {
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next))
+	}
+}
+
+func apiWriteCSV(w http.ResponseWriter, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	cw := csv.NewWriter(w)
+	cw.WriteAll(records) //nolint:errcheck
+}
+
+func apiBenchmarks(w http.ResponseWriter, r *http.Request, bms []Benchmark, next string) {
+	apiLink(w, next)
+	if !apiCSV(r) {
+		if bms == nil {
+			bms = []Benchmark{}
+		}
+		apiJSON(w, http.StatusOK, struct {
+			Benchmarks []Benchmark
+			Next       string `json:",omitempty"`
+		}{bms, next})
+		return
+	}
+	records := [][]string{{"id", "name", "os_name", "os_version", "cpu_architecture", "cpu_description", "cpu_clock_speed_mhz", "uploader", "label", "ns_per_op", "mb_per_sec", "last_update"}}
+	for _, bm := range bms {
+		records = append(records, []string{
+			bm.ID,
+			bm.Name,
+			bm.Scenario.Os.Name,
+			bm.Scenario.Os.Version,
+			bm.Scenario.Cpu.Architecture,
+			bm.Scenario.Cpu.Description,
+			fmtUint(uint64(bm.Scenario.Cpu.ClockSpeedMhz)),
+			bm.Uploader,
+			bm.Scenario.Label,
+			fmtFloat(bm.NanoSecsPerOp),
+			fmtFloat(bm.MegaBytesPerSec),
+			bm.LastUpdate.Format(time.RFC3339Nano),
+		})
+	}
+	apiWriteCSV(w, "benchmarks.csv", records)
+}
+
+func apiRuns(w http.ResponseWriter, r *http.Request, bm Benchmark, runs []APIRun, next string) {
+	apiLink(w, next)
+	if !apiCSV(r) {
+		if runs == nil {
+			runs = []APIRun{}
+		}
+		apiJSON(w, http.StatusOK, struct {
+			Benchmark Benchmark
+			Runs      []APIRun
+			Next      string `json:",omitempty"`
+		}{bm, runs, next})
+		return
+	}
+	// Custom metrics are in additional columns, named after their units.
+	seen := map[string]bool{}
+	var units []string
+	for _, run := range runs {
+		for unit := range run.Run.Metrics {
+			if !seen[unit] {
+				seen[unit] = true
+				units = append(units, unit)
+			}
+		}
+	}
+	sort.Strings(units)
+	header := []string{"name", "iterations", "ns_per_op", "allocs_per_op", "alloced_bytes_per_op", "mb_per_sec", "parallelism", "upload_time", "source_code_id", "upload_id",
+		"samples", "ns_per_op_min", "ns_per_op_median", "ns_per_op_mean", "ns_per_op_stddev", "ns_per_op_ci_low", "ns_per_op_ci_high",
+		"go_version", "gomaxprocs", "cores", "memory_bytes", "kernel", "cpu_governor", "tags"}
+	records := [][]string{append(header, units...)}
+	for _, run := range runs {
+		record := []string{
+			run.Run.Name,
+			fmtUint(run.Run.Iterations),
+			fmtFloat(run.Run.NanoSecsPerOp),
+			fmtUint(run.Run.AllocsPerOp),
+			fmtUint(run.Run.AllocedBytesPerOp),
+			fmtFloat(run.Run.MegaBytesPerSec),
+			fmtUint(uint64(run.Run.Parallelism)),
+			run.UploadTime.Format(time.RFC3339Nano),
+			run.SourceCodeID,
+			run.UploadID,
+		}
+		if st := run.Stats; st.Samples > 0 {
+			record = append(record, strconv.Itoa(st.Samples), fmtFloat(st.Min), fmtFloat(st.Median), fmtFloat(st.Mean), fmtFloat(st.StdDev), fmtFloat(st.CILow), fmtFloat(st.CIHigh))
+		} else {
+			record = append(record, "", "", "", "", "", "", "")
+		}
+		env := run.Environment
+		tags := make([]string, 0, len(env.Tags))
+		for name, value := range env.Tags {
+			tags = append(tags, name+"="+value)
+		}
+		sort.Strings(tags)
+		record = append(record, env.GoVersion, fmtUint(uint64(env.GoMaxProcs)), fmtUint(uint64(env.Cores)), fmtUint(env.MemoryBytes), env.Kernel, env.CpuGovernor, strings.Join(tags, " "))
+		for _, unit := range units {
+			var value string
+			if v, ok := run.Run.Metrics[unit]; ok {
+				value = fmtFloat(v)
+			}
+			record = append(record, value)
+		}
+		records = append(records, record)
+	}
+	apiWriteCSV(w, "runs.csv", records)
+}
+
+func apiSource(w http.ResponseWriter, r *http.Request, id string, code ben.SourceCode) {
+	if !apiCSV(r) {
+		var src *ben.Source
+		if s, ok := code.Source(); ok {
+			src = &s
+		}
+		apiJSON(w, http.StatusOK, struct {
+			ID          string
+			Description ben.SourceCode
+			Source      *ben.Source `json:",omitempty"`
+		}{id, code, src})
+		return
+	}
+	apiWriteCSV(w, "source.csv", [][]string{{"id", "description", "commits"}, {id, string(code), strings.Join(code.Commits(), " ")}})
+}
+
+func fmtFloat(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
+func fmtUint(u uint64) string   { return strconv.FormatUint(u, 10) }
@@ -0,0 +1,166 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben"
+)
+
+func TestAPI(t *testing.T) {
+	store, cleanup := newSQLiteStore(t)
+	defer cleanup()
+	scenario := ben.Scenario{
+		Cpu: ben.Cpu{Architecture: "amd64"},
+		Os:  ben.Os{Name: "linux"},
+		Env: ben.Environment{GoVersion: "go1.22.1", Cores: 4, Tags: map[string]string{"ci": "nightly", "runner": "a"}},
+	}
+	start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		runs := []ben.Run{
+			{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: float64(100 + i)},
+			{Name: "BenchmarkB", Iterations: 20, NanoSecsPerOp: 5, AllocsPerOp: 1},
+			{Name: "BenchmarkC", Iterations: 30, NanoSecsPerOp: 7},
+		}
+		if i == 2 {
+			runs[0].Metrics = map[string]float64{"p99-ns": 150}
+		}
+		if err := store.Save(nil, scenario, ben.SourceCode(fmt.Sprintf("commit%d", i)), "alice", start.Add(time.Duration(i)*time.Hour), runs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assets, err := NewAssets("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHTTPHandler(assets, store, RegressionPolicy{}, nil)
+	get := func(url string, wantStatus int, v interface{}) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != wantStatus {
+			t.Fatalf("GET %v: got status %v, want %v: %s", url, w.Code, wantStatus, w.Body)
+		}
+		if v != nil {
+			if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+				t.Fatalf("GET %v: %v: %s", url, err, w.Body)
+			}
+		}
+		return w
+	}
+
+	// Pagination over benchmarks.
+	var bms struct {
+		Benchmarks []Benchmark
+		Next       string
+	}
+	w := get("/api/v1/benchmarks?q=Benchmark&limit=2", http.StatusOK, &bms)
+	if got, want := len(bms.Benchmarks), 2; got != want {
+		t.Errorf("got %d benchmarks, want %d", got, want)
+	}
+	if got, want := bms.Next, "/api/v1/benchmarks?limit=2&offset=2&q=Benchmark"; got != want {
+		t.Errorf("got next page %q, want %q", got, want)
+	}
+	if got, want := w.Header().Get("Link"), "<"+bms.Next+">; rel=\"next\""; got != want {
+		t.Errorf("got Link %q, want %q", got, want)
+	}
+	seen, next := bms.Benchmarks, bms.Next
+	bms.Benchmarks, bms.Next = nil, ""
+	get(next, http.StatusOK, &bms)
+	if got, want := len(bms.Benchmarks), 1; got != want || bms.Next != "" {
+		t.Errorf("got %d benchmarks and next page %q, want %d and none", got, bms.Next, want)
+	}
+	names := map[string]bool{}
+	for _, bm := range append(seen, bms.Benchmarks...) {
+		names[bm.Name] = true
+	}
+	if want := map[string]bool{"BenchmarkA": true, "BenchmarkB": true, "BenchmarkC": true}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+
+	// Runs of a benchmark.
+	get("/api/v1/benchmarks?q=BenchmarkA", http.StatusOK, &bms)
+	id := bms.Benchmarks[0].ID
+	var runs struct {
+		Benchmark Benchmark
+		Runs      []APIRun
+	}
+	get("/api/v1/runs?id="+id+"&offset=1", http.StatusOK, &runs)
+	if got, want := runs.Benchmark.Name, "BenchmarkA"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := len(runs.Runs), 2; got != want {
+		t.Fatalf("got %d runs, want %d", got, want)
+	}
+	if got, want := runs.Runs[0].Run.NanoSecsPerOp, 101.0; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := runs.Runs[0].SourceCodeID, "commit1"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := runs.Runs[0].Environment, scenario.Env; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// CSV
+	w = get("/api/v1/runs?id="+id+"&format=csv&limit=1", http.StatusOK, nil)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"name", "iterations", "ns_per_op", "allocs_per_op", "alloced_bytes_per_op", "mb_per_sec", "parallelism", "upload_time", "source_code_id", "upload_id", "samples", "ns_per_op_min", "ns_per_op_median", "ns_per_op_mean", "ns_per_op_stddev", "ns_per_op_ci_low", "ns_per_op_ci_high",
+			"go_version", "gomaxprocs", "cores", "memory_bytes", "kernel", "cpu_governor", "tags", "p99-ns"},
+		{"BenchmarkA", "10", "102", "0", "0", "0", "0", records[1][7], "commit2", "3", "1", "102", "102", "102", "0", "102", "102",
+			"go1.22.1", "0", "4", "0", "", "", "ci=nightly runner=a", "150"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %v, want %v", records, want)
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, records[1][7]); err != nil || !ts.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("got upload time %v (%v), want %v", ts, err, start.Add(2*time.Hour))
+	}
+
+	// Sources
+	var src struct{ ID, Description string }
+	get("/api/v1/sources?s=commit1", http.StatusOK, &src)
+	if src.ID != "commit1" || src.Description != "commit1" {
+		t.Errorf("got %+v", src)
+	}
+	structured := ben.Source{Repository: "https://github.com/vanadium/core.git", Commit: "9c1e5a", Branch: "main", Dirty: true}
+	code := structured.SourceCode()
+	if err := store.Save(nil, scenario, code, "alice", start.Add(3*time.Hour), []ben.Run{{Name: "BenchmarkD", Iterations: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	var structuredSrc struct{ Source ben.Source }
+	get("/api/v1/sources?s="+code.ID(), http.StatusOK, &structuredSrc)
+	if !reflect.DeepEqual(structuredSrc.Source, structured) {
+		t.Errorf("got %+v, want %+v", structuredSrc.Source, structured)
+	}
+	// The web interface links to the commit.
+	if body, want := get("/?s="+code.ID(), http.StatusOK, nil).Body.String(), `<a href="https://github.com/vanadium/core/commit/9c1e5a">9c1e5a</a>`; !strings.Contains(body, want) {
+		t.Errorf("got %s, want it to contain %s", body, want)
+	}
+
+	// Errors
+	var apiErr struct{ Error string }
+	get("/api/v1/benchmarks?q=os:linux+os:darwin", http.StatusBadRequest, &apiErr)
+	if apiErr.Error == "" {
+		t.Errorf("no error message")
+	}
+	get("/api/v1/benchmarks?limit=0", http.StatusBadRequest, nil)
+	get("/api/v1/runs?id=12345", http.StatusNotFound, nil)
+	get("/api/v1/runs?id=xyz&format=csv", http.StatusNotFound, nil)
+	get("/api/v1/sources?s=nosuchcommit", http.StatusNotFound, nil)
+	get("/api/v1/nosuchendpoint", http.StatusNotFound, nil)
+}
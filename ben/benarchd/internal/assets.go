@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/vanadium/services/ben"
 	"github.com/vanadium/services/ben/benarchd/internal/assets"
 )
 
@@ -47,6 +48,8 @@ const (
 	tmplNoBenchmarks = "nobenchmarks.tmpl.html"
 	tmplBenchmarks   = "benchmarks.tmpl.html"
 	tmplRuns         = "runs.tmpl.html"
+	tmplCompare      = "compare.tmpl.html"
+	tmplSource       = "source.tmpl.html"
 )
 
 type Assets struct {
@@ -89,6 +92,9 @@ func (a *Assets) Template(name string) (*template.Template, error) {
 			},
 		})
 	}
+	if name == tmplSource {
+		t = t.Funcs(template.FuncMap{"commitURL": ben.CommitURL})
+	}
 	files := []string{name, "styling.tmpl.html", "footer.tmpl.html"}
 	for _, f := range files {
 		data, err := a.File(f)
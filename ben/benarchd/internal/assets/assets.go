@@ -11,12 +11,14 @@
 // badquery.tmpl.html
 // benchmarks.tmpl.html
 // chart.js
+// compare.tmpl.html
 // footer.tmpl.html
 // home.tmpl.html
 // nobenchmarks.tmpl.html
 // runs.tmpl.html
 // sortable.css
 // sortable.js
+// source.tmpl.html
 // styling.tmpl.html
 // DO NOT EDIT!
 
@@ -85,7 +87,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _badqueryTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x41\x6b\xe3\x30\x10\x85\xef\xf9\x15\xb3\xba\xdb\x22\x2c\x2c\x24\x28\x86\x6d\x9b\x73\x5a\xd3\x4b\x29\x25\xa8\x92\x1a\xab\x1d\x4b\x41\x9a\xd8\x31\xc6\xff\xbd\x28\x8e\xdb\x43\x5a\x68\x4f\x9a\x41\xdf\x7b\x7a\x68\x46\xfc\xb9\xd9\x5c\xdf\x3f\xdc\xae\xa1\xa2\x1a\x8b\x99\x48\x07\x1c\x6b\x74\x71\xc5\x2a\xa2\xfd\x92\xf3\xb6\x6d\xf3\xf6\x6f\xee\xc3\x8e\xcf\x17\x8b\x05\x3f\x26\x86\x25\xd6\x48\x5d\xcc\x00\x00\x04\x59\x42\x53\xac\xcb\x72\x53\x2e\xe1\xca\x38\x55\xd5\x32\xbc\x41\x69\xe2\x01\x29\xc2\xff\xa0\x2a\xdb\x18\xc1\x47\xee\xa4\xe9\x7b\x32\xf5\x1e\x25\x19\x60\x91\x3a\xb4\x6e\xc7\x86\x61\x26\xf8\x68\x2b\x9e\xbd\xee\x12\x29\xb4\x6d\x40\xa1\x8c\x71\xc5\x6a\x8d\x19\xca\xce\x1f\x08\x52\xf9\x1a\xcf\x1d\x3b\xc7\xa8\xa5\x75\x97\xec\x76\xab\xbc\x23\xe3\x26\xec\xd2\x73\x17\xac\xfe\xf6\x52\x19\x44\x98\x8a\x2c\x9b\x67\xca\x23\x2b\x04\xd7\xb6\xf9\xa1\xe4\xdf\x28\x99\x60\xfb\x81\x4a\x32\xc1\x4a\xcc\xac\xf2\x2e\xb2\xc2\x84\xe0\x83\xe0\xb6\x78\xec\xfb\xfc\xee\x60\x42\x37\x0c\x4f\x60\x23\x38\x4f\xd0\x48\xb4\x7a\x09\x7d\x9f\xaf\x13\x36\x0c\x93\xdd\x2f\x82\x7c\x99\xfd\xb3\x11\x3c\x7d\xe0\xe5\x78\x5e\xbc\x27\x13\xd8\xe9\xc9\x33\x2e\xf8\x38\x1f\xc1\xc7\xc5\x79\x0f\x00\x00\xff\xff\x5f\x6f\xa4\x6d\x49\x02\x00\x00")
+var _badqueryTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x92\x41\x6b\xe3\x30\x10\x85\xef\xf9\x15\xb3\xba\xdb\x22\x2c\x2c\x24\x28\x86\x6d\x9b\x73\x5a\xd3\x4b\x29\x25\xa8\x92\x1a\xab\x1d\x4b\x41\x9a\xd8\x31\xc6\xff\xbd\x28\x8e\xdb\x43\x5a\x68\x4f\x9a\x41\xdf\x7b\x7a\x68\x46\xfc\xb9\xd9\x5c\xdf\x3f\xdc\xae\xa1\xa2\x1a\x8b\x99\x48\x07\x1c\x6b\x74\x71\xc5\x2a\xa2\xfd\x92\xf3\xb6\x6d\xf3\xf6\x6f\xee\xc3\x8e\xcf\x17\x8b\x05\x3f\x26\x86\x25\xd6\x48\x5d\xcc\x00\x00\x04\x59\x42\x53\xac\xcb\x72\x53\x2e\xe1\xca\x38\x55\xd5\x32\xbc\x41\x69\xe2\x01\x29\xc2\xff\xa0\x2a\xdb\x18\xc1\x47\xee\xa4\xe9\x7b\x32\xf5\x1e\x25\x19\x60\x91\x3a\xb4\x6e\xc7\x86\x61\x26\xf8\x68\x2b\x9e\xbd\xee\x12\x29\xb4\x6d\x40\xa1\x8c\x71\xc5\x6a\x8d\x19\xca\xce\x1f\x08\x52\xf9\x1a\xcf\x1d\x3b\xc7\xa8\xa5\x75\x97\xec\x76\xab\xbc\x23\xe3\x26\xec\xd2\x73\x17\xac\xfe\xf6\x52\x19\x44\x98\x8a\x2c\x9b\x67\xca\x23\x2b\x04\xd7\xb6\xf9\xa1\xe4\xdf\x28\x99\x60\xfb\x81\x4a\x32\xc1\x4a\xcc\xac\xf2\x2e\xb2\xc2\x84\xe0\x83\xe0\xb6\x78\xec\xfb\xfc\xee\x60\x42\x37\x0c\x4f\x60\x23\x38\x4f\xd0\x48\xb4\x7a\x09\x7d\x9f\xaf\x13\x36\x0c\x93\xdd\x2f\x82\x7c\x99\xfd\xb3\x11\x3c\x7d\xe0\xe5\x78\x5e\xbc\x27\x13\xd8\xe9\xc9\x33\x2e\xf8\x38\x1f\xc1\xc7\xc5\x79\x1f\x00\x5f\x6f\xa4\x6d\x49\x02\x00\x00")
 
 func badqueryTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -105,7 +107,7 @@ func badqueryTmplHtml() (*asset, error) {
 	return a, nil
 }
 
-var _benchmarksTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x4d\x8f\xdb\x36\x10\xbd\xef\xaf\x98\xb2\xbd\x4a\x4c\xd2\x14\x45\x0a\x4a\x41\xba\xc9\x21\x40\xd0\x75\xbb\x71\x81\x9e\x0c\x5a\x1a\x5b\x4c\x28\x52\x21\xa9\xf5\x1a\xea\xfe\xf7\x82\x94\x2c\xcb\xf2\xc7\xc6\x5d\xef\xc9\xf2\x70\x38\xf3\x66\xc4\x79\x7c\x10\xfb\xe1\xfd\xcd\xf5\xe7\x7f\x26\x1f\xa0\x70\xa5\x4c\xaf\x98\xff\x81\xfb\x52\x2a\x9b\x90\xc2\xb9\xea\x37\x4a\x57\xab\x55\xbc\xfa\x39\xd6\x66\x49\x5f\xbe\x79\xf3\x86\xde\x7b\x1f\xe2\x7d\x91\xe7\xe9\x15\x00\x00\x73\xc2\x49\x4c\x7f\x47\x95\x15\x25\x37\x5f\xe1\x2f\xb4\xb5\x74\x16\xde\x99\xac\x10\x77\xc8\x68\xeb\x10\x9c\x9b\xc6\x61\x59\x49\xee\x10\x88\x75\x6b\x29\xd4\x92\x3c\x3c\xb4\x71\xa4\x50\x5f\xc1\xa0\x4c\xc2\x0a\xda\x02\xd1\x11\x28\x0c\x2e\x12\x62\xb5\x71\x7c\x2e\x31\xce\xac\xf5\xe9\x69\x9b\x9f\xcd\x75\xbe\x86\x4c\x72\x6b\x13\x52\xe6\x32\xca\xb1\xd4\xe0\x1f\x32\x2d\xb5\x89\xa2\xa5\xc1\x75\xf4\xf2\xc5\x8b\x81\xcd\xe1\xbd\xeb\x16\x7e\xed\x16\xe6\xdc\x22\xf1\x00\x59\x2e\xee\x86\xe1\x24\x5f\xeb\xda\x05\x9f\x2f\x76\xf8\xaf\x7d\x8c\xa2\x85\xb8\xc7\x3c\xf2\x60\xd0\x90\xae\x1f\x25\x17\x6a\x3f\xc8\x6c\x96\x69\xe5\x50\xb9\xce\x0d\x80\x59\xcc\x9c\xd0\xbd\x6f\xf7\x37\x8a\x32\x54\x0e\x4d\xc8\xb3\x34\x22\xef\x1f\xa2\x48\xe9\xc8\x56\x3c\x13\x6a\x19\x8c\xb6\xe0\xb9\x5e\x45\xd1\xab\xbc\xea\xa3\xee\x17\x91\x71\x93\xcf\x66\xb6\xae\x2a\x6d\x9c\x50\xcb\xd0\x81\xa1\xff\x42\x9b\x12\x78\x48\x9e\x90\x1f\x07\x2b\x87\xa2\xf9\xcd\x0b\x81\x32\xdf\x74\xa5\x37\x8c\x36\x02\x30\xa1\xaa\xda\x1d\xdc\x3c\x9b\x85\x35\x02\x6e\x5d\x61\x42\x02\x22\x10\x79\x42\xbe\x11\x50\xbc\xc4\xf0\x70\xc7\x65\x8d\x09\x69\x9a\xf8\xcf\x1a\xcd\xfa\xe1\x81\xd0\xbd\x14\x92\xcf\x51\x1e\x49\x11\xd6\x08\x2c\xb4\xf1\xe1\xd2\x6d\x1c\x46\xc3\xd2\xa8\x50\x9a\x8b\xbb\x91\xa9\x2d\xa0\xc3\x71\x8b\xdc\x64\xc5\x06\xb2\xad\xe7\xa5\x70\x64\x98\x7a\x5e\x3b\xa7\xd5\xa6\x2f\x83\x7f\xed\x63\x14\x19\x2e\x2c\xe6\x3b\xa6\x70\x26\x31\x1f\x57\xd6\x34\x62\x01\x2d\xdc\xf8\x7a\x32\xed\x46\xa4\xc7\xc5\xbb\xb1\xa0\x6f\xbf\x25\x4d\x63\x70\x21\x14\x06\xe7\x6e\x0f\x90\xeb\xc9\x94\x00\x21\xf0\x2f\xd4\x46\x7e\xeb\xda\xf7\x18\x58\x92\x32\xd1\xfb\x70\x87\x46\x70\x19\x89\x4c\x2b\x4b\x52\x83\xa5\xf6\xc3\x2c\xd2\xeb\xc9\x94\x51\x3e\xc6\x8b\x2a\x1f\xa1\x1c\xd6\x70\x73\x7b\x7e\x09\x37\xb7\xcf\x55\xc1\xcd\xed\xd9\x05\x4c\x2b\xa9\xfd\x90\x9f\x5f\xc6\x66\xe7\x73\x15\xb3\x89\x7f\x76\x49\x9f\xfc\x0c\x9c\x5f\xcf\xa7\x76\xaa\x9e\xa7\x98\x10\xfc\xd1\x4a\x18\xf5\x94\x95\x5e\x5d\x1d\x9c\x5d\x46\x3b\x26\x7d\x5e\xa2\x3d\x44\xb3\xed\x35\x83\x52\xf6\x0f\x51\xf4\xf2\x95\x1f\xf2\x73\xf9\x19\xf4\x1d\x9a\x85\xd4\xab\xc8\x66\x46\x4b\xb9\x43\xae\xac\x78\xbd\xbd\x6f\x2d\xa3\xc5\xeb\x9d\xd5\x70\x53\xee\xdc\x8a\xdc\xf1\xa8\xb5\x76\xef\x64\x64\x19\xd4\x07\xbb\x1b\xa2\xcd\xcd\x3b\xbe\x16\xdc\x56\x01\x6c\x6d\x66\x8f\x9f\x5d\x71\x18\xc8\x6c\xd6\x5e\x9a\x83\xf8\x7f\xf0\xd2\x8b\x85\xe2\xff\xc7\x80\x47\xd6\x23\x55\x97\x68\x44\x46\x20\x48\x92\x84\x38\x51\x22\x54\x68\x40\x38\x34\xdc\x1f\x0a\x92\x7a\x1b\xd5\xd5\xd3\x90\xf4\x19\x74\x15\x02\xab\x25\xd8\xb5\x75\x58\x82\x56\xb0\x2a\x44\x56\xc0\xbc\x7f\x81\xb0\x42\x83\x60\x6a\x45\x02\x33\x5d\x24\xef\xf5\x64\x0a\xfe\xba\x12\x0e\x33\x57\x1b\x7c\x24\x6f\xe0\xf4\x8b\x24\x5e\x15\x1a\xea\x96\x92\x72\x30\x9d\x14\x5c\x68\x03\xae\x10\x76\x9b\x9c\x0c\x78\xeb\x49\x79\x37\x94\xf1\xd4\x20\xd6\xc1\xb4\xca\xb9\x3b\x70\x04\x19\x1d\x1f\x6c\xef\x73\xe0\xf8\x7b\x35\x3a\x46\xe1\xd9\xcb\x70\xb5\x44\x88\x3f\x3a\x2c\xed\x88\x70\xe1\xf0\xdc\x78\x63\x7e\x0c\x7d\xcb\x2b\x4a\xab\xfe\x3c\xa7\x3d\x6f\xbf\x15\x79\xd2\x34\xf1\xc7\xf7\xbb\xec\xec\xc5\x8f\x9f\x2f\xaf\x7d\x78\xca\xa8\xcb\x0f\x66\x4c\x03\x37\x79\x1d\xe6\xa7\x60\x16\x02\x75\xbb\x27\x06\x9d\x5b\x7f\x16\x6d\x0c\x4f\xb7\x7b\xca\x50\x6b\xe9\x44\xd5\x09\xae\xdd\x00\xcc\x56\x5c\x9d\xac\xc7\x1b\x3a\x98\x4a\xdf\x62\x66\x27\x68\x6e\x2a\x9f\xcb\x6f\x4d\x95\xed\x92\x1e\x83\x7e\x4e\xb3\x36\x45\x6a\x3b\x40\x78\xfc\xe6\xfb\x69\xa8\x48\xe2\xdb\x0c\x15\x37\x42\xc7\x37\x36\xfe\x1b\x8d\xf5\x17\xcb\x5e\xaf\x87\x4e\xc3\xbe\x9f\xe8\xdb\x1e\x79\x6d\xdb\xd2\x76\x74\x88\x76\x94\xa2\xc3\xd1\xbf\x99\x4b\x36\x29\xab\xea\xf3\xba\x14\xa4\xe7\x16\xde\x75\x55\xc7\xef\xd1\x66\x46\x54\xee\x74\xaf\xbc\xe7\xbb\x01\x67\x5d\xa2\x69\x3b\xe8\xc7\xc9\x06\xb0\x2e\xda\xba\xef\x68\xd2\x56\x15\xf6\xd2\x72\xbf\x33\x5b\xd1\x79\x72\x6a\x2f\x0c\xad\x13\x78\xdb\x56\x05\xc3\x89\xf7\xd6\x09\xc9\x4b\x61\x6c\x9a\xd8\xd3\x71\xcb\xc6\x3e\xec\xc1\x90\x7b\x9c\x0c\x47\x24\x2f\x0c\x29\xf8\x83\x19\x2b\x78\xe8\x08\x38\x60\xd4\xd2\x93\x4d\xf2\xcb\x09\xad\x8a\xc6\x68\xe3\xa5\x6a\xd3\xc4\x1d\xb8\xef\xc7\xc2\xe8\xde\x1d\xc1\x68\xe8\x44\x7a\x4c\xcb\x1e\x17\xb6\x8c\x96\x5c\x6c\x9e\xdb\x83\x0c\xd6\x64\x09\xa1\xfd\xd7\x92\x2f\x96\xa4\x8c\xb6\x6b\xfb\x1f\x60\x16\x5a\x3b\x34\xed\xf7\x97\x2e\x11\xa3\x2d\x3e\x46\xdb\x6f\x42\xff\x05\x00\x00\xff\xff\xa6\x3f\xcf\x72\x24\x12\x00\x00")
+var _benchmarksTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x58\xdf\x8f\xdb\xb6\x0f\x7f\xbf\xbf\x82\x5f\x7d\x87\x61\x03\x66\xab\xd7\x75\x18\xba\xc9\x2e\xba\xb4\x0f\x1d\x8a\x26\x5b\x7a\x05\xf6\x14\x28\x36\x13\xab\x95\x25\x57\x92\x93\x0b\xbc\xfc\xef\x83\x64\x27\x71\x7e\xdd\x35\xed\xdd\xbd\x9c\x42\x51\xe4\x87\x94\x48\x7e\x60\xf6\xbf\x57\xc3\xc1\xfb\x7f\x46\xaf\xa1\x70\xa5\x4c\xaf\x98\xff\x07\xb7\xa5\x54\x36\x21\x85\x73\xd5\x6f\x94\x2e\x97\xcb\x78\xf9\x73\xac\xcd\x9c\x5e\x3f\x7f\xfe\x9c\xde\x7a\x1d\xe2\x75\x91\xe7\xe9\x15\x00\x00\x73\xc2\x49\x4c\xff\x40\x95\x15\x25\x37\x9f\xe0\x6f\xb4\xb5\x74\x16\x5e\x9a\xac\x10\x0b\x64\xb4\x55\x08\xca\x4d\xe3\xb0\xac\x24\x77\x08\xc4\xba\x95\x14\x6a\x4e\xd6\xeb\xd6\x8e\x14\xea\x13\x18\x94\x49\xd8\x41\x5b\x20\x3a\x02\x85\xc1\x59\x42\xac\x36\x8e\x4f\x25\xc6\x99\xb5\xde\x3d\x6d\xfd\xb3\xa9\xce\x57\x90\x49\x6e\x6d\x42\xca\x5c\x46\x39\x96\x1a\xfc\x22\xd3\x52\x9b\x28\x9a\x1b\x5c\x45\xd7\x4f\x9e\xf4\x64\x0e\x6f\x5d\xb7\xf1\x6b\xb7\x31\xe5\x16\x89\x07\xc8\x72\xb1\xe8\x9b\x93\x7c\xa5\x6b\x17\x74\x3e\xda\xfe\xaf\x76\x19\x45\x33\x71\x8b\x79\xe4\xc1\xa0\x21\x5d\x3e\x4a\x2e\xd4\xb1\x91\xc9\x24\xd3\xca\xa1\x72\x9d\x1a\x00\xb3\x98\x39\xa1\xb7\xba\xdd\xcf\x28\xca\x50\x39\x34\xc1\xcf\xdc\x88\x7c\xbb\x88\x22\xa5\x23\x5b\xf1\x4c\xa8\x79\x10\xda\x82\xe7\x7a\x19\x45\x4f\xf3\x6a\x6b\xf5\x38\x88\x8c\x9b\x7c\x32\xb1\x75\x55\x69\xe3\x84\x9a\x87\x0c\xf4\xf5\x67\xda\x94\xc0\x83\xf3\x84\xfc\xbf\xb7\x73\xca\x9a\x3f\x3c\x13\x28\xf3\x4d\x56\xb6\x82\x83\x83\x00\x4c\xa8\xaa\x76\x27\x0f\x4f\x26\x61\x8f\x80\x5b\x55\x98\x90\x80\x08\x44\x9e\x90\xcf\x04\x14\x2f\x31\x2c\x16\x5c\xd6\x98\x90\xa6\x89\xff\xaa\xd1\xac\xd6\x6b\x42\x8f\x5c\x48\x3e\x45\x79\xc6\x45\xd8\x23\x30\xd3\xc6\x9b\x4b\x77\x76\x18\x0d\x5b\x07\x81\xd2\x5c\x2c\x0e\x44\x6d\x00\x1d\x8e\x31\x72\x93\x15\x1b\xc8\xb6\x9e\x96\xc2\x91\xbe\xeb\x69\xed\x9c\x56\x9b\xbc\xf4\x7e\xb5\xcb\x28\x32\x5c\x58\xcc\xf7\x44\xe1\x4d\x62\x7e\x18\x59\xd3\x88\x19\xb4\x70\xe3\xc1\xe8\xa6\x2b\x91\xcd\x1f\xe3\x5d\x59\xd0\x17\x9f\x93\xa6\x31\x38\x13\x0a\x83\x72\x77\x06\xc8\x60\x74\x43\x80\x10\xf8\x17\x6a\x23\x3f\x77\xe9\xbb\x0f\x2c\x49\x99\xd8\xea\x70\x87\x46\x70\x19\x89\x4c\x2b\x4b\x52\x83\xa5\xf6\xc5\x2c\xd2\xc1\xe8\x86\x51\x7e\x88\x17\x55\xbe\x5e\x9f\x8d\x61\x38\xbe\x3c\x84\xe1\xf8\xb1\x22\x18\x8e\x2f\x0e\xe0\xa6\x92\xda\x17\xf9\xe5\x61\x6c\x4e\x3e\x56\x30\x1b\xfb\x17\x87\xf4\xd6\xd7\xc0\xe5\xf1\xbc\x6d\xab\xea\x71\x82\x09\xc6\xef\x8d\x84\x51\xdf\xb2\xd2\xab\xab\x93\xb5\xcb\x68\xd7\x49\x1f\xb7\xd1\x9e\x6a\xb3\xed\x98\x41\x29\xb7\x8b\x28\xba\x7e\xea\x8b\xfc\xd2\xfe\x0c\x7a\x81\x66\x26\xf5\x32\xb2\x99\xd1\xb2\x7f\x1e\x80\x15\xcf\x76\xf3\xd6\x02\xb3\x25\x97\x32\xfd\x61\x77\x79\xbc\x12\x74\x71\x4d\xa7\x5b\x9d\x70\x9b\xdd\x0d\xee\x5d\x5b\xfa\xe7\x78\xf8\xce\x27\xfc\x27\xf8\x8a\xe3\xdf\xf3\xb2\xfa\xdd\xdf\x05\x77\x49\x66\x17\x24\x1d\x8c\x3f\x78\x63\x3f\x32\xda\x62\x62\xb4\x78\xb6\x87\x3c\x4c\xf1\xbd\x89\xcd\x1d\x8f\x5a\x69\xf7\x5e\x0e\x24\xbd\xdc\xc3\xfe\x81\x68\xc3\x0a\x0e\x47\x96\xdb\xb1\x93\x9d\xcc\x1c\xcd\x0e\x57\x9c\x06\x32\x99\xb4\x03\xbd\x67\xff\x1d\x2f\x3d\x91\x29\xbe\xde\x06\xdc\xb3\x1f\xa9\xba\x44\x23\x32\x02\x81\x2e\x25\xc4\x89\x12\xa1\x42\x03\xc2\xa1\xe1\xfe\xc1\x92\xd4\xcb\xa8\xae\xbe\x0d\xc9\xd6\x83\xae\x82\x61\x35\x07\xbb\xb2\x0e\x4b\xd0\x0a\x96\x85\xc8\x0a\xd8\xdd\x3c\x2c\xd1\x20\x98\x5a\x91\xd0\x35\x1f\xc4\xef\x60\x74\x03\x7e\x94\x0a\x87\x99\xab\x0d\xde\xe3\x37\xcc\x9b\x07\x71\xbc\x2c\x34\xd4\x6d\xbb\xcc\xc1\x74\x34\x75\xa6\x0d\xb8\x42\xd8\x9d\x73\xd2\xeb\xa9\xdf\xe4\x77\xd3\xce\xbe\xd5\x88\x75\x70\x53\xe5\xdc\x9d\x78\x82\x8c\x1e\x3e\x6c\x46\x4f\x3e\x7f\xcf\x94\x0f\x51\x00\x34\x8d\xe1\x6a\x8e\x10\xbf\x71\x58\xda\xf5\xfa\x48\xe1\x44\xdd\x78\x61\x7e\x0e\x7d\xdb\xf3\x94\x56\xdb\xf7\x9c\x6e\xfb\xca\x0b\x91\xfb\x36\xf2\xe6\xd5\x41\x0b\x6a\x9a\xd8\xd7\x97\xe7\x65\x3c\x65\xd4\xe5\x27\x3d\xa6\xa1\x6f\x7a\x8e\xe8\xab\x60\x12\x0c\x75\xa7\x47\x06\x9d\x5b\xbd\x17\xad\x0d\x3f\x0a\x8e\x58\xab\xd6\xd2\x89\xaa\x23\x83\xfb\x06\x98\xad\xb8\xba\x33\x1e\x2f\xe8\x60\x2a\x3d\xc6\xcc\x8e\xd0\x0c\x2b\xef\xcb\x1f\x4d\x95\xed\x9c\x9e\x83\x7e\x49\xb2\x36\x41\x6a\xdb\x43\x78\x7e\x2a\x7f\xd7\x67\x4b\xf1\x38\x43\xc5\x8d\xd0\xf1\xd0\xc6\x1f\xd0\x58\x3f\xf4\x8e\x72\xdd\x57\xea\xe7\xfd\x8e\xbc\xc1\xf9\xb4\xb4\x19\xed\xa3\x6d\x9a\x53\x38\xd6\xeb\x47\x48\x52\x56\xd5\x97\x65\x29\xd0\xe2\x1d\xbc\x41\x55\xc7\xaf\xd0\x66\x46\x54\xee\xee\x5c\x79\xcd\x97\xbd\x9e\xf5\x10\x49\xdb\x43\xdf\x34\x67\x61\x3d\x68\xea\xbe\x20\x49\x3b\xc6\xba\xa5\xbd\xc7\x99\xd9\x11\xe2\x3b\xab\xf6\x81\xa1\x75\xe4\x73\x97\xaa\x20\xb8\xe3\xde\x3a\x92\xfb\x50\x18\x9b\x26\xf6\xed\xb8\xed\xc6\xeb\xf5\x19\x93\xf4\x54\xd3\x3c\x45\xc7\xf7\x5a\xf0\x6b\x63\xce\x34\xe0\x80\x51\x4b\xdf\x6c\x92\x5f\xee\xe0\xd1\x68\x8c\x36\x9e\x46\x37\x4d\xdc\x81\xfb\x72\x2c\x8c\x1e\xcd\x08\x46\x43\x26\xd2\x73\x3c\xfb\x3c\xe9\x66\xd4\x7f\x05\xe9\xd6\xed\x43\x06\x6b\xb2\x84\xd0\xed\x97\x9c\x8f\x96\xa4\x8c\xb6\x7b\xc7\x1f\x87\x66\x5a\x3b\x34\xed\xb7\xa1\xce\x11\xa3\x2d\x3e\x46\xdb\xef\x55\xff\x0d\x00\x09\x46\x42\x2c\xc0\x12\x00\x00")
 
 func benchmarksTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -125,7 +127,7 @@ func benchmarksTmplHtml() (*asset, error) {
 	return a, nil
 }
 
-var _chartJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x92\x3f\x6f\xdb\x30\x14\xc4\x77\x7d\x8a\x03\x3a\xd0\x06\x5c\x39\x29\xd0\xa2\x91\x91\xc1\x51\x32\x04\x08\x3a\x24\x6e\x87\x16\x19\x9e\xc9\x27\x89\x30\x45\x0a\x24\xe5\x3f\x0d\xfc\xdd\x0b\xca\x72\xdd\x21\xe8\x24\xe9\xe9\x47\xdd\xe9\xdd\xcd\xe7\x28\x5d\x77\xf0\xba\x6e\x22\x3e\x5d\x5d\x7f\xc1\xaa\x61\xfc\x20\x4b\x4a\xf7\x2d\x96\x7d\x6c\x9c\x0f\x39\x96\xc6\x60\x80\x02\x3c\x07\xf6\x5b\x56\x79\x36\x9f\xe3\x7b\x60\xb8\x0a\xb1\xd1\x01\xc1\xf5\x5e\x32\xa4\x53\x0c\x1d\x50\xbb\x2d\x7b\xcb\x0a\xeb\x03\x08\x77\x2f\xf7\x1f\x43\x3c\x18\x4e\xa7\x8c\x96\x6c\x03\x23\x36\x14\x21\xc9\x62\xcd\xa8\x5c\x6f\x15\xb4\x45\x6c\x18\x4f\x8f\xe5\xc3\xb7\x97\x07\x54\xda\x70\x9e\x65\xb5\x73\xb5\xe1\x5c\x36\xe4\x63\xc8\x8d\x23\x35\x11\xb2\xf7\x9e\x6d\x14\x33\xbc\x75\x24\x37\x54\x73\x28\xf0\x4b\x48\xe7\x79\xe0\xc4\x0c\xc2\x68\xcb\xe2\xf5\x38\x5d\x64\x59\xd5\x5b\x19\xb5\xb3\x50\x9e\x76\x65\x02\x26\x3a\x72\x1b\x66\x60\xc3\xed\x14\x6f\x19\xb0\x25\x0f\x45\x91\x70\x0b\xcb\x3b\x8c\xaa\x5b\x1d\x7a\x32\xfa\x37\xa5\xe3\xf9\x3d\x45\x5a\xd1\xda\xf0\x64\xba\xc8\x30\xe0\x39\x29\x55\x3a\xd3\xb7\x76\x22\x14\x45\x8e\xba\xe5\xa4\x9e\xee\xc5\xbb\x94\xed\xdb\x35\xfb\xc4\xc8\xae\xc7\xc0\x27\x8f\x17\xf0\xd9\xed\xc2\xc9\xdf\x69\x9e\x9c\xb9\x2e\x19\x08\xb8\x1d\xbc\x02\xcd\x72\xaf\x43\x31\x3e\x00\x51\x47\xc3\xc5\xa8\x3a\x1b\x87\xc1\x90\x8d\xac\x56\xbc\x8f\x05\x2a\x32\x81\x4f\x6f\x8e\xa7\xcb\xf6\xfd\x4f\xd8\x30\x77\x9d\xf8\x17\x5c\x93\xdc\xd4\x3e\x25\x54\x3a\xe3\x7c\x01\xf1\xa1\xba\xae\xbe\xf2\xcd\xa8\xb4\xd3\x2a\x36\x05\xc4\xe7\xab\xab\x71\xd2\x70\x6a\x4b\x01\x71\x7d\x73\x1e\xf1\xbe\x33\xce\xb3\xbf\x08\xd2\x10\xc9\x90\x9b\xf2\x54\xaf\xdc\x4f\xe7\xda\xb4\x96\xa1\x6a\xa5\xd1\x72\xb3\x72\xcf\x1c\x38\x8a\xd7\xf3\x2f\x6d\x98\xbb\x47\x7b\x97\xbc\x84\x02\xd1\xf7\x7c\x31\x7a\xfc\xbb\xac\xa1\x02\xff\xcb\xf1\x49\x5b\x3e\xd5\x60\xc8\x3f\xa5\x34\x9c\xc9\x53\x3f\x26\x29\x87\xd9\x79\xe3\xd3\x45\x76\xcc\xfe\x04\x00\x00\xff\xff\x36\xa2\x5c\x0d\x29\x03\x00\x00")
+var _chartJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x3a\xdb\x72\xdb\x38\xb2\xef\xfe\x8a\x9e\x9d\x9a\x90\x8a\x28\x4a\xb2\xe3\x4c\x2c\x2d\x27\x95\x38\xd9\xdd\x54\xe5\x56\xe3\x9d\x39\x0f\x3e\x7a\xa0\x48\x48\xc4\x98\x02\xb8\x00\x24\x5b\x27\xab\x7f\x3f\xd5\x0d\x82\x04\x29\xd9\x9b\xd9\x17\x9b\x04\x1a\x7d\x43\xdf\xa9\xf1\x18\xae\x65\xb5\x57\x7c\x5d\x18\x38\x9f\x4c\x5f\xc2\x3f\x0b\x06\xbf\xa7\x22\xcd\xf9\x76\x03\x6f\xb6\xa6\x90\x4a\xc7\xf0\xa6\x2c\x81\x80\x34\x28\xa6\x99\xda\xb1\x3c\x3e\x1b\x8f\xe1\x37\xcd\x40\xae\xc0\x14\x5c\x83\x96\x5b\x95\x31\xc8\x64\xce\x80\x6b\x58\xcb\x1d\x53\x82\xe5\xb0\xdc\x43\x0a\x6f\x6f\xde\x8d\xb4\xd9\x97\x0c\x4f\x95\x3c\x63\x42\x33\x30\x45\x6a\x20\x4b\x05\x2c\x19\xac\xe4\x56\xe4\xc0\x05\x98\x82\xc1\xc7\x0f\xd7\xef\x3f\xdf\xbc\x87\x15\x2f\x59\x7c\x86\x47\x6e\x58\xb9\x1a\x65\x52\x98\x94\x23\xce\x9b\xdf\xff\x0e\x59\x91\x2a\xa3\x2d\x79\x06\x05\xd7\x46\xaa\x3d\xbe\xa6\xb0\x64\x22\x2b\x36\xa9\xba\x8b\x20\x57\xe9\xbd\x80\x95\x92\x1b\x04\x43\x54\x8a\xe9\x6d\xd9\x1e\x1c\xa7\x15\x1f\xef\xa6\xc0\x44\x5e\x49\x2e\x8c\x8e\xcf\xce\x76\xa9\xb2\xe8\x3f\x31\xa3\x78\xa6\x21\x81\xdb\x33\x80\x6f\x2b\xce\xca\x7c\x06\xc1\xe7\x54\xc8\x1b\x96\xe9\xaf\x4c\x7d\xa9\x82\x08\x0c\x37\x25\x9b\x41\x20\xf4\x58\x56\xc1\x21\xf2\x61\xdf\x94\xa5\x3c\x86\x4c\x69\xf5\x18\xfa\x13\x5b\xa7\x6f\xf7\x86\xe1\x81\x1b\x96\x79\x27\x3e\xbd\x1d\x6b\x04\x5e\xcc\x49\x21\x1b\xe2\xec\xf7\xb4\xdc\x32\x50\xcc\x6c\x95\xd0\x24\xce\x8e\x56\xe4\xaa\x06\x70\x1a\x55\x5b\x01\x2a\x82\xfb\x82\x67\x05\x70\x0d\x5b\x91\xb3\x15\xa9\x72\x25\x15\xe2\xcb\xb6\xda\xc8\x4d\x7d\x4a\xdb\xab\x51\x90\x4b\xa6\x41\x48\x03\x8a\x55\x52\x99\xf8\x6c\xb5\x15\x99\xe1\x52\xf8\xf4\x43\x15\xd5\xaf\x03\xf8\x76\x06\xc0\x57\x10\xda\xf7\xd8\x62\xb5\xcb\x50\xf3\x09\xa1\x8a\x7f\xdd\x8a\xd8\xe9\xf6\xdf\xff\x86\x6f\x87\xc1\x6d\x7d\x82\x14\xb1\x98\x9f\x01\x1c\xce\x9a\x13\x74\xa0\x0f\x71\x38\x6b\xf9\x76\xb8\x7c\x4d\xf4\x24\xb2\x12\x58\x7b\xac\x35\x42\x36\xa0\x99\xe2\x4c\x7b\x92\x75\x30\x86\x76\xdb\x4a\x80\x66\xb1\x15\xdc\x68\x48\xe0\xdb\x01\x79\xac\x0f\xaf\xa4\x7a\x9f\x66\x45\xe8\x70\x84\xda\x89\xac\x63\xa4\x73\x0c\xa0\x1c\x00\xe0\x05\x40\xe8\x50\x03\x17\x70\x4a\x3d\x0d\x34\x58\x0e\x6e\xf1\xef\x02\x12\x30\x6a\xcb\xe6\xf5\xde\x81\xfe\x1f\x06\xf3\x33\xf7\xb7\xd6\xdf\x97\xe5\x1f\x2c\x33\xf1\x1d\xdb\xeb\x90\x8e\x0f\x62\x2d\x95\x09\x07\xf1\x26\xad\x5a\xa6\x70\xab\x77\x57\xce\x30\x71\xab\xb1\x45\xfb\x62\xf5\x34\x23\x0e\x0e\x8e\xe4\xc1\x73\x9e\x6b\x59\x4a\x45\xbe\x13\xfc\x78\xb1\xba\x9c\x2e\x2f\x83\x08\x82\x1f\xd9\xd5\x94\xbd\xbc\xa0\xc7\xc9\xe4\xea\xe5\xab\x57\xf4\xb8\x5a\x5d\xbd\x9a\x4c\xe8\xf1\xe7\xab\xcb\xcb\x17\x76\xf5\x2a\x3b\xff\x79\x69\x57\x5f\x4e\x7e\xce\x5f\x2d\xe9\x31\xcb\xf3\xec\xe2\x2a\x58\xcc\x2d\x31\xbd\x5b\x7f\xbe\x81\x04\x82\xc2\x98\x6a\x36\x1e\xdf\xdf\xdf\xc7\xf7\x17\xb1\x54\xeb\xf1\xf9\x64\x32\x19\xeb\xdd\x3a\xb0\x4e\x83\xc1\xe0\xda\x86\x8d\xaa\x94\x46\x03\x4b\xb3\xc2\x79\x4a\x1d\x0f\x9c\x5d\xe0\x73\x13\x47\xe0\x9e\x9b\x82\x96\xd6\x7c\xc7\x04\xf0\x1c\xd1\x61\x84\x83\x6d\x55\xca\x34\x07\xc3\x37\x0c\xb8\x00\x56\xb2\x4d\x0c\x1f\x56\xb4\x59\xa6\x7b\xe0\x9a\x34\x14\x1d\x21\x97\xa6\x60\xe4\x7a\x3a\x63\x22\x55\x5c\x3e\x45\x56\xa7\x1b\x06\x02\xff\xa4\x8a\x11\xf3\x68\xca\x46\x4a\xcf\x70\x5b\xe9\x42\xe4\x22\x02\x9e\x47\x74\x26\x72\xcc\xb4\x66\xdc\x90\xc0\xfb\x59\x31\x93\x15\x6f\xca\x32\x0c\xea\x60\x38\x46\x3e\x5f\xf3\x3c\x09\x60\x08\x4c\x60\x48\xff\xed\xd7\x0f\xd7\x72\x53\x49\xc1\x84\x09\x79\x3e\x88\x20\xf8\x75\x2b\x74\x30\x88\x4d\xc1\x84\x67\xd8\x5b\xa1\x7b\x36\x74\xfb\x8d\xe7\x33\x62\xa6\x4c\x97\xac\x9c\x81\xd8\x96\x65\x44\xba\x98\xd1\xdf\xc3\xa2\xb5\x58\x0c\x1e\x1d\x6e\xa1\xcb\xeb\x57\x25\x37\x5c\xb3\x38\x2d\xcb\xf0\x96\xb6\x7d\x80\xc8\xf9\xd4\x91\x44\x2d\xcc\xeb\x7f\x3d\x26\xd6\xbf\xb6\xd2\xb0\x10\x35\x36\x40\xf9\xde\x36\x47\x82\x81\x45\xbc\x38\x92\xd6\xe6\x92\xd6\x3d\xc9\x1e\x29\x28\x40\xe2\x32\xcd\xed\x64\x31\xf7\xb6\xe9\xde\xfd\xed\xe9\x22\x5e\xf1\xd2\x30\xd5\xe2\x5d\x62\xcc\x74\xfa\x5b\x6e\xe2\xcf\x78\xf3\x49\x92\x58\x13\x78\xf6\x0c\xd7\x3e\xbc\x83\x1f\x92\x04\x78\x3e\xaf\x55\xe7\xa9\xdc\xd7\x92\xa5\xd7\xf5\xf4\xe5\xa6\x65\xb9\x39\xf4\xa7\xcd\x80\x98\xf8\x2e\x4b\xe8\xd0\x21\x73\xa0\xb3\x8d\x45\x38\x17\xf8\x88\xaf\xc8\x5d\xc7\x3c\xe6\x0d\x8e\x56\xd0\xc3\xa0\x4f\x6f\x23\x15\xf3\xe9\xd9\x6b\xb8\x9d\x2c\x62\x22\x02\x09\x04\xb6\x4e\xa9\x69\x05\xf3\xbe\x02\xea\x68\x9e\x49\x91\xa5\xc6\xe2\x6b\xc9\xcd\xfd\xe0\x7a\xe6\x1b\x5d\x8f\x0f\x3f\x63\x00\x66\xdc\x92\x01\x39\x64\xbc\xe2\x4a\x9b\xeb\x82\x97\x79\xcb\x27\x6d\x28\xb6\x91\x3b\x46\x3b\x47\x90\x35\x5d\xfa\xeb\xd7\x24\x8e\xcd\x93\xe9\x6a\x70\x9c\x71\xfc\x0c\xed\x4c\x71\xc3\x52\xbd\x55\x2c\x87\xc4\xc9\xae\xe5\x86\x9d\x48\x63\xbe\x92\x6c\x46\xeb\x02\x2a\xcf\x5c\x3b\xa9\x1f\x5e\x3f\x56\x29\xa0\xed\xb6\x55\xc8\xec\x31\xb0\x5f\x60\x32\xef\x5c\xbb\x7b\xb2\x55\x86\xe5\xdf\x67\x93\xf4\x97\x56\x15\x13\xb9\x55\x68\x13\x16\x6b\xe5\x34\xb8\x07\xa7\xf3\x66\x9c\xa5\xc6\x57\x1c\x53\x4d\xb2\x26\xdc\x86\x3d\x98\x6b\x29\x0c\x13\x06\x6d\xea\x6f\x29\x2f\x29\x14\x53\xfc\xad\xab\xd2\x19\x90\xcb\x28\xe5\xe5\xc5\xf1\xb8\xf1\xb0\xa6\x4e\x49\xa1\xb2\x9e\xea\x42\xbf\xbd\x54\x26\x52\x24\xed\x16\x29\x07\xe3\x4b\x5a\x96\xae\x8a\xad\xd2\x35\xa3\x84\xe1\x95\xb3\x5b\x55\x7a\xe9\xa0\x71\xe7\xad\x2a\x23\x8b\xc3\xca\xe1\xbb\xfb\x57\x44\x83\x10\x30\x84\xe0\x59\xc9\x37\xdc\x24\xd3\x09\x25\x62\x7b\x82\x58\xef\xe2\x6c\xce\x3c\x8a\x15\x37\x4f\x84\xca\xaa\x97\x18\x70\x29\xfe\x43\x4b\x11\x3a\xd5\x77\x8f\xa0\x8c\xee\x08\x5e\x37\xbe\xc7\xef\x95\x92\xcd\x85\x00\x98\x42\xc9\x7b\x68\x77\x7c\x77\x41\x13\xe7\x86\x6d\x34\x24\x04\x71\x6b\x4b\x47\x2c\xa9\x6e\x17\xf3\x06\xed\x0f\x74\xfa\x33\x7b\x30\x2d\xda\x9a\x43\x3a\xed\xa3\x3c\xd6\x5d\x73\xd8\x69\xe3\xc9\xb0\xe4\xe3\x3d\x11\x68\xda\xda\xcd\xd7\xba\xcd\x4b\xba\xa3\x67\x1d\x73\x91\xb3\x87\x2f\xab\x30\x80\x60\x00\x7f\x85\x09\xbc\x06\x0d\x33\x08\xfe\x82\xa6\xa7\xf1\x3e\xff\x12\x74\xf1\x1c\x45\xd9\xa6\x1c\x40\x0d\x2d\x37\xf1\x4d\x0d\x30\xaf\x97\x5d\xe0\xd4\xf1\x17\x6d\x73\xd0\x10\x02\x32\x6d\x1d\x5f\x57\xdb\xf8\x8d\xca\x0a\x6e\x58\x66\xb6\x8a\xb9\xe4\x6d\x77\xde\x31\x9d\x29\x5e\x21\x59\x27\xbc\x45\x36\x4c\x20\x80\xb0\x45\xe1\x01\x22\xf2\x41\xe0\xe2\xab\x07\x8e\xd0\xcb\x4d\xfc\x1b\x55\x5a\x4c\xb5\x94\x48\x90\x13\xf8\x6f\x2d\xfe\x8f\x76\x05\x82\x45\xd0\x6b\x28\x08\xb6\xa7\x9c\xdd\x3a\x34\xe9\x3a\x82\xd4\x18\xa5\x23\xa8\x52\xc5\x84\x69\x55\xc4\x20\x81\x5c\x66\xdb\x0d\x13\x26\xce\x14\x4b\x0d\x7b\x5f\x32\x7c\xfb\x7c\x13\x52\x09\x1a\x81\x49\xd7\x74\x81\x4d\x49\x7f\x07\x5c\x58\x84\x4d\x08\x89\x35\x33\x6f\x8c\x51\x7c\xb9\x35\x2c\xbc\xab\xe9\xdd\xde\x2d\x9a\xcc\x62\x4d\xbd\xa5\x0e\x35\x2f\x9d\xa8\xc6\x06\x3d\x91\x58\x57\x1c\x8c\x52\xa1\x36\xea\x49\x79\x50\xe6\x00\x21\x83\x3e\x18\x22\x67\xbd\x50\xa7\x8d\x9a\xf7\xe9\xf9\x45\x75\x1b\xd5\x04\x35\xe8\xcc\xaa\xc7\x56\xab\x5c\xac\xbd\xfa\xf9\x64\xc9\x8d\xc8\xa8\x20\x3f\xd5\x95\x3d\x1a\xc5\x1b\x81\xee\x79\x6e\x0a\x48\xe0\x72\x32\x89\xa0\x60\x34\xd2\x48\x60\x7a\x35\x81\x21\xd4\x87\xe2\x92\x89\xb5\x29\xe0\x17\x98\xc2\x6b\x98\x5e\xc2\x73\xe8\x6e\xcc\x60\x32\x68\x8c\x9f\xad\x10\xc1\xcb\x49\x04\xca\x21\x9b\x44\x60\x64\x05\x09\x9c\x4f\x22\x58\x4a\x83\x19\x2e\x81\x17\xff\x2d\x09\xd4\xcc\xff\x40\x52\xb3\x3e\xb2\x24\x47\x96\x5c\x44\xbb\xff\x80\xc4\xc9\x32\x22\xd2\xa3\x9a\xec\xfc\xec\x0c\x60\x3c\x86\x2f\xa2\xdc\x13\x64\xab\x49\xdb\xbb\x53\xbb\x4b\x8b\x56\x51\x11\x48\xc1\x80\x46\x1c\x50\x35\x7d\x4b\xdc\x34\xb1\x6d\x0d\xd0\xa9\x14\x1b\xc3\x45\x7e\x09\x7d\xd2\x34\xb5\xbd\xa2\xf5\xb8\x08\x78\x3a\xe9\xb7\x79\xdd\x2f\x0c\x75\xec\x75\x0a\xda\xd6\x6e\x91\xe5\x5b\xcf\x6a\xae\xbf\xd2\x1b\x55\x98\x0d\xee\x43\xdb\x43\x20\xab\x66\xc3\x05\x24\xf0\x41\xac\xb8\xe0\x66\x1f\x81\xd9\xa4\x0f\x90\xc0\xa8\x5d\xd9\xd9\x95\xc9\x77\x36\xf2\xf5\x74\xe8\x08\xa4\xf2\x92\x91\x25\xfa\x29\x35\x45\xbc\xe1\x22\xc4\xf7\x08\xaa\x18\x9b\xc3\xc1\xbc\x01\x4a\x1f\x1a\xa0\xf4\x21\xc4\xf7\x23\xa0\x5d\x0f\x68\x57\x03\x15\x7c\x5d\x74\xb5\x08\xaf\xdd\xf2\x0c\xaa\x98\xc6\x3f\x47\xc9\xc4\xc6\x14\x4b\x38\x49\x88\x4d\xc7\x34\x3e\xc3\x28\x81\x8b\x97\x93\x09\x3c\x07\xcc\xfd\xf3\xb3\x86\xcd\xe1\xd1\xc6\xe1\xac\x61\x8e\xfe\xfd\x42\x89\x87\x1e\x9f\xc3\x34\x9e\xc2\x0c\xa6\xee\x0e\x10\xa8\x51\x93\xf1\xac\x83\xec\x7c\x08\x21\x19\x35\x31\x33\xae\xd9\x73\xef\xcf\xad\x6f\xcc\xe1\xe0\x90\xed\x7d\x64\x3b\x0f\x19\x7a\xc5\xb0\x76\x96\x11\xec\x60\xec\xb8\xa1\x25\xc2\x50\xa3\xa0\xda\xcc\x85\x3d\x9c\x0a\x44\xf0\x8d\x5c\x6f\x66\x3d\xd0\x85\x8d\x59\xfd\x3f\x82\x20\x2b\x53\xad\x83\x19\x04\x74\x36\xb0\xca\xa4\xf3\x8a\x65\x18\x36\xbf\x3d\xcc\x60\x12\xc1\x9e\xfe\x3e\x8d\x6c\xc5\xcb\x72\x86\xa3\x8e\xe9\xea\x15\xbb\x0a\x0e\x91\x65\x88\x50\x52\xb8\xae\x2b\x67\x9a\xb2\x58\xcc\xa8\x28\x42\x3e\x7d\x11\x41\xb0\x92\xc2\x8c\x34\xff\x3f\x16\xcc\x60\x7a\xee\x16\xee\x09\x3d\xf2\xb8\x94\x65\xde\x45\x3b\x1e\xc3\x9b\x07\x0c\x96\x34\x48\x78\x01\x86\x67\x77\x1a\xa4\xa0\x38\x1b\x3b\x51\x4a\x2e\x18\x89\x32\x6d\x28\x4e\x67\xbe\x5e\x23\x78\x38\x9f\xb9\x5b\xa3\x8b\x89\x60\x7f\xde\x03\xd1\x46\xc9\x3b\x9c\x54\xfe\x78\x75\xd5\x93\xee\x69\x2a\x2d\xf6\x3f\x87\xb5\xc9\xb2\x9c\xbc\x18\x38\xfc\x35\x81\x17\x73\xe0\xc3\xa1\x1f\xb4\x76\xce\x54\x9f\x03\x87\x31\xbc\x98\xd3\xce\x13\x2c\xed\xc3\xdd\xe0\x31\x89\xed\x5e\xcb\x54\x9e\xf7\x34\x5e\x5f\xe5\x4a\xaa\x4d\x6a\x6c\xfc\xdb\x0d\xda\xcb\x84\x11\xbc\xa0\x0b\x45\x44\x30\x84\xa3\x6b\x9d\x44\x40\x19\x79\x94\x8a\xac\x90\x0a\xaf\x95\x89\x1e\x8d\xc3\x63\xc2\x5f\x1c\x09\x6f\xc0\xba\x3b\x79\x5b\xd7\xc1\x50\x19\x17\x1e\xcb\x82\xdd\xc3\xbb\xd4\xb0\xd0\x0c\x62\x23\x3f\xca\x2c\x2d\x19\xbe\xdf\x18\xc5\xc5\x3a\xac\x85\x78\x08\xcd\x80\x04\xf0\xdd\x6e\x08\xd3\xef\x11\x64\xc3\xf3\xbc\x64\xc7\xb2\x3c\x1a\x7c\x71\x84\xf5\xe0\x8b\x93\xe1\x48\x11\x12\x7f\xbe\x78\xcb\xf3\x07\xf8\xc9\x5f\xa9\x33\x6d\x5d\xe1\x8f\xc7\xf4\x4d\x43\x48\xae\x19\x2c\x53\x91\x83\xae\x52\x37\x22\x96\x62\xc5\x73\x26\x32\x06\x5c\x18\xa6\x76\x69\xd9\x0c\xe1\x6c\xa6\xd1\xe4\x39\x0e\xd1\x66\x5b\x1a\x5e\x95\x34\x8e\xab\x4a\xac\x4f\x5c\x0f\xd1\xa4\x87\x6e\x5f\x5c\x79\x71\xea\x54\xf0\xc6\x14\xd8\x6d\xc9\xb7\x15\x26\xe7\xa4\xcd\x37\x9d\x6c\x5c\x9d\x68\xc8\x1f\xc2\x3a\x77\xc0\x10\x82\x28\x80\x21\xec\xc3\x3f\x97\x28\xfc\xb6\x9a\x2a\x1f\x79\xdf\xe5\x41\xe3\x17\x9b\x70\x10\x2b\xb6\x63\x4a\xb3\x70\xf0\xdf\x72\x55\xca\xfb\x13\x4c\xe1\xea\x53\x3c\x91\xaf\x56\xb2\xdc\xaf\xa5\x40\x77\x6d\x8b\x81\x8a\x29\xd7\x45\x11\xd3\x83\xf8\x0f\xc9\x05\xb5\x44\x2e\xde\x92\xc9\xa0\x71\xf2\xb2\x1c\xc9\x2a\xcd\xb8\xd9\x07\x33\x98\xc4\xd3\x4b\xcf\x91\x85\x14\xac\xef\xc8\x6d\x13\x69\x09\x7e\xcf\xad\x3c\x29\xfd\x71\x72\xf6\x64\x73\xb1\xc8\x09\x57\x13\x3a\x92\xc7\xb2\xda\xb2\xee\xe4\xb3\xef\x23\xca\x3d\xe8\x7f\xf1\x65\x4f\x9c\xf1\x18\xd0\xbd\x2c\x62\x28\xb9\xb8\xd3\x60\x24\xd9\x7a\xee\x35\x61\x72\x05\xdc\x74\xbe\xe6\xc5\xdf\x5f\x00\x91\xf5\x70\x71\xe7\x92\x6c\x8a\x12\x15\x8a\xad\x66\x10\xbc\xd6\x8f\xcd\x11\xab\xd8\x52\x1b\xf4\x38\xae\x95\xdf\xa4\xec\x8c\xab\xac\x24\x25\x65\x0f\xb3\x56\xc7\x11\x64\xfb\x99\xa7\xe0\x08\xd4\x0c\x2e\x3a\xf7\x7f\x88\x88\xad\x0e\x5e\x4a\xb3\x90\x40\x13\xf7\x6a\x74\x4d\xf0\x73\x81\x0f\xef\xd0\x0e\x74\x6a\x0a\x4d\x17\xec\x27\x6c\x7f\x2e\x55\xc5\x75\x80\xc0\x36\xc0\x77\x0e\x4b\xd3\xf6\xc0\x1b\x96\xf3\x94\xf4\x6d\x51\xbb\x23\x88\xbc\x7e\xf6\x46\x94\x16\xed\xb1\x57\xfb\xd8\x7d\xfc\x11\x5c\x5d\xfe\x04\xd7\x1f\x08\xb9\x9f\x8d\xc8\xdd\x48\xa6\xd1\xf1\x16\xe2\x1f\xb4\x44\x0f\x27\x18\x1f\x04\xdd\xb1\x99\x8b\x7f\xa5\xdf\x8b\xb7\x47\x12\x57\xc3\x23\xc5\xff\x15\x48\xb2\xa3\xae\x83\xef\xe3\xb4\x83\xf7\x7b\x88\xa0\x32\x83\x5e\xff\xe9\x9d\x73\xde\x43\xa4\xfb\x7d\x57\xcf\x1c\xb1\x70\xec\x26\xab\x0b\xec\xd7\xa8\x35\xe3\xf9\x43\x27\xc8\x78\x45\x5d\x53\x7a\x95\x7b\x18\xc1\xab\xa6\xb8\x9b\x4e\xda\xca\x6e\x3a\xe9\x5b\xd9\xb1\xf9\x3e\xea\x0e\x8f\xcf\xd5\xb1\x05\x3a\xf6\x05\xdb\xd1\xbb\x86\xc8\xb1\x58\xe7\x5e\xcb\xe7\x10\xa6\x47\x69\xb8\x6b\xfa\x87\xfe\xa7\x41\xa2\xe1\x5a\x79\xbf\xbb\xea\x7c\x4b\xad\x03\xa0\x91\xa4\x43\x34\x9a\x93\xcd\xbc\x6d\xc8\xa4\xb0\x13\xca\xf6\xe3\x58\xf3\x19\xab\xb5\xf9\xe6\x5b\xb5\x6e\x22\x4e\x6d\xff\x21\x8f\x59\x1c\xd1\x12\xe2\x1b\x20\x2e\xa9\x72\xa6\xec\xb7\x5b\xef\x7b\x5b\x0c\x7f\x93\x0a\xe8\xa3\x7b\xe4\xb3\x29\x57\x35\x94\xcd\xde\x4d\xda\x46\x4c\x8e\x4a\x5a\x6a\x09\x45\xba\x63\x74\x70\x89\xbf\x3e\xd0\xde\x10\xb6\x5f\x16\xd4\x5b\x9c\x66\xe6\xc2\x9b\x4b\x3c\xde\x90\xb6\xdf\xda\xf6\x76\x72\x45\x5f\x8d\xa3\x36\x99\xd8\x19\xe4\x7f\xf8\x3e\x4c\x53\x24\x86\x36\xac\xea\x01\xd8\x87\x77\x38\xc0\x74\x6f\xff\xe4\x1b\x36\x6f\x20\x2b\x48\x1a\x72\xb7\x77\x6c\xdf\x99\x72\xb6\x7e\x71\x04\x86\xac\xa1\x46\x67\x6d\x34\xf4\x09\x0c\xe2\x35\x33\xf8\x80\x55\xa0\x8d\xd5\x33\x50\xf1\x0d\x3d\x5d\xcb\x9c\xe1\xa7\x1c\x6d\x52\xcc\x5b\x2a\xbe\xc1\x87\xa8\xbe\xdd\x19\xdc\x2e\x9a\x0f\x38\x75\x06\xa9\xb6\xba\x08\xab\x4e\x96\xad\x23\x6b\xbd\x77\x7a\x7a\xe0\xb5\xb3\xff\x31\x15\xb5\xe1\x34\x69\x71\xdb\x10\x31\xf7\x09\x42\x52\xdb\xe4\x97\x95\xcb\x1f\xda\x8b\x2d\x3f\x74\x3f\x66\x3c\x7b\x06\xfe\x8f\x10\xa8\x99\xee\xfd\x18\x04\x61\xaa\x98\x74\xe1\x3d\xc6\x37\xa7\xf2\x81\xad\x7c\x92\x06\xe8\xfa\xc3\x47\x79\xdf\xe8\xca\x06\x7b\x7f\xf7\x1f\x7c\x5d\x3c\xe2\xc6\x4d\xf5\xa9\x4c\xab\x8d\x34\x82\xa5\x57\x80\xa6\x94\xdf\x70\x82\x44\x0f\xf3\xa3\x39\x73\xa3\x89\x5a\x0f\xed\x78\xd8\xfe\x72\x22\xa9\xef\xb4\xa9\x07\x9f\x26\x87\x94\xe6\xde\x48\x46\x40\x52\x63\xf2\x6e\xa2\x06\x16\xf0\x13\x9c\x93\x3e\x71\x6c\x66\xa1\x6e\x43\x01\x23\xd4\xd7\x18\xce\x17\x30\x83\xb0\x5e\x16\xb8\x80\x3b\x0b\x18\x82\xbf\xb6\x20\xd0\xde\x17\x8b\x4e\x23\xd6\xfc\x3e\x65\x07\xbf\x24\x30\x65\x57\xfd\x9f\xa6\xec\x60\x4c\xcb\xb1\x91\x5f\x15\xcb\xb8\x46\xc1\x2e\x28\x5d\xfe\x3d\xf0\x07\xb4\x35\x82\x97\xa7\x11\xbc\x3c\x85\xe0\xd3\x29\x04\x17\xa7\x11\x5c\x9c\x42\x70\xd7\x1f\x63\x7f\xde\x6e\x96\x4c\x85\xbb\x1e\x2c\x9e\x75\xc5\x0b\x6a\xe3\xff\x07\x00\x3e\x92\x39\x5f\xe6\x25\x00\x00")
 
 func chartJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -145,7 +147,27 @@ func chartJs() (*asset, error) {
 	return a, nil
 }
 
-var _footerTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcf\xb1\x6e\x03\x21\x0c\x06\xe0\x9d\xa7\x40\xec\x17\xef\x15\x61\x6d\xdf\x22\x22\x87\xc9\x59\x35\x50\x61\xdf\x2d\x88\x77\xaf\x74\x69\x97\xaa\xd9\x2c\xf9\xb3\xf5\xff\x63\x24\xcc\x54\xd1\xba\xdc\x9a\x62\x77\x73\x1a\xff\x1c\xed\xca\x51\xe4\xea\x4a\xe2\xa5\x50\xa5\xe5\x47\x04\x63\xad\x4f\x74\xbc\xd8\xdf\x6e\x8c\x59\x17\xc1\x55\xa9\xd5\x53\x5b\xeb\x77\x7e\xcd\xa9\x7e\x2e\x4c\xa2\xbf\x96\x29\xf8\x68\xb7\x8e\xf9\xea\xc0\x85\x8f\x56\xd0\x43\x0c\x1e\x98\xfe\x11\x9b\xea\x97\xbc\x01\x3c\x48\xb7\xfd\x7e\x59\x5b\x81\x23\xd6\x98\x68\x2f\xf0\x68\x97\x8e\x19\xb4\x23\x42\x89\xa2\xd8\x41\xb0\x1f\xb4\xa2\xc0\x1d\xab\x0b\xef\xe7\xd1\x9f\xef\xb0\xf3\xd9\x11\x12\x1d\xc1\x78\x78\x06\x0d\x66\x0c\xac\x69\x4e\xf3\x1d\x00\x00\xff\xff\xba\xbb\x8c\x27\x34\x01\x00\x00")
+var _compareTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x58\x6d\x6f\xdc\x36\x12\xfe\x9e\x5f\x31\xa7\xf3\x01\x77\xb8\x4a\xca\x1b\x50\xc4\xd5\xaa\x48\xed\x7c\x08\xd0\xd8\x6e\x5c\xa7\x28\x82\x60\xc1\x25\x47\x2b\x26\x14\xa9\x90\x94\xe5\x85\xaa\xfe\xf6\x82\xd4\xcb\xca\xbb\xeb\x38\xce\xba\xa8\x3f\x2d\x35\x33\x9c\x97\x67\xc5\x67\x46\x4c\xfe\x75\x7c\x7a\xf4\xeb\xef\x67\xaf\x20\xb7\x85\x48\x1f\x25\xee\x07\xae\x0a\x21\xcd\x2c\xc8\xad\x2d\x0f\xe3\xb8\xae\xeb\xa8\x7e\x16\x29\xbd\x8c\x9f\xbc\x78\xf1\x22\xbe\x72\x36\x81\xb3\x45\xc2\xd2\x47\x00\x00\x89\xe5\x56\x60\xfa\x13\x4a\x9a\x17\x44\x7f\x82\xb7\x68\x2a\x61\x0d\xbc\xd4\x34\xe7\x97\x98\xc4\x9d\x81\x37\x6e\x1a\x8b\x45\x29\x88\x45\x08\x8c\x5d\x09\x2e\x97\x41\xdb\x76\x7e\x04\x97\x9f\x40\xa3\x98\x79\x0d\x9a\x1c\xd1\x06\x90\x6b\xcc\x66\x81\x51\xda\x92\x85\xc0\x88\x1a\x13\xf4\x71\xbd\x55\xb7\x8e\x32\x7e\x85\x2c\xac\x39\xb3\x39\x34\x90\x29\x69\xc3\x8c\x14\x5c\xac\x0e\xa1\x50\x52\x99\x92\x50\xfc\x01\xba\x40\x91\xe1\x4b\xc9\x33\x4e\x89\xb4\x83\x71\x8d\x7c\x99\xdb\x43\x58\x28\xc1\x06\xbb\x24\xee\x23\x24\x71\x57\x6d\xb2\x50\x6c\x05\x54\x10\x63\x66\x41\xc1\x44\xc8\xb0\x50\xe0\x16\x54\x09\xa5\xc3\x70\xa9\x71\x15\x3e\x79\xfc\x78\x22\xb3\x78\x65\x7b\xc5\xf7\xbd\x62\x41\x0c\xfa\x1a\x12\xc6\x2f\xa7\xee\x04\x59\xa9\xca\x7a\x9b\x8f\x66\xfa\xd4\x2d\xc3\xb0\xab\xd2\x25\x83\x7a\x40\xa1\x20\x5c\x6e\x3b\x99\xcf\xa9\x92\x16\xa5\xed\xcd\x1c\x5c\x48\x2d\x57\xa3\x6d\xff\x18\x86\x14\xa5\x45\xed\xe3\x2c\x35\x67\xe3\x22\x0c\xa5\x0a\x1d\x70\x5c\x2e\xbd\xd0\xe4\x84\xa9\x3a\x0c\x9f\xb2\x72\xf4\xba\x5d\x04\x25\x9a\xcd\xe7\xa6\x2a\x4b\xa5\x2d\x97\x4b\x8f\xc0\xd4\x3e\x53\xba\x00\xe2\x83\xcf\x82\x7f\x4f\x34\xbb\xbc\xb9\xcd\x19\x47\xc1\x06\x54\x46\xc1\xc6\x46\x80\x84\xcb\xb2\xb2\x3b\x37\xcf\xe7\x5e\x17\x80\x5d\x95\x38\x0b\x7c\x46\xc0\xd9\x2c\xf8\x1c\x80\x24\x05\xfa\xc5\x25\x11\x15\xce\x82\xa6\x89\x7e\xa9\x50\xaf\xda\x36\x88\xb7\x42\x08\xb2\x40\x71\x43\x08\xaf\x0b\x20\x53\xda\xb9\x4b\xcf\x91\x68\x9a\xc3\x67\xe7\x2b\x89\xbd\x72\xa3\xd4\x98\xf1\xcb\x7f\xb4\x7a\x25\xd8\x50\xbf\x5f\xae\x11\x38\x15\x6c\xaf\xfa\x9d\xbb\xf4\x54\x30\x30\xaa\xd2\x14\x41\x69\xa8\x4a\xa1\x08\x03\xcb\x0b\x7c\xa0\x70\x48\xac\x07\x38\xfc\x72\x0d\xc7\x09\xd6\x7b\xc1\xe1\xdc\xa5\x27\x58\xef\x09\x47\x57\x53\x9f\xd6\x91\x2a\x4a\xa2\x71\x28\xc3\x54\x8b\x82\xdb\x60\x9a\xcc\xa2\xb2\x56\xc9\x01\xab\xc9\x53\xb7\x0c\x43\x4d\xb8\x41\x76\x4d\xe4\x49\x0b\xd9\xb4\xd6\x24\x76\x27\x36\x7d\xb4\x33\xb3\x24\xee\x79\x64\x10\x34\x4d\xcd\x6d\x0e\xd1\x2b\xad\xdb\xf6\x61\x50\x4f\xc2\x47\x23\x62\x51\x73\x22\x42\x4e\x95\x34\x41\x8a\x5a\x2b\x9d\xc4\x3c\x6d\x9a\xa8\x6d\x6f\x2b\x0c\x85\xc1\xb6\x6d\x1a\x9e\x81\x54\x16\xa2\xee\x1f\xe0\x46\x49\xf3\xf0\x4b\xe5\x32\x53\xae\xd2\x13\x05\x8b\xa1\x53\x1b\x28\x88\xa5\xb9\x8b\xfc\x7e\x4d\x7b\x1f\x20\x27\x97\x08\xba\x92\xc6\xbd\xbd\xb0\x50\x36\x87\xf7\x03\x29\x7c\x00\x22\x19\xbc\x1f\x0e\xc5\x87\x5b\x41\x93\xac\x6d\xfb\x9f\x51\xa8\x89\x5c\x22\x44\x6f\xd0\x6a\x4e\x8d\xd3\x1f\x70\x38\x9c\x41\xf4\x5a\x32\xbc\xfa\x9b\xc1\xdc\x05\xa5\x37\xa6\x28\xc4\xb8\x08\xc3\x27\x4f\xdd\x69\xb8\xeb\x7f\x00\xea\x12\x75\x26\x54\x1d\x1a\xaa\x95\x98\xee\x07\x48\xf2\xe7\xee\x55\x3b\x21\x05\xba\xd7\x2d\x7f\x7e\x4d\xe9\x07\x9c\x69\x00\x46\x2c\x09\x3b\x69\x7f\x88\x37\x24\x93\xf2\x60\x3a\x01\x5d\xdf\x1c\x0e\xc3\xd3\x66\xaf\xb5\xeb\x21\x6e\x2d\xd3\x5b\x2c\x67\xf3\xdd\x49\xcd\xe7\xdd\x24\x32\xf1\xef\x2a\x4b\x62\x9b\xef\xe1\x03\xfc\xb4\x38\x0b\x54\x89\x9a\x38\x58\xc1\xac\x8c\xc5\x02\x94\x84\x3a\xe7\x34\x9f\xbe\xbf\x35\x6a\xff\xa6\x06\xe9\xe9\xf9\x3d\xc5\x3d\x3a\xbb\x00\xd7\xb9\xb9\x45\x6a\x2b\x8d\xb7\xc4\x3d\x3a\xbb\xb8\xa7\xc0\x75\xae\xfa\xae\x80\x0c\x74\x3f\x43\xbb\x03\x68\x73\x6e\xd6\xc1\x83\xf4\xa2\x33\xd2\x7b\xc6\x4d\x7f\x76\x5d\x67\x3f\x27\x70\x8b\x3e\x94\x55\x81\x9a\xd3\xb1\xc8\x02\x89\x04\x95\x81\xcd\x7b\x86\x51\x19\x34\xcd\x41\x3f\x70\xa4\x4a\xb0\x07\x92\x50\xd7\xf2\x53\x89\xf5\x7e\x09\x6d\x05\xd4\x28\x88\xe5\x97\x08\x34\x77\x2c\xf8\x1d\xfc\x09\xbc\x0b\xcf\x78\x96\xa1\x46\x49\x11\xb8\xf1\x2d\xc6\x58\x62\xb9\xb1\x9c\x12\x21\x56\x30\xf9\x6c\x09\x52\x86\xc2\x92\x3b\xa6\xd6\xf1\x9a\x54\x72\x2b\xa9\x32\xf4\x53\xc5\x00\xc4\x1b\x22\x65\xf8\x5b\xce\xad\xc4\x15\x5c\x80\x45\x63\x3d\xed\xcb\xaa\x58\xa0\x76\x56\x1e\x2a\xda\x8d\x20\x2c\x48\xd7\xa9\xd1\x1d\xe7\x3f\x89\x37\x59\x25\x89\x77\x72\x8f\xfb\xbe\xda\xac\x67\xe8\x16\x07\xd7\xfb\x6d\xd3\x1c\x30\xd7\x32\xb8\xeb\x18\x10\x1d\x3b\x38\x0c\x1c\xf0\xbe\x41\x1f\xb0\xe8\x0d\x12\x53\x69\x5c\xf7\x9d\x2f\x70\x9c\x13\xb2\xaf\x87\x2d\x4d\x48\xff\x5d\xfa\x23\x67\xb3\xa6\x89\xc6\x8f\xdf\xe8\xf5\x31\xfc\x01\x95\x16\x9f\xfb\x6f\x88\xf4\x9a\x76\x60\x7e\x92\x26\xb1\x65\x7b\x67\xe1\x1a\x92\x1f\xe4\xcd\xdc\xb5\xd0\xb6\x9d\x6f\xe4\xb2\x95\xc0\x39\x45\x49\x34\x57\xd1\xa9\x19\x93\x71\x0d\x7c\x6b\xd4\x56\x4a\x58\x5e\xc2\xce\x2c\x9c\x60\x18\xf4\xbf\x29\xf2\x3b\xd4\x86\x2b\x39\x06\xbf\x4f\x2c\x68\x59\xdd\x39\xa5\xa3\xb2\x8a\x5e\x4e\x38\x7f\x5f\x54\xbe\x35\x87\x63\x34\x54\xf3\xd2\xde\x33\x36\xd7\x02\x0e\xdd\xa3\x6d\xef\xdd\xf5\x58\x8b\xef\x2c\x5f\x08\x90\x36\x4d\xa9\xb9\xb4\x19\x04\xff\x89\x9e\x66\x81\x3b\xae\xbe\x03\xdc\x69\x87\xa7\xe8\x1b\x77\x0c\x34\x70\xbe\x66\xcd\xb6\x1d\x27\xc9\xb5\x30\xe8\x87\xd3\xd4\x31\xca\xfd\x80\xf2\xdf\x72\x36\x4d\xf7\x59\x97\xee\xd9\x3b\x47\xb1\x6d\x0b\x72\xd6\x0d\xd3\x6f\x2b\x47\x64\xff\xef\x46\xe9\xee\xe1\x7f\xbb\xc2\x6f\xd3\xe7\x0d\x83\xf5\x68\xbe\xc9\xa3\x49\xec\x93\xbd\xf1\x1b\xee\x2b\x46\xf8\xfe\x46\xcc\xdd\x36\x0d\xd7\x6f\xfe\x55\x05\xa3\xe9\x2c\x88\xc7\xfb\xb9\x8f\x26\x48\x93\xb8\xd3\x6d\x5f\xf9\x65\x4a\x59\xd4\xdd\x8d\x5f\x1f\x36\x89\xbb\x6c\x93\xb8\xbb\x85\xfc\x6b\x00\xf8\x7f\xfe\x7c\x96\x14\x00\x00")
+
+func compareTmplHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_compareTmplHtml,
+		"compare.tmpl.html",
+	)
+}
+
+func compareTmplHtml() (*asset, error) {
+	bytes, err := compareTmplHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "compare.tmpl.html", size: 0, mode: os.FileMode(420), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _footerTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\xcf\xb1\x6e\x03\x21\x0c\x06\xe0\x9d\xa7\x40\xec\x17\xef\x15\x61\x6d\xdf\x22\x22\x87\xc9\x59\x35\x50\x61\xdf\x2d\x88\x77\xaf\x74\x69\x97\xaa\xd9\x2c\xf9\xb3\xf5\xff\x63\x24\xcc\x54\xd1\xba\xdc\x9a\x62\x77\x73\x1a\xff\x1c\xed\xca\x51\xe4\xea\x4a\xe2\xa5\x50\xa5\xe5\x47\x04\x63\xad\x4f\x74\xbc\xd8\xdf\x6e\x8c\x59\x17\xc1\x55\xa9\xd5\x53\x5b\xeb\x77\x7e\xcd\xa9\x7e\x2e\x4c\xa2\xbf\x96\x29\xf8\x68\xb7\x8e\xf9\xea\xc0\x85\x8f\x56\xd0\x43\x0c\x1e\x98\xfe\x11\x9b\xea\x97\xbc\x01\x3c\x48\xb7\xfd\x7e\x59\x5b\x81\x23\xd6\x98\x68\x2f\xf0\x68\x97\x8e\x19\xb4\x23\x42\x89\xa2\xd8\x41\xb0\x1f\xb4\xa2\xc0\x1d\xab\x0b\xef\xe7\xd1\x9f\xef\xb0\xf3\xd9\x11\x12\x1d\xc1\x78\x78\x06\x0d\x66\x0c\xac\x69\x4e\xf3\x3d\x00\xba\xbb\x8c\x27\x34\x01\x00\x00")
 
 func footerTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -165,7 +187,7 @@ func footerTmplHtml() (*asset, error) {
 	return a, nil
 }
 
-var _homeTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x55\x4d\x8f\xdb\x36\x10\xbd\xe7\x57\x4c\x59\xa0\x97\x44\x66\xb7\x59\x04\x58\x87\x56\x91\xa4\x39\x14\x28\x90\xa0\x69\x0a\xf4\xb4\xa0\xc9\x91\xc5\x2c\x3f\xb4\xe4\xd0\xb6\xb0\xc8\x7f\x2f\x24\x4a\x8e\x36\x76\x82\x9c\x38\x9c\x79\xef\x69\xe6\xc1\x1c\x8b\x9f\xfe\x78\xf7\xe6\x9f\xff\xde\xbf\x85\x96\x9c\xad\x9f\x88\xe1\x80\xa3\xb3\x3e\x6d\x58\x4b\xd4\xad\x39\x3f\x1c\x0e\xab\xc3\xf3\x55\x88\x3b\x7e\x75\x73\x73\xc3\x8f\x03\x86\x0d\x58\x94\xba\x7e\x02\x00\x20\xc8\x90\xc5\xfa\x35\x7a\xd5\x3a\x19\xef\xe0\x6f\x4c\xd9\x52\x82\x57\x51\xb5\x66\x8f\x82\x17\xc0\x08\x7e\x78\x20\x74\x9d\x95\x84\xc0\x12\xf5\xd6\xf8\x1d\xfb\xfc\xb9\xe8\x0c\xf7\x09\xb6\x6a\xcc\x11\x75\x75\x30\x9a\x5a\x78\x80\x26\x78\xaa\x1a\xe9\x8c\xed\xd7\xe0\x82\x0f\xa9\x93\x0a\x5f\xc2\x44\xe4\x13\x53\xf0\xd2\x95\xd8\x06\xdd\x0f\x42\x42\x9b\x3d\x28\x2b\x53\xda\x30\xa7\x6d\x65\x65\x1f\x32\xc1\x10\x7e\x4a\xd3\x8d\x4d\x53\x38\x69\xfc\x39\xf6\xf6\x56\x05\x4f\xe8\x4f\xb0\xaf\x14\x77\xd1\xe8\x6f\x94\x14\x5a\x0b\x73\x50\x55\x57\x95\x0a\x96\xd5\x82\x6b\xb3\xff\x21\xc2\x8b\x42\x28\xd0\x26\x44\x07\x52\x91\x09\x7e\xc3\x7e\x9e\xb2\x97\x44\x08\x8f\xd4\x18\xb4\x7a\x9e\xf2\x94\x58\x90\x00\x84\xf1\x5d\xa6\x8b\xc4\xdb\xdb\xb1\xc6\x80\xfa\x0e\x37\x6c\xc8\x33\x30\x7a\xc3\xee\x19\x78\xe9\x70\x08\xf8\x23\x2d\x2b\xb7\x68\xbf\xa1\x35\xd6\x18\x34\x21\x0e\xbc\xfa\x03\xca\xa8\x5a\xb8\xcf\x18\x7b\xc1\xc7\xe2\x62\x96\x2f\xde\x2c\x7a\xdc\x4b\x9b\x71\xc3\x0a\x73\xee\x2a\xe5\xad\x33\xc4\x96\x1f\xdd\x66\xa2\xe0\xe7\xb1\x17\xb7\x12\x56\x55\x94\x26\xa1\x7e\x94\x52\xc1\x86\x88\x7a\x9e\x47\xf0\xc1\xe7\x12\x7f\x90\xae\xb3\x38\x76\x6a\x30\xad\x4b\x3d\x2f\xbb\xb5\xa6\x16\x12\xda\x88\xcd\x86\xf1\xdf\xef\x37\xff\x06\xf7\xd6\xab\xa0\x91\xd5\xa7\x50\x70\x59\x43\x05\xaf\xac\x85\xed\xfc\x40\x12\x1c\x0c\xb5\xa3\x97\x09\x9c\x24\xd5\x1a\xbf\x83\x05\xc5\x9a\xef\x7c\x25\xa4\xb5\x35\x3e\x1f\x9f\xaa\x2e\xaf\xa5\xd3\x2f\xae\x9f\xee\x57\x26\xf0\xfd\x6f\xcf\x79\x42\x95\xa3\xa1\x9e\xd5\x33\x0a\x4e\x28\x38\x43\x4d\xbd\xbd\xfe\xd2\x57\xf0\xa0\x31\xdd\x51\xe8\xa0\xb0\x9b\x10\x81\x5a\x04\x91\x3a\x79\x7a\x1d\x8b\xb7\xc9\xea\x0b\xaa\x03\xb6\x86\x4e\xaa\x3b\xb9\x5b\x4c\x23\xf8\xec\xde\xf4\x23\x08\x1d\x46\x49\x21\x5e\x30\x77\x98\x3a\x24\xa8\xe0\xdd\x88\x19\xfc\x49\x7d\x22\x74\xcf\x00\x57\xbb\xd5\x33\x98\xe7\x1b\xa3\x5f\xee\x73\xa0\x97\x1f\xb7\xd9\x53\x86\xab\xeb\xd5\xaf\xd7\x25\x03\x48\x6a\xf5\xc8\xce\x41\x57\x75\x19\x2a\x78\xf3\xfe\xe3\xac\x35\x5a\x14\x5d\x09\x8e\x18\xfc\x65\x5e\xee\x6c\x90\x1a\x23\x54\xf0\xa7\x46\x4f\x86\x7a\x08\x0d\xcc\xe9\x59\x6d\xbe\xaf\x3f\x49\x8f\x3a\xe0\x99\x4e\x79\x2d\x15\xfc\x35\x9e\x32\x25\xb3\xf3\xa8\x61\xdb\x8f\x4e\x7f\x2d\x37\xa2\xd7\xae\x1f\xcf\x4b\x66\xfe\xf8\x42\xb9\xb0\x81\x96\xe1\xb0\x00\xcf\x97\x74\x13\x02\x61\x2c\x3b\x7a\x82\x0b\x5e\xf6\xab\xe0\xe5\x7f\xe3\xff\x00\x00\x00\xff\xff\x35\xf1\xf7\x01\x48\x06\x00\x00")
+var _homeTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x57\xeb\x6e\xe3\xb8\x15\xfe\x3f\x4f\x71\x56\xad\x07\x33\x8d\x64\xd9\x4e\x32\x1d\x2b\xb2\x17\x49\x26\x59\x0c\xd0\x69\x82\x5c\x16\xed\xfe\x68\x40\x49\xc7\x12\x37\xbc\x28\x24\xe5\x0b\xa6\xfb\xee\x05\x45\x49\xb6\x63\x27\x93\x02\x0b\x18\x16\x2f\xdf\xf9\xce\x8d\x3c\x24\xe3\x9f\xbe\x5c\x9d\xdf\xfd\xfb\xfa\x02\x0a\xc3\xd9\xf4\x5d\x6c\x3f\xb0\xe4\x4c\xe8\x89\x57\x18\x53\x46\x61\xb8\x58\x2c\xfa\x8b\xc3\xbe\x54\x79\x38\x1c\x8f\xc7\xe1\xd2\x62\x3c\x8b\x45\x92\x4d\xdf\x01\x00\xc4\x86\x1a\x86\xd3\x33\x14\x69\xc1\x89\x7a\x84\x1b\xd4\x15\x33\x1a\x4e\x55\x5a\xd0\x39\xc6\xa1\x03\xd4\xe0\xef\xdf\x0d\xf2\x92\x11\x83\xe0\x69\xb3\x62\x54\xe4\xde\x1f\x7f\x38\x1e\xdb\x6f\x60\xfd\x19\x5d\x62\x16\x2c\x68\x66\x0a\xf8\x0e\x33\x29\x4c\x30\x23\x9c\xb2\x55\x04\x5c\x0a\xa9\x4b\x92\xe2\x09\x34\x82\x61\x23\x19\x87\xce\xaa\x38\x91\xd9\xca\x12\xc5\x19\x9d\x43\xca\x88\xd6\x13\x8f\x67\x2c\x60\x64\x25\x2b\x03\xb6\xf9\xbb\x6e\x7a\x5e\xe3\x05\x27\x54\xec\x62\x1f\x1e\x52\x29\x0c\x8a\x0e\xf6\x8c\x31\x57\x34\x7b\x61\x2a\x45\xc6\xa0\x6d\x04\xc1\x30\x48\x25\xf3\xa6\x71\x98\xd1\xf9\x9b\x04\x3e\x39\x01\x07\x9d\x49\xc5\x81\xa4\x86\x4a\x31\xf1\xfe\xd2\x8c\xee\x23\x31\xb8\x34\x33\x8a\x2c\x6b\xbd\xec\x06\x36\x84\x00\x62\x2a\xca\xca\xec\x15\x7c\x78\xa8\xe7\x3c\x30\xab\x12\x27\x9e\x1d\xf7\x80\x66\x13\xef\xc9\x03\x41\x38\xda\x46\xb8\xc5\xc5\x48\x82\xec\x05\xae\x7a\xce\x83\x99\x54\x56\x6e\x7a\x8b\x44\xa5\x05\x3c\x55\xa8\x56\x71\x58\x4f\x6e\xf8\xb2\x8e\xcd\x86\x8d\x73\xc2\x2a\x9c\x78\x4e\xb2\xb5\x4a\x57\x09\xa7\xc6\xdb\x54\x9a\x54\xc6\x48\xd1\xba\xbd\xd1\x73\xcd\x20\x50\x84\x6a\xcc\xb6\x86\x52\xc9\xa4\xc2\xac\xf5\x27\x0e\x6d\x9c\x5d\xfb\x96\xf0\x92\x61\x6d\x29\x45\x1d\xb9\xf9\x6a\xd3\x5a\x46\xa7\x31\x81\x42\xe1\x6c\xe2\x85\x3f\x3f\x4d\x7e\x95\xfc\x42\xa4\x32\x43\x6f\xda\x35\xe3\x90\x4c\x21\x80\x53\xc6\x20\x69\x37\x88\x86\x05\x35\x45\x1d\x4b\x0d\x9c\x98\xb4\xa0\x22\x87\x0d\x11\x46\x5f\xd1\x22\x75\xc4\xa8\xa8\x96\x07\x69\x59\x45\x84\x67\x9f\x8e\x0e\xe6\x7d\x2a\xc3\xf9\xe8\x30\xd4\x98\x56\x8a\x9a\x95\x37\x6d\x51\xd0\xa1\x60\x07\xd5\xd8\x76\xb6\xb6\x4b\x0a\xc8\x50\x3f\x1a\x59\x82\x93\x9e\x49\x05\xa6\x40\x88\x75\x49\xba\xdd\xb1\xb1\x37\xbd\xe9\x1e\x56\x8b\x9d\x42\x49\xd2\x47\x92\xff\xc8\x9b\xde\xe8\x73\xe7\xd0\xd5\xcd\x81\xd4\x51\x46\xd4\x82\x8a\xde\x68\x7c\x10\xd4\xb6\x2b\x7e\x20\x74\xef\xf0\x62\xc8\xb5\x37\xfd\xd0\xf9\x75\x75\x03\x1d\xf8\x23\xb4\x50\x10\xfa\x7d\x6e\x4e\x86\x5c\xef\x75\xce\x89\x4a\x05\x4e\xce\x07\x5c\xa6\x58\x1a\x3b\x75\x7a\xf3\x0d\xce\xaf\xef\xb5\x0f\xa6\x20\x06\x0c\x79\x44\xe0\x52\xa1\xed\x0a\x20\xc0\x29\x63\x54\x63\x2a\x45\xf6\x43\x97\x2e\x7b\xc7\x17\x9d\xe2\xde\xe8\xf3\x2d\xcd\x45\xef\xef\xe7\xbf\xa2\xa2\xb3\x55\x6f\x34\xee\x8d\x8e\x7a\xa3\xcb\x03\x4d\x45\x8a\xd1\x68\x30\xfc\x14\x0c\x46\xc1\x60\xe8\x4d\xc3\xff\x74\x62\x1f\xac\xd0\x7f\x9d\xc8\xc7\xbf\x86\xf0\x1c\xbc\xeb\x9f\x5d\x4f\xd9\x7a\xc0\xca\x5b\x57\xbb\x01\xc7\xe5\xd6\x9e\xaa\x84\x86\xaa\x64\x92\x64\x98\x39\x6e\xb8\xc4\x44\x55\x44\xad\x60\xa8\x8d\x0f\x56\xd3\xda\xd1\x38\x6c\x57\x7e\xb3\x81\x65\x89\x8a\x18\xa9\xf6\x6c\x0c\x1b\x10\xa9\x21\x80\xab\x1a\x63\xd7\xb6\x5e\x69\x83\xdc\x07\xec\xe7\x7d\x1f\xda\x1c\xd6\xad\xf7\x4f\x95\x34\x27\xf7\x49\x25\x4c\x05\xc3\xa3\xfe\xe0\xc8\x8d\x00\x9a\xb4\xbf\x15\x69\xcb\x9b\x96\x15\x04\x36\x4f\x2d\x57\x93\x77\xd7\x58\xa2\x14\xfb\xe5\x1a\x57\x15\x04\xf0\x35\x43\x61\xa8\x59\x81\x9c\xb5\x11\x50\x2d\x5b\xdb\x8f\x7e\x27\x02\x33\x89\x3b\x3c\xae\xd2\x05\xf0\x8f\xfa\x4b\xb4\xa6\xb9\xc0\x0c\x92\x55\xbd\x4b\x9e\xd3\xd5\xe8\x88\xaf\xea\xef\x0e\x97\x96\x95\x4a\x11\x02\xb8\x56\x38\xa3\x4b\x6b\x8f\x25\xf9\xfa\xa5\x6d\x35\x00\x5b\x13\xec\x10\xb1\x59\x6b\xa9\xdd\x5c\x74\x34\x1b\x91\xdd\x20\x49\xce\xa9\xd9\x21\x2e\x88\x2e\x1c\x4f\x03\x78\x83\x1a\x87\x8c\xc6\xe9\x70\x37\x16\xb9\xf4\x21\x97\x9c\x2c\x4b\x25\x53\x6d\xb1\x0a\xb5\x0f\x8f\xa8\x04\x32\x3b\x35\x47\x25\xa4\x0d\xf9\x2f\x12\xe6\xa8\x34\x95\xc2\x87\x5f\xae\xbe\x9d\xfe\xeb\xfa\xe6\xea\xfc\xd6\x07\x51\xf1\x04\x95\x55\x7a\x7e\x7d\xbf\x2d\xdf\x0a\xd8\x15\x6c\x27\x3b\xb6\xe7\x16\xe6\x32\x1a\xf6\x47\xa3\x4d\x53\xa2\xcf\x6b\xed\x51\x89\xca\xd6\x74\x22\xd2\x5d\x0f\x0c\xc9\x21\x80\x3b\x92\xbf\x98\x49\x30\xb2\x55\x27\x4b\x7b\xf8\x12\xc6\x9a\x2d\x44\x8d\x76\x47\x53\x6b\x89\x21\x79\x94\xd2\xf6\x3b\x11\x34\x2f\x0c\x5b\xed\xa6\xdd\x6e\x36\x1f\x2a\x61\xa8\x5d\x49\xf7\xb5\x26\x30\x94\x6f\x46\x9f\x68\x20\x90\x11\x83\xd6\xff\x9b\xcb\x73\x38\x3c\x3c\x1c\xd7\xa0\x6e\x01\x6c\xd5\x83\xe1\x51\xc3\xb8\x31\x72\x37\x3c\x8e\x06\x47\xd1\xe0\xf8\xb7\x1d\x1b\x84\xf6\x81\x30\x56\xa7\x2d\x59\x19\x1b\x76\x9e\x94\xba\x5e\xd8\x06\xb5\x01\xa1\x43\x59\xb6\x98\xba\x79\x16\xca\xd2\x1a\xf3\xed\x2c\xd4\x76\x59\x94\x44\x61\xe6\x22\xf1\x9e\x99\x13\xbf\xfe\x9f\xf8\x60\xcb\xaf\xfb\x9f\xf8\x30\xb1\x12\x3f\x4d\x5a\x9b\x9b\xe2\x3c\x18\x0c\xea\x36\x33\x27\xc3\xfe\x31\xef\x6c\x99\x0c\x76\x0c\xe5\x68\x14\x4d\xed\x96\xaf\xb4\x91\x1c\x9a\xbe\xc2\x52\x2a\xe3\xd2\x45\xd6\x07\xeb\x56\x92\xb6\x6d\xb4\xd9\x62\xce\xb7\xad\xa4\x39\xc2\xa8\x1c\x8f\x03\xa1\xbb\x2e\xd7\xb9\x0e\x3b\x63\xf7\x95\xc1\x7f\xd6\x87\xb7\xbd\x1b\x12\x2a\x6c\x89\x7b\xf9\x84\xfc\x5b\x7b\x22\x4a\xf5\x0a\xea\xe7\x16\x45\x14\x42\xce\x64\xb2\x71\x35\xb0\x05\x4b\x61\x73\x61\xf8\xe0\x0c\x7f\x45\x9f\xad\xfc\x0d\xdb\xc7\xda\x58\x22\xb2\x46\x38\x41\xb3\x40\x14\xa0\x19\xd1\x05\xea\x5a\x99\xc2\xbc\x62\x44\x01\x2e\x4b\x85\xda\x6e\xba\x37\x28\x79\xf9\xa4\x6a\x35\xf7\x6b\xd5\x77\xa8\xb8\x86\x94\x08\x48\x6c\x89\xe1\x09\x15\x6d\x4a\x5e\x26\xbf\xba\x69\x48\x7c\x10\x98\x93\x26\xd1\x65\x5d\xcd\x6c\x3c\x4c\x81\xfc\x47\x1c\x41\x17\x4f\x91\x41\xae\x64\x55\xb6\x7a\xed\xaa\x10\xa6\x40\x8d\xba\x1f\x27\xaa\xb9\xfa\xdd\xc9\x76\xc5\x58\x7a\x50\xcd\x1b\xc6\xd6\xc8\x45\x57\xbe\xf4\x9e\x9a\xe9\x03\xc9\xb2\x57\x0c\x91\x2c\xdb\x34\xe5\x65\xa0\xc0\x45\x03\xac\x0d\x2a\x89\x22\x1c\x0d\xaa\xe6\xba\xb8\xa1\x12\xbe\x7e\xd1\x76\x35\x55\xeb\xda\xf1\x86\x94\xed\xaf\x0c\x2e\x59\xae\xce\xd5\xb7\xf2\x66\x67\xb8\xf5\xbe\xf7\xe2\xd9\xdd\x54\xdf\x13\x5e\x9e\x48\x96\x4d\xd6\xd7\x92\xbb\xc1\x20\xaa\x7f\xbf\xd5\x93\x02\x17\xcd\xe4\xe1\xd6\xa4\x37\xfd\xb3\x19\xed\x7d\xa8\xff\xee\xd9\x2b\xe2\xff\x7f\x92\x6d\x36\xed\x8b\x70\xf7\xd5\x3a\x93\xd2\xa0\x72\x8f\xd6\x06\x1e\x87\xee\xc1\x19\x87\xee\x21\xfd\xbf\x01\x00\x4c\xd1\xaf\xe0\x59\x0f\x00\x00")
 
 func homeTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -185,7 +207,7 @@ func homeTmplHtml() (*asset, error) {
 	return a, nil
 }
 
-var _nobenchmarksTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x4f\x4b\x33\x31\x10\xc6\xef\xfd\x14\xf3\xe6\xbe\x0d\xe5\x05\xa1\x92\x2e\xf8\xef\xaa\x22\x5e\x44\xa4\xc4\x6c\xda\x1d\x9d\x24\x25\x99\x76\x5b\x96\xfd\xee\x12\x53\x8b\x58\x85\x9e\xf2\x84\xfc\x9e\x99\xf0\xcc\xa8\x7f\xd7\x77\x57\x8f\x4f\xf7\x37\xd0\xb2\xa3\x7a\xa4\xf2\x01\x5b\x47\x3e\xcd\x44\xcb\xbc\x3a\x97\xb2\xeb\xba\x71\xf7\x7f\x1c\xe2\x52\x4e\xa6\xd3\xa9\xdc\x66\x46\x64\xd6\xea\xa6\x1e\x01\x00\x28\x46\x26\x5b\x5f\x5a\x6f\x5a\xa7\xe3\x3b\x3c\xd8\xb4\x26\x4e\x70\x11\x4d\x8b\x1b\xab\x64\x01\x3e\xe1\xbe\x67\xeb\x56\xa4\xd9\x82\x48\xbc\x23\xf4\x4b\x31\x0c\x23\x25\x4b\x3d\xf5\x1a\x9a\x5d\x26\x55\x83\x1b\x30\xa4\x53\x9a\x09\xd7\x50\x45\x7a\x17\xd6\x0c\x59\xbe\xa5\xfd\x4d\xec\xfb\x3b\x8d\xfe\x98\x9d\xcf\x4d\xf0\x6c\xfd\x01\xfb\x51\x71\x19\xb1\xf9\xe3\xc9\x58\x22\xf8\x12\x55\x35\xa9\x4c\x20\x51\x2b\xd9\xe0\xe6\x24\xc3\x59\x31\x14\x14\x0f\xa0\x66\x1b\x51\x53\x85\x26\xf8\x24\x6a\xf4\x8b\xa0\x24\xd6\xb7\x01\xe2\x3e\xb1\x45\x88\xf0\xdc\xf7\xe3\x61\x78\x29\xe6\x93\x5b\xfe\xf2\xc7\xef\x32\x47\x74\x3c\x80\x45\x08\x6c\x63\xce\xff\x80\x2b\x59\x26\xa0\x64\xd9\x89\x8f\x00\x00\x00\xff\xff\x7b\x69\x4d\x38\x24\x02\x00\x00")
+var _nobenchmarksTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x92\x4f\x4b\x33\x31\x10\xc6\xef\xfd\x14\xf3\xe6\xbe\x0d\xe5\x05\xa1\x92\x2e\xf8\xef\xaa\x22\x5e\x44\xa4\xc4\x6c\xda\x1d\x9d\x24\x25\x99\x76\x5b\x96\xfd\xee\x12\x53\x8b\x58\x85\x9e\xf2\x84\xfc\x9e\x99\xf0\xcc\xa8\x7f\xd7\x77\x57\x8f\x4f\xf7\x37\xd0\xb2\xa3\x7a\xa4\xf2\x01\x5b\x47\x3e\xcd\x44\xcb\xbc\x3a\x97\xb2\xeb\xba\x71\xf7\x7f\x1c\xe2\x52\x4e\xa6\xd3\xa9\xdc\x66\x46\x64\xd6\xea\xa6\x1e\x01\x00\x28\x46\x26\x5b\x5f\x5a\x6f\x5a\xa7\xe3\x3b\x3c\xd8\xb4\x26\x4e\x70\x11\x4d\x8b\x1b\xab\x64\x01\x3e\xe1\xbe\x67\xeb\x56\xa4\xd9\x82\x48\xbc\x23\xf4\x4b\x31\x0c\x23\x25\x4b\x3d\xf5\x1a\x9a\x5d\x26\x55\x83\x1b\x30\xa4\x53\x9a\x09\xd7\x50\x45\x7a\x17\xd6\x0c\x59\xbe\xa5\xfd\x4d\xec\xfb\x3b\x8d\xfe\x98\x9d\xcf\x4d\xf0\x6c\xfd\x01\xfb\x51\x71\x19\xb1\xf9\xe3\xc9\x58\x22\xf8\x12\x55\x35\xa9\x4c\x20\x51\x2b\xd9\xe0\xe6\x24\xc3\x59\x31\x14\x14\x0f\xa0\x66\x1b\x51\x53\x85\x26\xf8\x24\x6a\xf4\x8b\xa0\x24\xd6\xb7\x01\xe2\x3e\xb1\x45\x88\xf0\xdc\xf7\xe3\x61\x78\x29\xe6\x93\x5b\xfe\xf2\xc7\xef\x32\x47\x74\x3c\x80\x45\x08\x6c\x63\xce\xff\x80\x2b\x59\x26\xa0\x64\xd9\x89\x8f\x01\x00\x7b\x69\x4d\x38\x24\x02\x00\x00")
 
 func nobenchmarksTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -205,7 +227,7 @@ func nobenchmarksTmplHtml() (*asset, error) {
 	return a, nil
 }
 
-var _runsTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x58\x5f\x8f\xdb\xb8\x11\x7f\xbe\xfb\x14\x53\xbd\x9c\x0d\xac\xa4\xe4\x1a\xa0\x4d\x4e\x56\x91\xec\xa6\xd7\x05\x6e\xb3\x8b\xec\x5d\xd1\xe2\x10\x18\x34\x39\x96\x98\xa5\x48\x1d\x49\xad\xd7\x51\xfd\xdd\x0b\x92\x92\xfc\x77\x73\x4e\xd7\x4d\xd1\x27\xd3\xc3\x99\x1f\xe7\x1f\x67\x86\xca\xfe\x70\x71\x7d\xfe\xf3\x3f\x6f\xde\x42\x69\x2b\x91\x7f\x9b\xb9\x1f\x78\xa8\x84\x34\x93\xa8\xb4\xb6\x7e\x95\xa6\x8b\xc5\x22\x59\xfc\x31\x51\xba\x48\x9f\xbf\x7c\xf9\x32\x7d\x70\x3c\x91\xe3\x45\xc2\xf2\x6f\x01\x00\x32\xcb\xad\xc0\xfc\x0d\x4a\x5a\x56\x44\xdf\xc1\x7b\x34\x8d\xb0\x06\x5e\x6b\x5a\xf2\x7b\xcc\xd2\xc0\xe0\x99\xdb\xd6\x62\x55\x0b\x62\x11\x22\x63\x97\x82\xcb\x22\x5a\xad\x02\x8e\xe0\xf2\x0e\x34\x8a\x89\xdf\x41\x53\x22\xda\x08\x4a\x8d\xf3\x49\x64\x94\xb6\x64\x26\x30\xa1\xc6\x44\xdd\xb9\x9e\x2b\xac\x93\x39\x7f\x40\x16\x2f\x38\xb3\x25\xb4\x30\x57\xd2\xc6\x73\x52\x71\xb1\x7c\x05\x95\x92\xca\xd4\x84\xe2\x0f\x10\x0e\x4a\xb8\x14\x5c\x22\xb4\xc0\xb8\xa9\x05\x59\xbe\x82\x40\x89\x67\x42\xd1\xbb\x81\x8d\x08\x5e\xc8\x98\xa2\xb4\xa8\xa1\x05\x8b\x0f\x36\xf6\xb4\x57\x10\x88\x3d\x67\x96\x6e\xa8\x92\x19\xaa\x79\x6d\xc1\x2e\x6b\x9c\x44\x4e\x28\xfd\x48\xee\x49\xa0\x46\x60\x34\x0d\xce\x35\x9d\x77\x0b\x63\x89\xe5\x34\xa1\xaa\x4a\x69\x49\xb4\x35\xa9\x50\x84\xa1\x4e\x3e\x9a\x28\xcf\xd2\x20\x78\x3c\xb6\xc7\xd8\x91\xcd\xd2\x10\xae\x6c\xa6\xd8\x12\xa8\x20\xc6\x4c\xa2\x8a\x89\x98\x61\xa5\xc0\x2d\xa8\x12\x4a\xc7\x71\xa1\x71\x19\x3f\x7f\xf6\x6c\x83\xe6\xcd\x0e\x1b\x7f\xea\x36\x66\xc4\xa0\x0f\x42\xc6\xf8\xfd\x26\x9c\x20\x4b\xd5\x58\xcf\xf3\xd1\x6c\xfe\x0b\xcb\x38\x0e\x61\x72\xca\xa0\xee\xc3\x58\x11\x2e\xf7\x41\xa6\x53\xaa\xa4\x45\x69\x3b\x36\x97\x3a\x0b\x6e\x4b\x48\x86\x44\xeb\xf2\xc6\xb9\x05\xa9\xe5\x6a\x40\xe9\xfe\xc6\x7d\xec\x1c\x6a\xa1\x39\x1b\x16\x71\x2c\x55\xec\x72\x82\xcb\xc2\x13\x4d\x49\x98\x5a\xc4\xf1\xf7\xac\x1e\xce\xdb\x35\x8e\x12\x1d\x10\x28\x0a\x31\x2c\xe2\xf8\xf9\xf7\xce\x53\x83\xd8\x61\xc1\xe9\xd4\x34\x75\xad\xb4\xe5\xb2\xf0\x2e\xdd\xe0\x07\xc8\xca\x17\x79\x46\xba\x54\xff\xcb\x6f\x93\xb6\x4d\xde\x91\x0a\xe1\x5f\xd0\x68\xf1\x5b\x83\x7a\xb9\x5a\x45\x79\x47\x5d\xad\xb2\x94\xe4\x59\x5a\xbe\xd8\x82\xf0\xd7\x63\x2b\xb6\xc4\x92\x38\x50\xbb\x80\xec\x50\x36\x8c\xee\x2e\xc0\x96\x52\x0e\xd3\xe5\xcb\x36\xcd\x51\xf5\x2e\xc9\x11\xd9\xe1\xb3\xa7\xd3\xe0\x25\xa9\x64\x2c\x9b\x0a\x35\xa7\x51\x7e\x7d\x9b\xa5\x96\x3d\x15\xa5\x6d\x93\x5b\x8a\x92\x68\xae\x92\x6b\xd3\xf9\x06\x46\x3b\xe4\xbf\xa3\x36\x5c\xc9\xd5\x6a\x7c\xe8\xcc\x2c\xdd\xb7\xe6\x04\x06\x9e\xdf\xfc\x72\x6a\x0b\xcf\xeb\x26\xf1\x15\xd5\x22\xb5\x8d\xde\x33\xd5\xed\x5f\x60\xb8\xf1\xbf\x67\xef\x37\xe1\x4f\x7f\xa3\xb6\x30\xce\x5d\x0d\xbc\xad\x11\xd9\x55\xf9\x69\xb5\xea\x79\x4f\xe1\x94\x01\xf9\x44\xbe\x59\xad\xe0\xea\x6f\x9f\x02\xd8\x37\x07\xed\x43\xc9\x86\x2a\x71\xc2\xf0\xfe\x52\x87\x12\x7d\x22\x3b\x7a\x38\x77\xb3\x8f\x4b\xd1\xbd\xc8\xfd\x44\x66\x28\xfe\x1b\xa6\x7a\xe0\x93\xc5\xeb\x78\xfb\xf6\x23\x97\xa5\x7b\xe5\x28\x4b\xfd\x79\x3e\xe0\x9b\x55\xf7\x70\x35\x73\x0c\x9c\x75\x1d\x72\xca\xf8\xbd\x6b\x91\x8c\xdf\x77\xf9\xb2\x09\xb0\xd9\xfa\xa3\x7c\x74\x8b\x02\xa9\x05\xa2\x91\x80\x55\xf0\x49\xa9\xea\x0c\x34\x2f\x4a\x0b\x54\x70\x7a\xe7\x88\x1a\x0d\xda\xf1\x1a\xb0\x5b\xad\x55\xdd\xf8\xbb\xf3\xa7\xeb\x57\xeb\x46\x17\x8c\xff\x3f\xee\x6e\xa0\xee\x51\xcf\x85\x5a\xc4\x86\x6a\x25\xc4\x5e\xb7\x7b\xdf\x48\x73\xe2\x16\xb6\x39\x02\x6e\x0b\xc7\xfd\xf4\xb8\xd7\xdf\xd6\x53\xec\x9a\x76\xa0\x1f\x94\x8f\xe5\x79\x98\x64\x06\x7c\xf8\x9d\xfd\xe1\x36\x80\x1f\x89\x27\x91\xe5\x15\x42\x8d\x1a\xb8\x45\x4d\x5c\x58\xa3\xdc\xd1\x52\x55\x67\xa9\x2d\xbf\xa2\x26\xb2\xa9\x66\xa8\x41\xcd\xa1\xc2\x4a\xe9\x25\x10\x21\x14\xf5\x2a\x99\x5d\x0d\xfd\x96\xf9\x5f\xea\x38\x5b\x5a\x34\x5f\xa0\x2c\xb1\xc8\x82\xd0\xd7\xd7\xba\xc2\x82\x04\x7d\x6b\xad\x28\x1a\x83\xcc\xeb\x68\x90\x2a\xc9\xa2\xfc\xea\x4d\x6a\x9e\xa6\xd2\x56\x3a\x19\x4b\xaa\x1a\x16\x25\x4a\x57\x92\xfc\x43\x6c\x81\x1a\xa1\x09\x4d\x86\x45\x47\xd7\xec\xc7\x2c\xf5\xe7\x22\x8b\x19\x1a\x8a\x92\xb9\x27\x5c\xdf\x11\xd9\x63\x86\x74\x0a\xb2\xf5\x80\xe2\xa2\x67\x54\xa3\x29\x02\x55\x0c\x8f\x57\x2b\xca\x6f\xbd\xd8\xb9\x62\xf8\x95\x42\x99\x5f\xf6\xf9\xf4\xc4\x48\x7d\x71\xf2\xd4\x44\x13\x21\x50\x70\x53\x41\xe3\x32\xc7\x75\x9a\x46\xc2\xac\x7f\x04\x9d\x01\x26\x45\x72\x06\x3f\x5e\x5f\xbd\xfe\xc7\xcd\xfb\xeb\xf3\x5b\x98\x2b\x0d\x3f\xaa\x35\x8b\x89\xf2\x9b\x35\xcc\xbe\x01\xfb\x0d\xd8\xf1\x1c\xa8\x8c\x87\x5e\x03\x6d\xab\x89\x2c\x10\x92\x4b\x8b\x95\x39\x7a\x02\xc9\x87\x6e\xac\x1b\x39\x6d\xdb\xe4\x52\x32\x7c\xe8\x5e\x39\xef\x1b\x99\xdc\x68\xb4\x76\xf9\x33\x0f\xef\x1d\xd7\x2e\x77\x1b\x8f\x55\x4a\x58\x5e\x47\xce\xde\x7d\x98\xcc\xd4\x44\x7e\x36\xa7\x1c\x61\x38\xee\x1d\x91\xea\x16\xa9\xb9\x41\x7d\x5d\xbb\x13\x9d\x78\xee\xc2\xed\x8f\x7e\x6c\xfe\xc9\xdb\x96\xcf\xc1\x23\xbc\xf6\x45\xb1\x93\xef\x50\x77\x68\xbe\xad\x1f\x8d\x85\xec\x8d\xab\x1a\x07\x10\x77\x77\x8e\xc6\xbd\xc2\x82\xf4\xa2\xb7\x48\x07\xd4\x03\xf4\xcf\x63\xfe\x27\xf3\x6d\x1f\xcb\xa7\x43\xae\x9f\xca\x66\xd2\xb6\xfd\x13\x19\x92\x75\x5d\xb8\xbc\x70\x39\x30\x0a\xf5\xc5\x8c\xc3\x83\xf9\x71\xff\x78\x27\xac\xef\xf8\xe7\x9d\x19\xd2\x73\x7d\xa1\x9e\x36\xd5\x6e\xdc\xa0\xb7\x5a\x1f\xbc\x3f\xde\x39\x4a\xb8\x8c\x9c\xfc\x39\xcf\xf8\xe0\x29\x62\x51\x73\x22\x62\x4e\x95\x34\x51\x8e\x5a\x2b\x9d\xa5\x7c\x63\xd4\x3e\xc5\x6c\xbd\x26\x1c\x39\xc1\x66\x69\x45\xb8\xdc\xfe\x62\xe5\x3f\x4d\xa5\xc3\x57\xbc\x2f\xfd\xb4\xd5\x9f\x54\x28\x55\x08\x4c\xc2\x77\xb2\xc4\xa0\xbd\x96\x3f\x29\xc2\xce\x89\x10\x33\x42\xef\x46\xf3\x46\x7a\x45\x46\x63\x68\x07\xc5\xef\x89\x1f\x08\x2a\x03\x13\xc0\x07\xab\x09\xb5\x17\xc4\x92\xd1\x38\xa9\x48\xbd\x16\x61\x9b\x32\x00\x1a\x6d\xa3\x25\xfc\x2a\x71\x01\x17\xc4\xe2\x88\x25\x2e\x2d\x7f\x7d\xf1\x61\x7c\x06\xef\xfc\x24\x92\xd4\x44\x1b\xfc\xab\x50\xc4\xf6\xbb\xcf\x3e\x8c\x3f\xfc\x30\xa0\xac\xc6\xeb\x35\xd3\x64\x71\xee\xf4\x1e\x79\x5d\xce\x80\x29\xda\x54\x28\x6d\x52\xa0\x7d\x2b\xd0\x2d\xdf\x2c\x2f\xd9\xe8\xbb\xe1\x7d\xf2\xdd\x78\x90\xef\x91\xb6\x9d\xb6\xf9\x35\x75\xae\x94\x7b\xac\xf8\xc0\x76\xd1\xc9\xd2\x10\xd4\x2c\x0d\x1f\x78\xff\x1d\x00\x00\xff\xff\xf4\xf1\xd4\x51\xf1\x15\x00\x00")
+var _runsTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x59\x7b\x8f\xdb\xb8\x11\xff\x3b\xf9\x14\x53\x35\x0f\x1b\x58\x49\xb7\x69\xda\x5e\x36\xb2\x82\x64\xb3\xc8\x6d\x91\xcd\x2e\xe2\xbb\xa0\x45\x51\x2c\x68\x71\x6c\x31\x91\x48\x1d\x49\xd9\xeb\x28\xfa\xee\x05\x49\x49\x96\x1f\xfb\xea\xf9\x52\x1c\xfc\x87\xf9\x18\xfe\xe6\x49\xce\x90\x8a\xfe\xf4\xf6\xfc\xf8\xe7\x7f\x5d\x9c\x40\xaa\xf3\x2c\x7e\x18\x99\x3f\xb8\xca\x33\xae\x46\x5e\xaa\x75\x71\x14\x86\x8b\xc5\x22\x58\xfc\x25\x10\x72\x16\x1e\xbe\x78\xf1\x22\xbc\x32\x34\x9e\xa1\x45\x42\xe3\x87\x00\x00\x91\x66\x3a\xc3\xf8\x0d\xf2\x24\xcd\x89\xfc\x02\x1f\x51\x95\x99\x56\xf0\x5a\x26\x29\x9b\x63\x14\x3a\x02\x4b\x5c\x55\x1a\xf3\x22\x23\x1a\xc1\x53\x7a\x99\x31\x3e\xf3\xea\xda\xe1\x64\x8c\x7f\x01\x89\xd9\xc8\xce\xa0\x4a\x11\xb5\x07\xa9\xc4\xe9\xc8\x53\x42\x6a\x32\xc9\x30\x48\x94\xf2\x1a\xbe\x96\xca\xb5\x83\x29\xbb\x42\xea\x2f\x18\xd5\x29\x54\x30\x15\x5c\xfb\x53\x92\xb3\x6c\x79\x04\xb9\xe0\x42\x15\x24\xc1\x97\xe0\x18\x05\x8c\x67\x8c\x23\x54\x40\x99\x2a\x32\xb2\x3c\x02\x37\xe2\x4f\x32\x91\x7c\xe9\xc8\x48\xc6\x66\xdc\x4f\x90\x6b\x94\x50\x81\xc6\x2b\xed\xdb\xb1\x23\x70\x83\x1d\xa5\xc4\x99\x44\xa5\x98\xe0\x50\x41\x22\x32\x21\x8f\xe0\xcf\xc9\xdf\x9e\xfd\xf8\xec\xc7\x8e\x86\x0b\xa6\xb0\x37\xfd\x02\xcd\xef\xa5\x93\x55\xb1\xaf\x78\x04\x2a\x27\x59\xb6\x82\x8d\xc2\x9e\x86\x91\x4a\x24\x2b\x34\xe8\x65\x81\x23\xcf\xc8\x12\x7e\x26\x73\xe2\x46\x3d\x50\x32\x19\x79\x49\x4a\xa4\x0e\x3e\x2b\x2f\x8e\x42\x37\x11\x3f\x8c\x42\xe7\xa9\x68\x22\xe8\x12\x92\x8c\x28\x35\xf2\x72\x9a\xf9\x14\x73\x01\xa6\x61\x05\xf2\xfd\x99\xc4\xa5\x7f\xf8\xc3\x0f\xbd\x31\xab\xb1\x9b\xf8\x7b\x33\x31\x21\x0a\xad\xfd\x23\xca\xe6\x7d\xb8\x8c\x2c\x45\xa9\x2d\xcd\x67\xd5\xef\xb9\xa6\xef\x3b\x0f\x19\x61\x50\xb6\x1e\xcc\x09\xe3\xdb\x20\x97\x97\x89\xe0\x1a\xb9\x6e\xc8\x4c\xd4\x2c\x98\x4e\x21\xe8\x62\xac\x09\x19\x63\x16\x4c\x34\x13\x1d\x4a\xd3\xf5\x5b\xb7\x19\xd4\x99\x64\xb4\x6b\xf8\x3e\x17\xbe\x09\x07\xc6\x67\x76\x50\xa5\x84\x8a\x85\xef\x3f\xa3\x45\xc7\x6f\x53\xb9\x84\x48\x87\x90\x60\x96\x75\x0d\xdf\x3f\x7c\x66\x2c\xd5\x2d\xdb\xbd\xf0\xf2\x52\x95\x45\x21\xa4\x66\x7c\x66\x4d\xda\xa3\x07\x88\xd2\xe7\x71\x44\x9a\x28\x7f\xf5\xeb\xa8\xaa\x82\x0f\x24\x47\xf8\x06\xa5\xcc\x7e\x2d\x51\x2e\xeb\xda\x8b\x9b\xd1\xba\x8e\x42\x12\x47\x61\xfa\x7c\x0d\xc2\xee\x8c\x35\xdf\x12\x4d\x7c\x37\xda\x38\x64\x63\xa4\xa7\x74\x13\xfb\x6b\x42\x19\x4c\x13\x2f\xeb\x63\x66\x54\x6e\x0e\x99\x41\xba\x9b\xf7\xe5\xa5\xb3\x12\x17\xdc\xe7\x65\x8e\x92\x25\x5e\x7c\x3e\x8e\x42\x4d\x7f\x2b\x4a\x55\x05\xe3\x04\x39\x91\x4c\x04\xe7\xaa\xb1\x0d\x0c\x36\x86\x3f\xa1\x34\x7b\xb2\xae\x87\xbb\x78\x46\xa1\x96\xbf\x83\x82\xc7\x17\xbf\xec\x5b\xc3\xe3\xa2\x0c\xec\x61\xaa\x31\xd1\xa5\xdc\x52\xd5\xcc\xbf\x45\xb7\xe3\x6f\xd3\xf7\xc1\xfa\x8e\x5a\xc3\x38\x36\xc7\xdf\xb8\x40\xa4\x67\xe9\xd7\xba\x7e\xf8\x60\x7f\x46\xe9\x90\xf7\x64\x9b\xba\x86\xb3\x9f\xbe\x3a\xb0\x07\x3b\xf5\x43\x4e\xbb\x53\x62\x8f\xee\xfd\xa5\xc8\x84\x39\xc2\xf6\xa4\x47\x0b\x57\xd7\xbb\x00\x77\x85\xe8\x96\xe7\xde\x93\x09\x66\xbf\x87\xaa\x16\x78\x6f\xfe\xba\xbb\x7e\xdb\x9e\x8b\xc2\xad\xe3\x28\x0a\x2d\x3f\xeb\xf0\xfe\xa9\xbb\xfb\x34\x33\x04\x8c\x36\x19\xf2\x92\xb2\xb9\x49\x91\x94\xcd\xed\xf2\x75\x80\x7e\xd6\xf7\x56\xc1\x95\x19\x63\xc0\x54\xc8\x91\x27\xe6\x28\x33\xb2\xf4\xe2\x88\xf1\xa2\x6c\x53\x72\x92\x62\xf2\x65\x22\xae\x3c\xcb\xa8\xa3\x81\x73\xd7\x02\xa1\x53\x94\xa0\x1a\xaf\xa9\x28\xb4\x88\x1d\x83\x41\x92\xb1\xe4\x0b\x10\x28\x04\xe3\x1a\xb4\x00\x85\x08\x4c\x2b\x50\xa2\x94\x09\x42\x22\x28\x0e\x1b\x71\x3b\xd1\x9b\xd6\xca\x28\xbd\xee\x46\xa7\xc9\x8c\xf1\xc3\x75\x33\xff\x81\xf3\x28\x18\x23\x4f\x33\xb1\xf0\x55\x22\x45\x96\x6d\xe5\xd5\x8f\x25\x57\x10\xd9\xaa\x2a\x1e\x74\x49\x36\x24\x05\x0b\xe7\x87\xa1\x2c\xb9\x7a\xc5\xa8\xc9\xb8\x5d\x5d\x11\x9c\xbe\xdd\xc8\xbc\xff\x18\x9f\x7f\x30\x49\xf7\x00\xfe\x27\x80\x27\x24\x2f\x5e\x4e\x85\xcc\x89\x1e\x25\x6a\xee\xc5\xc7\xe3\x4f\x06\x6e\x18\x85\x4e\xae\x3d\x27\xf3\x7e\x1d\xbc\xbe\xd8\x6f\x4b\xe8\xad\x4c\xbf\x2a\xe5\x6f\x38\x3c\x22\x9d\x5e\xb7\xe3\x5d\x4d\xd7\xe1\xc3\x2d\xf3\xdd\xb9\x00\xf6\x5e\x30\xf2\x34\xcb\x11\x0a\x94\xc0\x34\x4a\x62\xc2\xee\x00\x84\x04\x9d\x22\xe4\x48\x19\xe1\x20\xa6\xb6\xa7\x48\x5e\x64\xa8\x4c\x97\x70\x28\xed\xd1\x09\x84\x53\x3b\xf9\xe2\xaf\x8f\x21\x11\x7c\xca\x28\xf2\x04\x81\x99\xb0\x9d\x93\xac\x59\xcb\x24\xe4\x48\xb8\x17\x1b\x66\xa1\x28\xa2\x50\xa7\xdf\x51\x45\x5e\xe6\x13\x94\x46\x96\x1c\x73\x21\x97\x40\xb2\x4c\x24\x56\x57\xb5\xae\xba\x17\xdb\x29\xf5\xff\x94\x71\xb2\xd4\xa8\xee\x21\x2c\xd1\x48\xdd\xa2\xef\x2f\x75\x8e\x33\xe2\xe4\x2d\xa4\x48\x50\x29\xa4\x56\x46\x85\x89\xe0\xd4\x8b\xcf\xde\x84\xea\xb7\x89\xb4\x16\xa7\x4a\x93\xbc\x80\x45\x8a\x1c\x64\x73\xcd\x5d\xa0\xc4\x26\x18\x91\x7a\x77\x4e\x8b\xd7\x69\x6a\xf9\x22\xf5\x29\x9a\x5c\x41\xcd\x05\xb9\x2d\x3a\xe8\x75\x8a\x34\x02\xd2\x55\x0d\x68\xbc\xd7\x4b\x1c\xde\x3d\xb2\xf5\xd8\x2e\x3b\x16\x14\xbf\x93\x2b\xe3\xd3\x36\x9e\xd4\x77\x0e\x9e\x82\x48\x73\xe3\xce\x98\xca\xa1\x34\x91\xa3\x05\xc8\x92\xc3\xa4\x3d\xce\x0f\x00\x83\x59\x70\x00\xef\xce\xcf\x5e\xff\xf3\xe2\xe3\xf9\xf1\xd8\x94\x00\xf0\x4e\xac\x48\x94\x17\x5f\xac\x60\x6e\xf1\xd0\x3b\x01\x73\x77\x29\xe9\x63\x1e\x40\x4e\x92\x94\x71\x74\x87\x19\x99\x29\x90\x98\x08\x49\x91\x82\x2d\xf6\x74\xda\x46\xd8\x7d\x1c\x79\xc2\xe7\x4c\x0a\x9e\x23\xd7\xb7\x88\x95\x94\x4a\x8b\x1c\x72\xd4\x92\x25\x86\x79\x61\x63\x10\x26\x4b\xcb\xba\xd3\xf5\x3e\xdc\xcf\x1c\xd8\x2d\x9c\x5b\x96\x3a\x25\x1a\x88\x44\x58\x08\xa9\xd0\x74\x39\x30\x6e\xb9\x17\x12\x13\x34\xfb\xc0\xb8\x46\xdd\x47\x84\x8f\xdd\xb3\xcc\x0e\x31\xb6\x6b\xcf\x28\xdc\x99\x0a\x77\x5d\x84\xab\x4a\x12\x3e\x43\x08\x4e\x35\xe6\xea\xce\xc5\x77\xdc\x15\xa2\xb2\xe4\x97\x55\x15\x9c\x72\x8a\x57\xcd\x05\xff\x63\xc9\x83\x0b\x89\x5a\x2f\x7f\x66\xe6\x3a\x5b\x55\x6c\x0a\x33\x0d\xc1\x58\x13\xad\x82\x71\x93\xfc\x0e\xeb\x1a\x22\x55\x90\xae\x54\xb3\x0f\x4b\x5e\xfc\xa4\xc8\x4a\x95\xf3\x97\x55\x55\x48\xc6\xf5\x14\xbc\xc7\xc1\xe1\xd4\x6b\x97\x7f\x30\x54\x17\x28\x4d\x3d\x57\xd7\x8f\xa3\xd0\x40\xc4\x4d\x15\xe8\x4a\xc5\xcd\xa2\x4b\x0b\x91\x69\x56\x78\xae\xea\xdd\x94\x78\x4d\x86\x9d\xce\x30\x03\x9d\x66\x1f\x08\x17\x63\x4c\xd4\x05\xca\xf3\xc2\x70\xb4\xfc\xb9\xea\xae\x33\x46\xca\xbe\xd2\x2b\x75\xa3\x89\x0c\xe3\x55\x25\x50\x55\xed\x64\x5d\xb7\x25\x81\x23\x61\x1c\xfa\xda\x3f\x9f\x79\x10\x9c\x31\x5e\xd7\x5c\x1d\xd8\xf4\xbf\x63\x1a\x89\x9d\xb7\x00\x4a\x53\x8a\xf3\x6d\xa2\xb1\xa6\x6f\x71\xde\x91\x99\x4a\xe3\xf8\x74\x9b\xec\xf8\xf4\xbd\x58\xd4\xb5\xbf\x63\xe2\x27\x36\x4b\xcd\xfa\xc6\xde\xeb\x66\xbf\xee\x86\x15\x5b\x63\x58\xeb\xbd\xb6\x35\x41\x63\xbb\xaa\xda\x35\xd6\x20\xde\x11\x0b\xe9\x1b\x93\x34\x77\x20\x6e\xce\xdc\x19\xf7\x0c\x67\xa4\x5d\x3a\xc6\xa4\x43\xdd\x31\x7e\x33\xe6\xdd\x77\x78\x7b\x8a\x34\xa5\x60\x77\x9f\x3e\x7d\xdb\xec\x29\xd7\x75\xfb\x69\x1f\x37\xd9\xd5\x4b\x9d\x1a\x55\x55\x5b\xe6\x43\xb0\xca\x99\x8e\xf5\xc0\xe5\x5e\x35\x74\xef\x75\xd7\x1b\xcf\x5a\x68\x95\xff\xea\xfa\x56\xe2\x5e\xb2\xd9\x8f\x4e\x55\x15\x9c\xf0\xf9\xbe\xb0\xdc\xb9\xf8\xa8\xe4\x4c\x1f\xc0\xa3\x39\xc9\x4a\x84\xa3\x51\x1b\x21\xf6\xb0\xaf\x6b\x73\xd2\xc4\x55\xe5\xa6\xeb\x1a\xaa\xca\x2e\x68\x77\xc4\xde\x02\x04\x56\x0f\xf3\x2b\xd9\x82\x5e\x5a\x70\xa2\x74\xe5\x80\x6f\x05\x3a\x32\x81\x74\xf1\xc9\xc9\x66\x6e\xf9\x1d\x3f\xa2\x51\x32\x92\xf9\x2c\x11\x5c\x79\xb1\x96\xae\x40\xbb\x2c\x8b\x28\x64\xed\x33\xc7\x2d\x1a\xdc\xf5\xcd\xa3\x97\x64\x4e\xa4\xdc\x99\x62\xac\x29\x44\x66\x4e\xd2\xd1\xe1\xe1\x0d\x82\xa2\x94\x42\xf6\x44\x34\x99\x68\x0f\x4f\x2f\xf7\x7e\x76\x88\x42\xf3\xe2\xbf\xfe\x41\xc3\x7e\xb9\x08\xbb\xef\x3b\xeb\x5f\x2f\x6e\x7a\xfc\xbf\xf9\x8b\x48\x2b\xc1\x60\x5a\x72\x2b\xc1\x60\x08\x55\x27\xf1\x9c\x48\x68\x9e\x68\x60\x04\x54\x24\xa5\xa9\x92\x82\x19\xea\x93\x0c\x4d\xf3\xcd\xf2\x94\x0e\x9e\x36\x24\x4f\x87\x2f\xd7\x56\x52\x49\x16\x30\x82\x9d\xc8\x60\x67\x8f\xcd\x3b\x93\x1a\x5c\x0b\xdc\xbd\x43\x3d\x1d\x1e\x98\x68\x33\xa7\x86\x6d\xb8\x97\xec\x83\x56\xb8\xc0\xbe\x2a\x21\xed\x09\x50\xaf\x9a\x1b\x44\x30\x82\xf0\xdf\xaf\x9e\xfc\xa7\x19\x1e\x0d\x0e\xbf\x69\x59\xe2\x70\xf0\xe4\xdb\xa3\x61\x18\x68\x54\x7a\xb0\x60\x9c\x8a\x45\xd0\xde\xe7\x02\x85\x44\x26\xe9\x70\x1b\x93\x50\x7a\x32\x47\xae\xdf\x33\xa5\x91\xa3\xb4\x32\xf3\x19\x3e\x3d\xb0\x0a\xf6\x56\x98\xee\xa0\xeb\xd7\xc3\xb6\xbd\xe9\xc5\x55\x6c\xf5\x3f\x02\x4e\x85\x30\x0f\x6d\x76\xa6\x09\x9d\x28\x74\x11\x17\x85\xee\xbb\xe4\x7f\x07\x00\x77\x3a\xeb\x89\xa8\x1c\x00\x00")
 
 func runsTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -225,7 +247,7 @@ func runsTmplHtml() (*asset, error) {
 	return a, nil
 }
 
-var _sortableCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\xef\x6f\xdb\x36\x10\xfd\xee\xbf\xe2\x4d\xc0\x90\xc4\x8b\x7e\x36\x6e\x3d\x25\x0e\x90\x76\xfd\x30\x20\xd9\x80\x75\x0b\xb0\x4f\x05\x2d\x9e\x24\xa2\x14\x29\x90\x27\xcb\xde\xb0\xff\x7d\xa0\x64\x67\x45\x06\xb4\xeb\x50\xd8\x10\xc4\xc7\xc7\xbb\x77\x8f\xa7\x4b\x97\x78\x63\xfb\x83\x53\x4d\xcb\x28\xb2\xfc\x25\x7e\x6d\x09\x8f\xc2\x08\xa9\x86\x0e\x77\x03\xb7\xd6\xf9\x04\x77\x5a\x63\x22\x79\x38\xf2\xe4\x76\x24\x13\x2c\xd3\x45\xba\xc4\x6f\x9e\x60\x6b\x70\xab\x3c\xbc\x1d\x5c\x45\xa8\xac\x24\x28\x8f\xc6\xee\xc8\x19\x92\xd8\x1e\x20\xf0\xfa\xdd\x0f\xb1\xe7\x83\xa6\xe3\x41\xad\x2a\x32\x9e\xc0\xad\x60\x54\xc2\x60\x4b\xa8\xed\x60\x24\x94\x01\xb7\x84\xfb\x1f\xdf\xbc\xfd\xe9\xdd\x5b\xd4\x4a\xd3\x94\x2d\xe9\xa4\x8e\xa5\x60\x11\xb3\xd8\x6a\x7a\xff\xde\x5b\xc7\xaa\xb2\x06\x7f\x2e\x00\x80\x9d\x30\x5e\xb1\xb2\xa6\x44\x96\x14\x1e\x24\x3c\xc5\xca\xc4\x76\xe0\xeb\x89\x21\x95\xef\xb5\x38\x94\x50\x46\x2b\x43\xf1\x56\xdb\xea\xc3\xbc\x35\x2a\xc9\x6d\x89\x7c\xdd\xef\x67\xa0\xa5\x50\xf0\xc7\x48\x27\x5c\xa3\x4c\xac\xa9\xe6\x12\xab\x67\xa8\x9b\xd9\x4f\xf0\x8e\x82\x36\xa1\x63\xa1\x55\x63\x4a\xf8\x61\x3b\x6f\xd8\x5e\x54\x8a\x0f\x25\xb2\x79\xbd\x15\xd5\x87\xc6\x85\xc2\x4b\x0c\x4e\x9f\x9f\x85\x0a\x4b\xd5\x89\x86\x52\xbf\x6b\xbe\xdb\x77\xfa\x7a\xe0\x7a\x7d\x79\xe3\x77\x0d\xf6\x9d\x36\x7e\x13\xb5\xcc\x7d\x99\xa6\xe3\x38\x26\xe3\x8b\xc4\xba\x26\x2d\xb2\x2c\x0b\xfc\x68\xae\x64\x13\xe5\x59\xf6\x6d\x74\xac\xe2\xb4\xda\x29\x1a\x5f\xdb\xfd\x26\xca\x90\xa1\xb8\x42\x71\x15\xa1\x56\xbc\x89\x22\xf4\xc7\x8b\xbd\xf3\x3d\x55\xfc\x8b\x60\x65\x37\xd1\xfe\x41\xc9\xdf\x1f\x94\x44\x47\xc4\x11\xa6\xeb\xdb\x44\xbd\x55\x86\xc9\xc5\xb4\x23\xc3\xbe\x84\xb1\x86\xae\xff\xf1\x76\x36\x35\xba\xbd\xe9\x05\xb7\x90\x9b\xe8\xe1\x0a\x79\xa1\xf3\xe4\x2a\x47\x78\xdc\xe7\x39\x5e\x25\xeb\x17\x8f\x45\xd6\x16\x8f\xe1\x4d\xaf\x92\xd5\x1a\xab\x64\xf5\xfd\x7d\x91\x05\x6e\xbc\x0e\x3f\xac\xff\x88\x6e\x6f\xd2\x10\xe6\xf6\x26\xd4\x76\x7b\x76\x01\x63\x63\x47\x3d\x09\xbe\x5e\xfc\xb5\xf8\x77\x4b\xb4\x24\x24\xb9\x38\x0e\xad\x41\x32\x16\xbe\x22\x23\x95\x69\x2e\x3f\x4b\x95\x74\xe2\x1e\xdb\xa9\xb2\xda\xba\x12\xae\xd9\x8a\xf3\xec\x12\xf3\x3f\x59\xbf\xba\xc0\x37\xaa\xeb\xad\x63\x61\xbe\x50\x04\x3e\xd7\xc1\x4f\xbd\x91\x7f\x69\x8e\x8f\xd4\xff\xcf\x24\x4f\x5f\x50\x6d\x5d\x57\xc2\x59\x16\x4c\xe7\xf9\x3a\x93\xd4\x5c\x7c\x52\x43\x88\x1f\x80\x93\x6f\x83\xf3\xc1\x38\x49\xb5\x18\xf4\xa7\xe5\x9f\x8e\x96\x6d\x98\x15\x5f\xcb\xf8\x67\x51\xff\xb3\x21\x59\x52\xbc\x9c\x02\xa7\xcb\xe5\x02\x4b\x24\xe1\x78\xad\xed\x18\xfb\xca\x59\xad\x17\x98\xe0\x9f\x77\xe4\x9c\x92\xe4\xd1\x49\x8d\x13\x07\x9e\x98\x95\x69\xc2\x18\x84\xb6\xa6\x01\x8b\xed\xa0\x85\x43\x48\x8d\xd1\x9a\x33\x0e\x33\xae\x55\x52\x92\x49\x16\xd3\x44\x7b\x96\x60\x12\x74\xc2\x4a\xcc\x60\x50\xf4\x77\x00\x00\x00\xff\xff\xc3\xca\xfc\x12\xa9\x05\x00\x00")
+var _sortableCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x54\x5d\x6f\xdc\x36\x10\x7c\xbf\x5f\x31\x15\x50\xd8\xbe\x5a\x9f\xf1\x25\x57\xd9\x67\xc0\x49\xf3\x50\xc0\x6e\x81\xa6\x35\xd0\xa7\x80\x12\x57\x12\x11\x8a\x14\xc8\xd5\x7d\xb4\xe8\x7f\x2f\x28\xdd\xb9\x81\x0b\x34\x49\x11\xdc\x41\x10\x87\xc3\xdd\x9d\xe1\x6a\xd3\x25\xde\xd8\xe1\xe0\x54\xdb\x31\x8a\x2c\x7f\x89\x5f\x3b\xc2\xa3\x30\x42\xaa\xb1\xc7\xdd\xc8\x9d\x75\x3e\xc1\x9d\xd6\x98\x48\x1e\x8e\x3c\xb9\x2d\xc9\x04\xcb\x74\x91\x2e\xf1\x9b\x27\xd8\x06\xdc\x29\x0f\x6f\x47\x57\x13\x6a\x2b\x09\xca\xa3\xb5\x5b\x72\x86\x24\xaa\x03\x04\x5e\xbf\xfb\x21\xf6\x7c\xd0\x74\x3c\xa8\x55\x4d\xc6\x13\xb8\x13\x8c\x5a\x18\x54\x84\xc6\x8e\x46\x42\x19\x70\x47\xb8\xff\xf1\xcd\xdb\x9f\xde\xbd\x45\xa3\x34\x4d\xd9\x92\x5e\xea\x58\x0a\x16\x31\x8b\x4a\xd3\xfb\xf7\xde\x3a\x56\xb5\x35\xf8\x73\x01\x00\xec\x84\xf1\x8a\x95\x35\x25\xb2\xa4\xf0\x20\xe1\x29\x56\x26\xb6\x23\x5f\x4f\x0c\xa9\xfc\xa0\xc5\xa1\x84\x32\x5a\x19\x8a\x2b\x6d\xeb\x0f\xf3\xd6\x4e\x49\xee\x4a\xe4\xeb\x61\x3f\x03\x1d\x05\xc1\x1f\x23\xbd\x70\xad\x32\xb1\xa6\x86\x4b\xac\x9e\xa1\x6e\x66\x3f\xc1\x5b\x0a\xb5\x09\x1d\x0b\xad\x5a\x53\xc2\x8f\xd5\xbc\x61\x07\x51\x2b\x3e\x94\xc8\xe6\x75\x25\xea\x0f\xad\x0b\xc2\x4b\x8c\x4e\x9f\x9f\x05\x85\xa5\xea\x45\x4b\xa9\xdf\xb6\xdf\xed\x7b\x7d\x3d\x72\xb3\xbe\xbc\xf1\xdb\x16\xfb\x5e\x1b\xbf\x89\x3a\xe6\xa1\x4c\xd3\xdd\x6e\x97\xec\x5e\x24\xd6\xb5\x69\x91\x65\x59\xe0\x47\xb3\x92\x4d\x94\x67\xd9\xb7\xd1\x51\xc5\x69\xb5\x55\xb4\x7b\x6d\xf7\x9b\x28\x43\x86\xe2\x0a\xc5\x55\x84\x46\xf1\x26\x8a\x30\x1c\x2f\xf6\xce\x0f\x54\xf3\x2f\x82\x95\xdd\x44\xfb\x07\x25\x7f\x7f\x50\x12\x3d\x11\x47\x98\xae\x6f\x13\x0d\x56\x19\x26\x17\xd3\x96\x0c\xfb\x12\xc6\x1a\xba\xfe\xc7\xdb\xd9\xd4\xe8\xf6\x66\x10\xdc\x41\x6e\xa2\x87\x2b\xe4\x85\xce\x93\xab\x1c\xe1\x71\x9f\xe7\x78\x95\xac\x5f\x3c\x16\x59\x57\x3c\x86\x37\xbd\x4a\x56\x6b\xac\x92\xd5\xf7\xf7\x45\x16\xb8\xf1\x3a\xfc\xb0\xfe\x23\xba\xbd\x49\x43\x98\xdb\x9b\xa0\xed\xf6\xec\x02\xc6\xc6\x8e\x06\x12\x7c\xbd\xf8\x6b\xf1\xef\x96\xe8\x48\x48\x72\x71\x1c\x5a\x83\x64\x2c\x7c\x4d\x46\x2a\xd3\x5e\x7e\x92\x2a\xe9\xc4\x3d\xb6\x53\x6d\xb5\x75\x25\x5c\x5b\x89\xf3\xec\x12\xf3\x3f\x59\xbf\xba\xc0\x37\xaa\x1f\xac\x63\x61\xbe\xb0\x08\x7c\xaa\x83\x9f\x7a\x23\xff\xd2\x1c\x1f\x55\xff\x3f\x93\x3c\x7d\x41\x8d\x75\x7d\x09\x67\x59\x30\x9d\xe7\xeb\x4c\x52\x7b\xf1\x9f\x35\x84\xf8\x01\x38\xf9\x36\x3a\x1f\x8c\x93\xd4\x88\x51\xf3\x67\x1d\x2d\xbb\x30\x2b\xbe\x96\xf1\xcf\xa2\x7e\xb6\x21\x59\x52\xbc\x9c\x02\xa7\xcb\xe5\x02\x4b\x24\xe1\x78\xa3\xed\x2e\xf6\xb5\xb3\x5a\x2f\x30\xc1\x3f\x6f\xc9\x39\x25\xc9\xa3\x97\x1a\x27\x0e\x3c\x31\x2b\xd3\x86\x31\x08\x6d\x4d\x0b\x16\xd5\xa8\x85\x43\x48\x8d\x9d\x35\x67\x8c\x8a\xd0\x29\x29\xc9\x24\x8b\x69\xa2\x3d\x4b\x30\x15\x74\xc2\x4a\xcc\x60\xa8\xe8\xef\x01\x00\xc3\xca\xfc\x12\xa9\x05\x00\x00")
 
 func sortableCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -245,7 +267,7 @@ func sortableCss() (*asset, error) {
 	return a, nil
 }
 
-var _sortableJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\x4d\x6f\xdb\x38\x10\xbd\xfb\x57\xcc\x9e\xac\xa0\xb6\x92\xec\x61\x0f\xf1\x1a\x8b\xd4\xf5\xa1\x40\x91\xc3\xa6\xbb\x17\xc3\x28\x28\x72\x6c\xd1\xa5\x48\x83\x1c\x39\x31\x5a\xff\xf7\xc5\x50\x96\x23\xc9\x5f\x59\x54\x80\x01\x59\x7a\xf3\xe6\xbd\x99\x21\xa9\xdb\x5b\x98\xb8\xf5\xd6\xeb\x65\x4e\xf0\xfb\xdd\xfd\x1f\xf0\x35\x47\xf8\x57\x58\xa1\x74\x59\xc0\x63\x49\xb9\xf3\x21\x85\x47\x63\x20\x82\x02\x78\x0c\xe8\x37\xa8\xd2\xde\xed\x2d\xfc\x13\x10\xdc\x02\x28\xd7\x01\x82\x2b\xbd\x44\x90\x4e\x21\xe8\x00\x4b\xb7\x41\x6f\x51\x41\xb6\x05\x01\x1f\x9f\x3f\x0d\x03\x6d\x0d\x72\x94\xd1\x12\x6d\x40\xa0\x5c\x10\x48\x61\x21\x43\x58\xb8\xd2\x2a\xd0\x16\x28\x47\xf8\xf2\x79\x32\x7d\x7a\x9e\xc2\x42\x1b\x4c\x7b\xbd\x8d\xf0\x40\x22\x33\x18\x60\x0c\xca\xc9\xb2\x40\x4b\xe9\x12\x69\x6a\x90\x6f\xc3\xc7\xed\xc4\x88\x10\x9e\x44\x81\x49\xbf\x50\x66\xa8\x04\x89\x61\x0c\x19\x06\xe7\xe3\x4d\xff\x66\xd4\x9b\xcd\xd3\x85\xf3\x53\x21\xf3\x54\x0a\x63\x92\x8a\x74\x00\x85\xf8\x8e\x5f\xf9\xfe\x79\x0f\xbe\xe9\xf5\x16\xa5\x95\xa4\x9d\x3d\x7e\x99\x50\x76\x03\x3f\x7a\x00\x00\xac\x8c\x73\xc1\x18\xf0\x95\xbc\x90\xf4\x49\x90\x48\x6e\x46\x87\xb7\x39\x0a\x85\x9e\x85\x53\xf6\x4e\xc9\xdf\xbe\x55\x41\x2d\xe9\xcc\xd7\x95\xbf\xe7\xae\xf4\x4f\x9c\x29\x0b\xdb\x30\xc0\x11\x2d\x13\x6d\x44\x42\xf9\x00\xb4\x55\xf8\x5a\x9b\xe1\x4b\xac\xd7\x68\x15\x63\x3e\x4b\x67\x13\xca\xf7\xa9\xf9\xa2\x3c\xd5\x81\x5f\xa1\x7a\x0c\x92\x1d\xe5\xa9\x64\x13\x5f\x74\xa0\x54\x3a\x4b\x42\xdb\x70\xd6\x4d\xb4\x83\x6a\x28\x82\x44\xab\xb4\x5d\xf6\x1b\xdc\x5c\x2a\x1d\x6c\x59\xfc\x6f\xda\xba\x48\x43\x5b\x16\xe8\xb5\xec\xb7\x15\x0b\xa5\xa6\x1b\xb4\xc4\x64\x68\xd1\x27\x7d\x69\xb4\xfc\xde\x1f\x1c\x6a\x93\x34\xfd\xd7\x5a\x98\xb5\x32\xf9\x5b\xdb\xf6\xa8\x05\x3d\xdb\x91\x43\xe1\x79\xb9\x50\x92\x77\x73\xf0\xd5\xb4\xe9\xb1\x70\x9b\xf3\x93\x70\xb1\x76\xbf\xc0\xa7\xf0\x32\x61\xbb\xdf\xb6\x34\xa6\x8d\xda\x75\xa2\x5a\xad\x13\x4a\x25\x75\x1d\xff\x82\xf7\x5b\x7b\xb8\x8a\x3d\x2f\x9b\x01\x71\x09\xc6\xc9\x1e\x54\x33\x35\xa8\xdb\xd9\x01\x7b\x74\x5e\xa1\xff\xdb\xbd\x84\xe4\xd8\x48\xdb\xfb\x9e\xe1\x0d\x55\x5b\xdf\x75\x56\xda\x19\x05\x07\x7b\xcd\x49\x60\x8b\x29\x07\x24\x87\x61\x14\x03\xc8\xba\xc3\xa2\x17\x49\x64\x39\x35\x44\x3c\xac\xa2\x5a\x37\x4f\x65\x91\xa1\x4f\x44\xca\xb4\xb3\x98\x7e\x7e\xa2\xab\x1c\x91\xb5\x22\xb2\x2b\x11\x7a\x91\x9c\x94\xdf\xbd\x3c\x52\xe9\x6d\x94\x33\xe4\x0c\xc7\x4c\x3b\x40\x13\xf0\x3a\x05\x47\x0f\xc5\x69\x8a\xde\xf9\x7f\xd7\x84\xd6\x02\x9b\x7e\x53\xe3\xa4\x30\x38\x71\xc5\x5a\x78\xbc\x58\x8b\xb3\xea\x6b\xd5\x17\x78\x2f\x75\x65\x77\x75\xa8\x5a\x93\xda\x10\xb0\x70\x3e\x89\x5b\x27\x8c\xe1\x6e\x04\x1a\xfe\xac\x66\xca\xa0\x5d\x52\x3e\x02\xfd\xe1\x03\x74\x0b\x41\x59\x5a\xed\xf2\x93\x5c\x1b\x95\x54\xb2\xe6\xa9\x77\x2f\x0d\x59\x6f\x92\xf6\xde\x18\x76\x5a\xdb\xf1\x91\xd1\x48\x18\xc5\x49\x67\x9b\x07\xb7\xf4\x28\x08\xf7\x07\x61\xd2\x0f\x6b\x61\x9b\xcb\x98\xe1\xd5\x2e\xc2\x87\x23\x8c\x8f\x77\x03\x5e\x31\x8c\xea\xb7\x77\xfa\x86\x29\x7e\x7b\x28\xe5\xae\x71\x98\xb7\x4e\xe9\xa8\x93\x15\x7a\xf7\xc2\x27\xf4\xa3\xf7\x62\x9b\xae\xbd\x23\x47\xdb\x35\xa6\x81\x3f\x54\x9a\x5f\x0a\xb3\xbb\x58\xa7\x10\xa9\x1b\x67\xff\x6c\xce\x0f\x9a\xcd\xb8\xaf\x9a\xc1\xe0\x33\xcd\xd8\xe7\x85\x71\x04\xcd\xf4\xbc\x36\x53\x35\x64\x78\x3f\x87\x31\xfc\xf0\xee\xe5\x81\x01\x83\xf8\xf8\x01\x66\xf3\x5d\x8d\xab\xd3\xad\xaa\xde\xaf\xaa\x74\xa9\x44\x63\xde\x72\xae\x3a\x03\xc0\x01\x8c\xa8\xd2\x56\xe0\xd9\x6a\xde\x3e\x88\x49\xf8\x25\x12\x8c\x23\xf2\xdd\x1f\x2d\x0c\x8e\x0f\xfa\x37\xb3\xbb\x39\xfc\xfc\x19\xc3\x47\xad\xcd\x2e\xfa\xaa\x56\xc2\x8a\xfd\x55\x99\x52\xc2\x57\x9a\x38\x4b\x68\xa9\xc6\xf3\x00\xf2\xaf\x35\x7e\xbb\xde\x7f\x01\x00\x00\xff\xff\x88\xef\x2f\x44\xb1\x0a\x00\x00")
+var _sortableJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x56\xc1\x72\xe2\x38\x10\xbd\xfb\x2b\x7a\x4f\x98\x1a\x50\x92\x3d\xec\x21\x5e\x6a\x2b\xc3\x70\x98\xaa\xa9\x1c\x36\xb3\x7b\xa1\xa8\x29\x59\x6a\xb0\x18\x59\xa2\xa4\x36\x09\x35\xc3\xbf\x6f\x49\xc6\xc4\x36\x90\x64\x33\x3e\x19\xf4\xfa\xf5\x7b\xdd\x2d\xc9\x57\x57\x30\xb5\x9b\x9d\x53\xab\x82\xe0\xf7\xeb\x9b\x3f\xe0\x6b\x81\xf0\x2f\x37\x5c\xaa\xaa\x84\xbb\x8a\x0a\xeb\x3c\x83\x3b\xad\x21\x82\x3c\x38\xf4\xe8\xb6\x28\x59\x72\x75\x05\xff\x78\x04\xbb\x04\x2a\x94\x07\x6f\x2b\x27\x10\x84\x95\x08\xca\xc3\xca\x6e\xd1\x19\x94\x90\xef\x80\xc3\xc7\x87\x4f\x63\x4f\x3b\x8d\x21\x4a\x2b\x81\xc6\x23\x50\xc1\x09\x04\x37\x90\x23\x2c\x6d\x65\x24\x28\x03\x54\x20\x7c\xf9\x3c\x9d\xdd\x3f\xcc\x60\xa9\x34\xb2\x24\xd9\x72\x07\xc4\x73\x8d\x1e\x26\x20\xad\xa8\x4a\x34\xc4\x56\x48\x33\x8d\xe1\xd5\x7f\xdc\x4d\x35\xf7\xfe\x9e\x97\x98\x0e\x4a\xa9\xc7\x92\x13\x1f\xc7\x90\xb1\xb7\x2e\xbe\x0c\x86\x59\x32\x5f\xb0\xa5\x75\x33\x2e\x0a\x26\xb8\xd6\x69\x4d\x3a\x82\x92\x7f\xc7\xaf\xe1\xfd\xe1\x00\x1e\x26\xc9\xb2\x32\x82\x94\x35\xa7\x8b\x29\xe5\x43\xf8\x91\x00\x00\x04\x65\x21\x17\x4c\x00\x9f\xc8\x71\x41\x9f\x38\xf1\x74\x98\x1d\x57\x0b\xe4\x12\x5d\x10\x4e\xf9\x1b\x25\x7f\xfb\x56\x07\x75\xa4\x03\x00\xf4\xe5\x1f\xb8\x6b\xfd\x53\xab\xab\xd2\xb4\x0c\x00\x00\x74\x4c\x74\x11\x29\x15\x23\x50\x46\xe2\x53\x63\x26\x3c\x7c\xb3\x41\x23\x03\xe6\xb3\xb0\x26\xa5\x62\x98\x1d\xd7\xa8\x60\xca\x87\x25\x94\x77\x5e\x04\x47\x05\x13\xc1\xc4\x17\xe5\x89\x09\x6b\x88\x2b\xe3\x2f\xba\x89\x76\x50\x8e\xb9\x17\x68\xa4\x32\xab\x41\x8b\x3b\x94\x4a\x79\x53\x95\xff\x9b\xb6\x29\xd2\xd8\x54\x25\x3a\x25\x06\x5d\xc5\x5c\xca\xd9\x16\x0d\x05\x32\x34\xe8\xd2\x81\xd0\x4a\x7c\x1f\x8c\x8e\xb5\x49\xdb\xfe\x1b\x2d\x81\xb5\x36\xf9\x5b\xd7\x76\xd6\x81\x5e\xec\x48\x43\x1e\xb7\x0b\xa5\x45\x3f\x47\x78\xda\x36\x1d\x96\x76\x8b\xef\xab\xdd\x2f\xf0\x49\x7c\x99\xb0\xdb\x6f\x53\x69\xdd\x45\xed\x7b\x51\x9d\xd6\x71\x29\xd3\xa6\x8e\x7f\xc1\xdb\xad\xdd\xc2\xfb\x65\x07\x40\xdc\x82\x71\xb2\x47\xf5\x4c\x8d\x9a\x76\xf6\xc0\x0e\xad\x93\xe8\xfe\xb6\x8f\x3e\x3d\x35\xd2\xf5\x7e\x60\xc8\x92\xbe\xf5\x7d\x6f\xa7\x5d\x50\x70\xb4\xd7\x9e\x84\x60\x91\x85\x80\xf4\x38\x8c\x7c\x04\x79\x7f\x58\xd4\x32\x8d\x2c\xe7\x86\x28\x0c\x2b\xaf\xf7\xcd\x7d\x55\xe6\xe8\x52\xce\x02\xed\x3c\xa6\x5f\x0c\xb3\xb3\x11\x79\x27\x22\x7f\x25\x42\x2d\xd3\xb3\xf2\xfb\x8f\x43\xaa\x9c\x89\x72\xc6\x21\xc3\x29\xd3\x1e\x50\x7b\x7c\x9d\x22\x44\x8f\xf9\x79\x8a\xe4\xf2\xaf\xd7\x84\x36\x02\xdb\x7e\x99\xb6\x82\x6b\x9c\xda\x72\xc3\x1d\xbe\x58\x8b\x8b\xea\x1b\xd5\x2f\xf0\xbe\xd4\x95\xfd\xab\x43\xd5\x99\xd4\x96\x80\xa5\x75\x69\x3c\x3a\x61\x02\xd7\x19\x28\xf8\xb3\x9e\x29\x8d\x66\x45\x45\x06\xea\xc3\x07\xe8\x17\x82\x72\x56\x9f\xf2\xd3\x42\x69\x99\xd6\xb2\x16\xcc\xd9\xc7\x96\xac\x7d\xd2\xf3\x16\x60\xe7\xb5\x9d\x5e\x19\xad\x84\x51\x9c\xb0\xa6\x7d\x71\x0b\x87\x9c\xf0\x70\x11\xa6\x03\xbf\xe1\xa6\xbd\x8d\x03\x9c\x89\xe6\x72\x84\xc9\xe9\x69\x10\x76\x4c\x40\x0d\xba\x27\x7d\xcb\x54\x58\x3d\x96\x72\xdf\xba\xcc\x3b\xb7\x74\xd4\x19\x14\x3a\xfb\xe8\x61\x02\x77\xce\xf1\x1d\xdb\x38\x4b\x96\x76\x1b\x64\x3e\x7c\xa8\xb4\xbf\x14\xe6\xd7\xb1\x4e\x7e\x98\x1d\x02\x0f\x77\xff\x7c\x91\x25\xdd\x66\xdc\xd4\xcd\x08\xe0\x0b\xcd\x38\xe4\x85\x49\x04\xcd\xd5\xa2\x31\x53\x37\x64\x7c\xb3\x80\x09\xfc\x70\xf6\xf1\x36\x00\x46\xf1\xef\x5b\x98\x2f\xf6\x0d\xae\x49\xb7\xae\x7b\xbf\xae\xd3\x31\x81\x5a\x3f\xe7\x5c\xf7\x06\x20\x04\x04\x04\x4c\x9e\xc1\xf3\xf5\xa2\x7b\x11\x13\x77\x2b\x24\x98\x44\xe4\x9b\x3f\x5a\x02\x38\xfe\x31\x18\xce\xaf\x17\xf0\xf3\x67\x0c\xcf\x3a\x87\x5d\xf4\x55\xef\x84\x75\xf0\x57\x67\x62\x84\x4f\x34\xb5\x86\xd0\x50\x96\x3c\x0f\xe0\x3e\xe9\x8d\xdf\x3e\xf9\x6f\x00\x88\xef\x2f\x44\xb1\x0a\x00\x00")
 
 func sortableJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -265,7 +287,27 @@ func sortableJs() (*asset, error) {
 	return a, nil
 }
 
-var _stylingTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcf\xb1\x4e\xc3\x30\x10\xc6\xf1\xbd\x4f\x61\x79\x85\xd8\xed\xc2\x80\xe2\x32\x33\xf0\x10\x96\x7d\x71\x8e\xda\x77\x91\xef\xd4\xaa\x8a\xf2\xee\x28\x85\x89\x09\x89\xf1\x96\xdf\xff\xbe\x75\xcd\x30\x21\x81\xb1\xa2\xf7\x8a\x54\xec\xb6\x1d\xc6\x06\x1a\x0d\xc5\x06\xc1\x5e\x11\x6e\x0b\x77\xb5\x26\x31\x29\x90\x06\x7b\xc3\xac\x73\xc8\x70\xc5\x04\xc3\xe3\x78\x36\x48\xa8\x18\xeb\x20\x29\x56\x08\x27\x77\xb4\xe7\xc3\x58\x91\x2e\xa6\x43\x0d\x0f\x1c\x64\x06\x50\x6b\xe6\x0e\x53\xb0\xb3\xea\x22\xaf\xde\x8b\x72\x8f\x05\x5c\x61\x2e\x15\xe2\x82\xe2\x12\x37\x9f\x38\x83\x2b\xa0\x2d\x57\x87\xec\x4f\xee\xe8\x5e\x7c\x8b\x0a\x1d\x63\x75\x48\x19\x0b\x0f\x0b\xd2\xc5\x35\x24\x97\x44\xf6\x9e\xa4\x8e\x8b\x1a\xe9\xe9\x9f\xfe\x6e\x7e\x8a\x3d\x8f\xfe\x9b\xfc\xe3\x96\x89\x49\xe5\x77\x09\x13\xd3\xdb\x14\x1b\xd6\x7b\xf8\xf8\x09\x3c\xbd\x27\xa6\xfd\xe5\x75\x05\xca\xdb\x76\xf8\x0a\x00\x00\xff\xff\x26\x75\xc0\xb2\x87\x01\x00\x00")
+var _sourceTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x56\xcd\x72\xdb\x36\x10\xbe\xeb\x29\xb6\x6c\x0f\xc9\x4c\x41\xc4\x6e\xa6\x8d\x5d\x88\x99\xc6\xee\xa1\x9d\x4e\x92\x71\xda\x43\x4f\x1a\x18\x58\x89\x48\x41\x80\x06\x20\xc9\x1a\x94\xef\xde\x01\x41\x4b\xb4\xc4\x1e\x3a\x76\x6f\xe1\x85\xc0\x62\xf7\xdb\x5f\x60\x97\x7d\x75\xfd\xe1\xea\xf7\x3f\x3f\xfe\x0c\x75\x68\x74\x35\x63\xe9\x07\xf7\x8d\x36\x7e\x5e\xd4\x21\xb4\x97\x94\x6e\xb7\xdb\x72\xfb\x5d\x69\xdd\x8a\x9e\x5d\x5c\x5c\xd0\xfb\xc4\x53\x24\x5e\xe4\xb2\x9a\x01\x00\xb0\xa0\x82\xc6\xea\x1d\x1a\x51\x37\xdc\xfd\x05\x37\xe8\xd7\x3a\x78\xf8\xc9\x89\x5a\x6d\x90\xd1\xcc\xd0\x33\xc7\x18\xb0\x69\x35\x0f\x08\x85\x0f\x3b\xad\xcc\xaa\xe8\xba\x8c\x93\xf6\x03\x5b\xb9\x54\xf7\x28\xc9\x56\xc9\x50\x43\x84\xa5\x35\x81\x2c\x79\xa3\xf4\xee\x12\x1a\x6b\xac\x6f\xb9\xc0\x1f\x21\x0b\x96\x52\xb9\xb0\x83\x08\xc2\x6a\xeb\x2e\xe1\x6b\xf1\xfd\xf9\x9b\xf3\x37\x0f\xc7\x8c\x0e\xc0\x8c\x66\xa3\xd9\xad\x95\x3b\x10\x9a\x7b\x3f\x2f\x1a\xa9\x89\xc4\xc6\x42\x5a\xf4\x00\x84\xac\x1c\xee\xc8\xd9\xab\x57\x23\x5a\xc0\xfb\x30\x1c\xfc\x30\x1c\xdc\x72\x8f\x45\x32\x97\x49\xb5\x19\xc3\x69\xbe\xb3\xeb\xd0\xf3\x7c\xf6\xe3\x5d\x5e\x12\x92\x9d\x4b\xc6\xa0\x2b\x86\x20\x36\x5c\x99\x53\x90\xc5\x42\x58\x13\xd0\x84\x81\x0d\x80\x79\x14\x41\xd9\x3d\xef\xb0\x25\x44\xa0\x09\xe8\x7a\x3d\x2b\xa7\xe4\x7e\x41\x88\xb1\x24\xc5\x4b\x99\x55\x4f\xf4\x35\x97\x76\x4b\xc8\xb9\x6c\x0f\xa8\x47\x2e\x08\xee\x32\x82\x40\xad\xf7\x0b\x42\xce\xce\x53\x3c\xf6\x62\xd3\x82\x8b\x85\x5f\xb7\xad\x75\x41\x99\x55\x1f\x38\xb0\x1b\x74\x4b\x6d\xb7\xc4\x0b\x67\xf5\x58\x1e\x80\xd5\xaf\xab\x4f\x76\xed\x04\x82\xb0\x12\x81\xf9\x96\xef\xbd\x1b\x95\x41\x51\xc5\x58\xfe\x72\xdd\x75\x8c\x26\x8e\x0a\x98\x6f\xb8\xd6\xd5\x0b\xc6\xa1\x76\xb8\x9c\x17\x94\xb7\x8a\x6e\xce\xa8\xef\xd1\xfc\x5b\x3f\xef\x25\xe0\x6f\x58\x3b\x7d\xb7\x46\xb7\xeb\xba\xa2\xfa\xf5\xd3\x87\xf7\x8c\xf2\xea\x5b\x38\x08\xbe\xbd\x9b\x67\xa1\xcb\x29\x89\xdb\x87\xca\xf6\x49\xee\x25\xa3\x59\x31\xa3\xf5\xeb\xb1\x23\x31\x6e\x55\xa8\xa1\xcc\xce\x0c\x45\x9d\x3f\x16\xf8\xad\xc6\x47\x25\xc7\x03\x27\x99\x3a\xd4\xc9\x11\x65\x2a\x4b\x0f\x60\x87\xbb\x77\xa0\xb9\xc7\x84\x9e\x6d\x5a\xe1\x62\x91\x73\x69\xac\x21\x66\xdd\xa0\x53\xa2\xa8\x3e\x3a\xfb\x19\x45\x60\x34\xd4\x4f\x03\xba\xc1\xd6\x7a\x15\xac\xdb\x3d\x1d\xeb\x9d\xe3\x46\xd4\x4f\xc7\xb9\xb2\x4d\xa3\x26\x7c\x63\xf4\x38\x6c\x8c\x4e\x06\x37\x3d\x18\xc7\x26\xc4\xa8\x96\x60\x1d\x94\x07\x8f\xa1\xcc\x9a\xba\xee\x88\x77\x22\x3d\x89\x28\xff\x83\x0f\x8c\x06\xf9\x54\x8c\x18\x47\xc6\x76\xdd\x33\x41\xe6\x2c\x3d\x07\x1c\x1c\x5d\xf7\x43\xab\x10\x7d\x5c\x0b\x28\xa7\xd5\x9c\x26\x32\xe5\x07\x8d\x3c\xc9\x44\x8c\x8e\x9b\x15\x42\x39\x14\xbc\xff\x3f\x72\x15\x63\xf9\x9e\x37\xd8\x75\x5f\x92\xf6\x0c\x49\x63\xf4\xe4\xfe\x31\xda\x5b\xf3\xf8\xf5\x45\xed\x8f\x5e\xdd\xd6\x61\xf2\xf5\xca\xca\x3e\x17\x69\x3b\xfb\x77\x5d\x8c\x4a\xb5\xa9\x66\x93\x9b\xa1\xcb\x56\xc3\x40\x91\x9a\xf5\xe9\x3c\xb3\xb4\x36\xa0\xcb\xe3\xcc\x20\xcf\x68\x36\x9d\xd1\x3c\x62\xc5\x28\x71\xa9\xcc\x21\x38\x5d\x37\xb4\x8d\xbc\xff\xe3\xe6\xb7\xe9\x07\x65\xdf\xab\x62\x2c\x53\x4f\x8a\xf1\x9b\xc3\x19\xe5\xd5\x83\xfb\x31\xee\xc9\x83\x7f\xfd\x3b\x55\x5e\xa7\x11\xa9\xeb\x1e\x77\xd7\x7e\x6e\x2a\xa0\x1f\xcf\xe6\x45\xa8\x11\xfc\xa8\x11\xd7\x5c\xc2\xda\x64\xbb\x02\x4a\x10\x75\xba\x39\xbe\xa8\x5e\xf4\x72\x2f\x87\x3e\xbc\x57\xd3\xff\x66\xff\x0c\x00\xd7\x84\x11\x4f\x55\x0a\x00\x00")
+
+func sourceTmplHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_sourceTmplHtml,
+		"source.tmpl.html",
+	)
+}
+
+func sourceTmplHtml() (*asset, error) {
+	bytes, err := sourceTmplHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "source.tmpl.html", size: 0, mode: os.FileMode(420), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _stylingTmplHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\xcf\xb1\x4e\xc3\x30\x10\xc6\xf1\xbd\x4f\x61\x79\x85\xd8\xed\xc2\x80\xe2\x32\x33\xf0\x10\x96\x7d\x71\x8e\xda\x77\x91\xef\xd4\xaa\x8a\xf2\xee\x28\x85\x89\x09\x89\xf1\x96\xdf\xff\xbe\x75\xcd\x30\x21\x81\xb1\xa2\xf7\x8a\x54\xec\xb6\x1d\xc6\x06\x1a\x0d\xc5\x06\xc1\x5e\x11\x6e\x0b\x77\xb5\x26\x31\x29\x90\x06\x7b\xc3\xac\x73\xc8\x70\xc5\x04\xc3\xe3\x78\x36\x48\xa8\x18\xeb\x20\x29\x56\x08\x27\x77\xb4\xe7\xc3\x58\x91\x2e\xa6\x43\x0d\x0f\x1c\x64\x06\x50\x6b\xe6\x0e\x53\xb0\xb3\xea\x22\xaf\xde\x8b\x72\x8f\x05\x5c\x61\x2e\x15\xe2\x82\xe2\x12\x37\x9f\x38\x83\x2b\xa0\x2d\x57\x87\xec\x4f\xee\xe8\x5e\x7c\x8b\x0a\x1d\x63\x75\x48\x19\x0b\x0f\x0b\xd2\xc5\x35\x24\x97\x44\xf6\x9e\xa4\x8e\x8b\x1a\xe9\xe9\x9f\xfe\x6e\x7e\x8a\x3d\x8f\xfe\x9b\xfc\xe3\x96\x89\x49\xe5\x77\x09\x13\xd3\xdb\x14\x1b\xd6\x7b\xf8\xf8\x09\x3c\xbd\x27\xa6\xfd\xe5\x75\x05\xca\xdb\x76\xf8\x1a\x00\x26\x75\xc0\xb2\x87\x01\x00\x00")
 
 func stylingTmplHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -340,12 +382,14 @@ var _bindata = map[string]func() (*asset, error){
 	"badquery.tmpl.html":     badqueryTmplHtml,
 	"benchmarks.tmpl.html":   benchmarksTmplHtml,
 	"chart.js":               chartJs,
+	"compare.tmpl.html":      compareTmplHtml,
 	"footer.tmpl.html":       footerTmplHtml,
 	"home.tmpl.html":         homeTmplHtml,
 	"nobenchmarks.tmpl.html": nobenchmarksTmplHtml,
 	"runs.tmpl.html":         runsTmplHtml,
 	"sortable.css":           sortableCss,
 	"sortable.js":            sortableJs,
+	"source.tmpl.html":       sourceTmplHtml,
 	"styling.tmpl.html":      stylingTmplHtml,
 }
 
@@ -395,12 +439,14 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"badquery.tmpl.html":     {badqueryTmplHtml, map[string]*bintree{}},
 	"benchmarks.tmpl.html":   {benchmarksTmplHtml, map[string]*bintree{}},
 	"chart.js":               {chartJs, map[string]*bintree{}},
+	"compare.tmpl.html":      {compareTmplHtml, map[string]*bintree{}},
 	"footer.tmpl.html":       {footerTmplHtml, map[string]*bintree{}},
 	"home.tmpl.html":         {homeTmplHtml, map[string]*bintree{}},
 	"nobenchmarks.tmpl.html": {nobenchmarksTmplHtml, map[string]*bintree{}},
 	"runs.tmpl.html":         {runsTmplHtml, map[string]*bintree{}},
 	"sortable.css":           {sortableCss, map[string]*bintree{}},
 	"sortable.js":            {sortableJs, map[string]*bintree{}},
+	"source.tmpl.html":       {sourceTmplHtml, map[string]*bintree{}},
 	"styling.tmpl.html":      {stylingTmplHtml, map[string]*bintree{}},
 }}
 
@@ -2,32 +2,269 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-google.charts.load('current', {packages: ['corechart', 'line']});
-
-function drawChart(items, elem) {
-  var data = new google.visualization.DataTable();
-  data.addColumn('datetime', 'date');
-  data.addColumn('number', 'cpu time');
-
-  data.addRows(items);
-
-  var options = {
-    hAxis: {
-      title: 'date',
-      slantedText: false,
-    },
-    vAxis: {
-      title: 'ns/op'
-    },
-    backgroundColor: '#f1f8e9',
-    width: '500',
-    height: '190',
-    explorer: {
-      actions: ['dragToZoom', 'rightClickToReset'],
-      keepInBounds: true
-    },
-  };
-
-  var chart = new google.visualization.LineChart(elem);
-  chart.draw(data, options);
+// Self-contained SVG charts of the history of a benchmark, drawn from the
+// results of the /api/v1 endpoints.
+
+var chartMetrics = [
+  {field: 'NanoSecsPerOp', title: 'ns/op'},
+  {field: 'AllocsPerOp', title: 'allocs/op'},
+  {field: 'MegaBytesPerSec', title: 'MB/s'},
+];
+
+// metricValue returns the value of metric in the run r, which is undefined for
+// custom metrics that r does not report.
+function metricValue(r, metric) {
+  if (metric.custom) {
+    return (r.Run.Metrics || {})[metric.field];
+  }
+  return r.Run[metric.field];
+}
+
+// customMetrics returns the custom metrics reported by the runs of series.
+function customMetrics(series) {
+  var units = {};
+  series.forEach(function(s) {
+    s.runs.forEach(function(r) {
+      for (var unit in r.Run.Metrics || {}) {
+        units[unit] = true;
+      }
+    });
+  });
+  return Object.keys(units).sort().map(function(unit) {
+    return {field: unit, title: unit, custom: true};
+  });
+}
+
+var chartColors = ['#3f51b5', '#e91e63', '#009688', '#ff9800', '#795548', '#9c27b0', '#607d8b', '#cddc39'];
+
+var svgNS = 'http://www.w3.org/2000/svg';
+
+// drawCharts plots each metric of the runs of the benchmark with the given id
+// over upload time in elem. If overlay is true, the runs of the other
+// scenarios of the benchmark with the same name are plotted too.
+function drawCharts(elem, id, name, overlay) {
+  var benchmarks = fetchAll('/api/v1/runs?id=' + encodeURIComponent(id), 'Runs').then(function(runs) {
+    return [{id: id, label: null, runs: runs}];
+  });
+  if (overlay) {
+    benchmarks = Promise.all([
+      benchmarks,
+      fetchAll('/api/v1/benchmarks?q=' + encodeURIComponent(quote(name)), 'Benchmarks'),
+    ]).then(function(results) {
+      var series = results[0];
+      var others = results[1].filter(function(bm) { return bm.Name === name && bm.ID !== id; });
+      return Promise.all(others.map(function(bm) {
+        return fetchAll('/api/v1/runs?id=' + encodeURIComponent(bm.ID), 'Runs').then(function(runs) {
+          return {id: bm.ID, label: scenarioLabel(bm), runs: runs};
+        });
+      })).then(function(more) {
+        series[0].label = 'this scenario';
+        return series.concat(more);
+      });
+    });
+  }
+  benchmarks.then(function(series) {
+    while (elem.firstChild) {
+      elem.removeChild(elem.firstChild);
+    }
+    chartMetrics.concat(customMetrics(series)).forEach(function(metric) {
+      var measured = series.some(function(s) {
+        return s.runs.some(function(r) { return metric.custom ? metricValue(r, metric) !== undefined : metricValue(r, metric) > 0; });
+      });
+      if (measured) {
+        elem.appendChild(drawChart(series, metric));
+      }
+    });
+  }).catch(function(err) {
+    elem.textContent = 'Failed to draw charts: ' + err;
+  });
+}
+
+// fetchAll returns a promise of the concatenation of the field of all the
+// pages of results of url.
+function fetchAll(url, field) {
+  return fetchPages(url + '&limit=1000', field);
+}
+
+function fetchPages(url, field) {
+  return fetch(url).then(function(resp) {
+    return resp.json();
+  }).then(function(page) {
+    if (page.Error) {
+      throw page.Error;
+    }
+    var items = page[field] || [];
+    if (!page.Next) {
+      return items;
+    }
+    return fetchPages(page.Next, field).then(function(more) {
+      return items.concat(more);
+    });
+  });
+}
+
+function quote(s) {
+  return s.indexOf(' ') < 0 ? s : '"' + s + '"';
+}
+
+function scenarioLabel(bm) {
+  var s = bm.Scenario;
+  var label = s.Os.Name + ' ' + s.Cpu.Architecture;
+  if (s.Cpu.Description) {
+    label += ' (' + s.Cpu.Description + ')';
+  }
+  label += ' ' + bm.Uploader;
+  if (s.Label) {
+    label += ' [' + s.Label + ']';
+  }
+  return label;
+}
+
+function svg(tag, attrs, parent) {
+  var e = document.createElementNS(svgNS, tag);
+  for (var k in attrs) {
+    e.setAttribute(k, attrs[k]);
+  }
+  if (parent) {
+    parent.appendChild(e);
+  }
+  return e;
+}
+
+function text(str, attrs, parent) {
+  var e = svg('text', attrs, parent);
+  e.textContent = str;
+  return e;
+}
+
+// drawChart returns an SVG element plotting the given metric of the runs of
+// each of series.
+function drawChart(series, metric) {
+  var width = 500, height = 190 + (series.length > 1 ? 15 * series.length : 0);
+  var left = 60, right = 10, top = 20, bottom = 40 + (series.length > 1 ? 15 * series.length : 0);
+  var plotW = width - left - right, plotH = height - top - bottom;
+
+  // Only plot the runs that report the metric, one point per upload.
+  series = series.map(function(s) {
+    var runs = s.runs.filter(function(r) { return metricValue(r, metric) !== undefined; });
+    return {id: s.id, label: s.label, points: uploadPoints(runs, metric)};
+  });
+  var tmin = Infinity, tmax = -Infinity, vmax = 0;
+  series.forEach(function(s) {
+    s.points.forEach(function(p) {
+      tmin = Math.min(tmin, p.time);
+      tmax = Math.max(tmax, p.time);
+      vmax = Math.max(vmax, p.high !== undefined ? p.high : p.value);
+    });
+  });
+  if (tmax === tmin) {
+    tmin -= 3600 * 1000;
+    tmax += 3600 * 1000;
+  }
+  vmax = vmax > 0 ? vmax * 1.1 : 1;
+  var x = function(t) { return left + (t - tmin) / (tmax - tmin) * plotW; };
+  var y = function(v) { return top + plotH - v / vmax * plotH; };
+
+  var chart = svg('svg', {width: width, height: height, 'class': 'chart'});
+  svg('rect', {x: 0, y: 0, width: width, height: height, fill: '#f1f8e9'}, chart);
+  text(metric.title, {x: left, y: 14, 'font-size': 12, 'font-weight': 'bold'}, chart);
+  // Axes, with 4 ticks on each.
+  svg('line', {x1: left, y1: top + plotH, x2: left + plotW, y2: top + plotH, stroke: '#999'}, chart);
+  svg('line', {x1: left, y1: top, x2: left, y2: top + plotH, stroke: '#999'}, chart);
+  for (var i = 0; i <= 4; i++) {
+    var v = vmax * i / 4;
+    svg('line', {x1: left, y1: y(v), x2: left + plotW, y2: y(v), stroke: '#ddd'}, chart);
+    text(formatValue(v), {x: left - 4, y: y(v) + 4, 'font-size': 10, 'text-anchor': 'end'}, chart);
+  }
+  for (var i = 0; i <= 3; i++) {
+    var t = tmin + (tmax - tmin) * i / 3;
+    text(new Date(t).toLocaleDateString(), {x: x(t), y: top + plotH + 14, 'font-size': 10, 'text-anchor': 'middle'}, chart);
+  }
+  series.forEach(function(s, idx) {
+    var color = chartColors[idx % chartColors.length];
+    // The noise band spans the confidence intervals of the uploads with
+    // multiple samples.
+    if (s.points.some(function(p) { return p.high !== undefined; })) {
+      var upper = s.points.map(function(p) {
+        return x(p.time) + ',' + y(p.high !== undefined ? p.high : p.value);
+      });
+      var lower = s.points.slice().reverse().map(function(p) {
+        return x(p.time) + ',' + y(p.low !== undefined ? p.low : p.value);
+      });
+      svg('polygon', {points: upper.concat(lower).join(' '), fill: color, 'fill-opacity': 0.15, stroke: 'none'}, chart);
+    }
+    var points = s.points.map(function(p) {
+      return x(p.time) + ',' + y(p.value);
+    });
+    svg('polyline', {points: points.join(' '), fill: 'none', stroke: color, 'stroke-width': 1.5}, chart);
+    // Each point links to the description of its source code.
+    s.points.forEach(function(p) {
+      var link = svg('a', {href: '?s=' + encodeURIComponent(p.source)}, chart);
+      var pt = svg('circle', {cx: x(p.time), cy: y(p.value), r: 3, fill: color}, link);
+      var title = new Date(p.time).toLocaleString() + ': ' + p.value + ' ' + metric.title;
+      if (p.samples > 1) {
+        title += ' (median of ' + p.samples + ' samples';
+        if (p.high !== undefined) {
+          title += ', 95% CI ' + formatValue(p.low) + '-' + formatValue(p.high);
+        }
+        title += ')';
+      }
+      if (s.label) {
+        title = s.label + '\n' + title;
+      }
+      svg('title', {}, pt).textContent = title;
+    });
+    if (series.length > 1) {
+      var ly = top + plotH + 30 + 15 * idx;
+      svg('rect', {x: left, y: ly - 8, width: 10, height: 10, fill: color}, chart);
+      var link = svg('a', {href: '?id=' + encodeURIComponent(s.id)}, chart);
+      text(s.label, {x: left + 14, y: ly + 1, 'font-size': 10}, link);
+    }
+  });
+  return chart;
+}
+
+// uploadPoints returns the points to plot for the given metric of runs, one
+// per upload with the median of the values of its samples (i.e., its runs)
+// ordered by upload time. For ns/op, the points of uploads with multiple
+// samples also have the bounds of the confidence interval of their mean.
+function uploadPoints(runs, metric) {
+  var byUpload = {}, points = [];
+  runs.forEach(function(r) {
+    var key = r.UploadID || r.UploadTime;
+    var p = byUpload[key];
+    if (!p) {
+      p = byUpload[key] = {time: new Date(r.UploadTime).getTime(), source: r.SourceCodeID, stats: r.Stats, values: []};
+      points.push(p);
+    }
+    p.values.push(metricValue(r, metric));
+  });
+  points.forEach(function(p) {
+    p.samples = p.values.length;
+    p.value = medianOf(p.values);
+    if (!metric.custom && metric.field === 'NanoSecsPerOp' && p.stats && p.stats.Samples > 1) {
+      p.low = p.stats.CILow;
+      p.high = p.stats.CIHigh;
+    }
+  });
+  return points.sort(function(a, b) { return a.time - b.time; });
+}
+
+function medianOf(values) {
+  var sorted = values.slice().sort(function(a, b) { return a - b; });
+  var n = sorted.length;
+  return n % 2 === 1 ? sorted[(n - 1) / 2] : (sorted[n / 2 - 1] + sorted[n / 2]) / 2;
+}
+
+function formatValue(v) {
+  if (v >= 1e9) {
+    return (v / 1e9).toPrecision(3) + 'G';
+  }
+  if (v >= 1e6) {
+    return (v / 1e6).toPrecision(3) + 'M';
+  }
+  if (v >= 1e3) {
+    return (v / 1e3).toPrecision(3) + 'k';
+  }
+  return Number(v.toPrecision(3)).toString();
 }
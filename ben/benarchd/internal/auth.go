@@ -0,0 +1,166 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/vanadium/services/ben/archive"
+	v23 "v.io/v23"
+	"v.io/v23/context"
+	"v.io/v23/security"
+)
+
+const (
+	// sessionCookie is the name of the cookie with the ID of the session
+	// that authenticates the requests of a browser.
+	sessionCookie = "benarchd_session"
+	// loginTokenLifetime is how long a URL returned by archive.Login can
+	// be used to start a session.
+	loginTokenLifetime = 5 * time.Minute
+	// sessionLifetime is how long a session authenticates requests.
+	sessionLifetime = 24 * time.Hour
+)
+
+// Authenticator authenticates HTTP requests by the blessings that signed them
+// (see archive.SignRequest) or, for browsers, by a session started via the
+// URL returned by archive.Login, and authorizes them according to a Policy.
+//
+// Sessions are kept in memory, so they are lost when the process exits.
+type Authenticator struct {
+	ctx    *context.T
+	url    string
+	policy *Policy
+
+	mu       sync.Mutex
+	tokens   map[string]session // Unused login tokens, by token.
+	sessions map[string]session // Sessions, by ID.
+}
+
+type session struct {
+	names   []string
+	expires time.Time
+}
+
+// NewAuthenticator returns an Authenticator that recognizes the blessings
+// that the principal of ctx recognizes and enforces policy. url is the public
+// URL of the web interface.
+func NewAuthenticator(ctx *context.T, url string, policy *Policy) *Authenticator {
+	return &Authenticator{
+		ctx:      ctx,
+		url:      url,
+		policy:   policy,
+		tokens:   make(map[string]session),
+		sessions: make(map[string]session),
+	}
+}
+
+// Authenticate returns the blessing names that authenticate r, which has the
+// given body. It returns no names and no error if r is not authenticated.
+func (a *Authenticator) Authenticate(r *http.Request, body []byte) ([]string, error) {
+	if len(r.Header.Get(archive.SignatureHeader)) > 0 {
+		now := time.Now()
+		blessings, err := archive.VerifyRequest(r, body, now)
+		if err != nil {
+			return nil, err
+		}
+		call := security.NewCall(&security.CallParams{
+			Timestamp:       now,
+			LocalPrincipal:  v23.GetPrincipal(a.ctx),
+			RemoteBlessings: blessings,
+		})
+		names, rejected := security.RemoteBlessingNames(a.ctx, call)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no recognizable blessing names (rejected names: %v)", rejected)
+		}
+		return names, nil
+	}
+	if c, err := r.Cookie(sessionCookie); err == nil {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if s, ok := a.sessions[c.Value]; ok && time.Now().Before(s.expires) {
+			return s.names, nil
+		}
+		delete(a.sessions, c.Value)
+	}
+	return nil, nil
+}
+
+// ServeHTTP serves archive.LoginPath: POST requests signed by
+// archive.SignRequest return a URL with a login token, and GET requests to
+// that URL start a session and redirect to the home page.
+func (a *Authenticator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		names, err := a.Authenticate(r, nil)
+		if err == nil && len(names) == 0 {
+			err = fmt.Errorf("request not signed")
+		}
+		if err != nil {
+			apiJSON(w, http.StatusUnauthorized, archive.LoginResult{Error: err.Error()})
+			return
+		}
+		token, err := a.add(a.tokens, names, loginTokenLifetime)
+		if err != nil {
+			apiJSON(w, http.StatusInternalServerError, archive.LoginResult{Error: err.Error()})
+			return
+		}
+		apiJSON(w, http.StatusOK, archive.LoginResult{URL: a.url + archive.LoginPath + "?token=" + url.QueryEscape(token)})
+	case http.MethodGet:
+		token := r.FormValue("token")
+		a.mu.Lock()
+		s, ok := a.tokens[token]
+		delete(a.tokens, token)
+		a.mu.Unlock()
+		if !ok || time.Now().After(s.expires) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(w, "ERROR: invalid or expired login URL")
+			return
+		}
+		id, err := a.add(a.sessions, s.names, sessionLifetime)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintln(w, "ERROR:", err)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookie,
+			Value:    id,
+			Path:     "/",
+			MaxAge:   int(sessionLifetime.Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	default:
+		apiJSON(w, http.StatusMethodNotAllowed, archive.LoginResult{Error: fmt.Sprintf("method %v not allowed", r.Method)})
+	}
+}
+
+// add adds a session for names, which expires after lifetime, to m under a
+// new random key and returns the key. Expired sessions are removed from m.
+func (a *Authenticator) add(m map[string]session, names []string, lifetime time.Duration) (string, error) {
+	var buf [24]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	key := base64.RawURLEncoding.EncodeToString(buf[:])
+	now := time.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for k, s := range m {
+		if now.After(s.expires) {
+			delete(m, k)
+		}
+	}
+	m[key] = session{names, now.Add(lifetime)}
+	return key, nil
+}
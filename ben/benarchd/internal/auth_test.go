@@ -0,0 +1,150 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	v23 "v.io/v23"
+	"v.io/v23/security/access"
+	_ "v.io/x/ref/runtime/factories/generic"
+	"v.io/x/ref/test"
+	"v.io/x/ref/test/testutil"
+)
+
+func TestAuthenticator(t *testing.T) {
+	ctx, shutdown := test.V23Init()
+	defer shutdown()
+	store, cleanup := newSQLiteStore(t)
+	defer cleanup()
+	scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+	runs := []ben.Run{{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: 5}}
+	for _, uploader := range []string{"test-blessing:alice", "test-blessing:secret"} {
+		code := ben.SourceCode(strings.TrimPrefix(uploader, "test-blessing:") + "-Commit")
+		if err := store.Save(nil, scenario, code, uploader, time.Now(), runs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	policy := &Policy{
+		Permissions: access.Permissions{}.Add("test-blessing", string(access.Read)),
+		Rules: []Rule{{
+			Uploader:    "test-blessing:secret",
+			Permissions: access.Permissions{}.Add("test-blessing:secret", string(access.Read)),
+		}},
+	}
+	assets, err := NewAssets("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := NewAuthenticator(ctx, "http://benarchd", policy)
+	h := NewHTTPHandler(assets, store, RegressionPolicy{}, auth)
+
+	idp := testutil.IDProviderFromPrincipal(v23.GetPrincipal(ctx))
+	bob := testutil.NewPrincipal()
+	if err := idp.Bless(bob, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	bobBlessings, _ := bob.BlessingStore().Default()
+	sign := func(req *http.Request) *http.Request {
+		if err := archive.SignRequest(bob, bobBlessings, req, nil, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+	serve := func(h http.Handler, req *http.Request, wantStatus int) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != wantStatus {
+			t.Errorf("%v %v: got status %v, want %v: %s", req.Method, req.URL, w.Code, wantStatus, w.Body)
+		}
+		return w
+	}
+	uploaders := func(w *httptest.ResponseRecorder) []string {
+		var page struct{ Benchmarks []Benchmark }
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("%v: %s", err, w.Body)
+		}
+		var ret []string
+		for _, bm := range page.Benchmarks {
+			ret = append(ret, bm.Uploader)
+		}
+		return ret
+	}
+
+	// Unauthenticated requests are rejected, except for assets.
+	serve(h, httptest.NewRequest("GET", "/", nil), http.StatusUnauthorized)
+	serve(h, httptest.NewRequest("GET", "/api/v1/benchmarks", nil), http.StatusUnauthorized)
+	serve(h, httptest.NewRequest("GET", "/chart.js", nil), http.StatusOK)
+
+	// Signed requests only get the results that bob can read.
+	w := serve(h, sign(httptest.NewRequest("GET", "/api/v1/benchmarks", nil)), http.StatusOK)
+	if got, want := uploaders(w), []string{"test-blessing:alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// Only the sources of results that bob can read are described.
+	serve(h, sign(httptest.NewRequest("GET", "/api/v1/sources?s=alice-Commit", nil)), http.StatusOK)
+	serve(h, sign(httptest.NewRequest("GET", "/api/v1/sources?s=secret-Commit", nil)), http.StatusNotFound)
+	serve(h, sign(httptest.NewRequest("GET", "/api/v1/sources?s=unknown", nil)), http.StatusNotFound)
+	// Requests signed for a different URL are rejected.
+	req := sign(httptest.NewRequest("GET", "/api/v1/benchmarks", nil))
+	req.URL.RawQuery = "limit=1"
+	serve(h, req, http.StatusUnauthorized)
+
+	// Login via the URL returned for a signed request.
+	serve(auth, httptest.NewRequest("POST", archive.LoginPath, nil), http.StatusUnauthorized)
+	w = serve(auth, sign(httptest.NewRequest("POST", archive.LoginPath, nil)), http.StatusOK)
+	var login archive.LoginResult
+	if err := json.Unmarshal(w.Body.Bytes(), &login); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(login.URL, "http://benarchd"+archive.LoginPath+"?token=") {
+		t.Fatalf("unexpected login URL %q", login.URL)
+	}
+	w = serve(auth, httptest.NewRequest("GET", login.URL, nil), http.StatusSeeOther)
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != sessionCookie {
+		t.Fatalf("got cookies %v", cookies)
+	}
+	// Login URLs can only be used once.
+	serve(auth, httptest.NewRequest("GET", login.URL, nil), http.StatusUnauthorized)
+
+	req = httptest.NewRequest("GET", "/api/v1/benchmarks", nil)
+	req.AddCookie(cookies[0])
+	w = serve(h, req, http.StatusOK)
+	if got, want := uploaders(w), []string{"test-blessing:alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	req = httptest.NewRequest("GET", "/api/v1/benchmarks", nil)
+	req.AddCookie(&http.Cookie{Name: sessionCookie, Value: "forged"})
+	serve(h, req, http.StatusUnauthorized)
+
+	// Blessings that are not authorized to read anything.
+	mallory := testutil.NewPrincipal("mallory")
+	malloryBlessings, _ := mallory.BlessingStore().Default()
+	req = httptest.NewRequest("GET", "/api/v1/benchmarks", nil)
+	if err := archive.SignRequest(mallory, malloryBlessings, req, nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	serve(h, req, http.StatusUnauthorized)
+	other := testutil.NewPrincipal()
+	if err := idp.Bless(other, "other"); err != nil {
+		t.Fatal(err)
+	}
+	policy.Permissions = access.Permissions{}.Add("test-blessing:bob", string(access.Read))
+	otherBlessings, _ := other.BlessingStore().Default()
+	req = httptest.NewRequest("GET", "/api/v1/benchmarks", nil)
+	if err := archive.SignRequest(other, otherBlessings, req, nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	serve(h, req, http.StatusForbidden)
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vanadium/services/ben"
+)
+
+// significanceLevel is the p-value below which a difference between the
+// results of two revisions is considered significant.
+const significanceLevel = 0.05
+
+// comparedMetrics enumerates the ben.Run fields compared by Compare.
+var comparedMetrics = append(metrics[:len(metrics):len(metrics)],
+	metric{"MB/s", func(r ben.Run) float64 { return r.MegaBytesPerSec }})
+
+// Revision selects the runs of a Benchmark to compare: either those of the
+// source code with ID SourceCodeID or, if SourceCodeID is empty, those of the
+// most recent upload at or before UploadTime.
+type Revision struct {
+	SourceCodeID string
+	UploadTime   time.Time
+}
+
+// ParseRevision parses an upload time in RFC 3339 format (e.g.
+// "2016-02-14T15:04:05Z") or, failing that, a source code ID into a Revision.
+func ParseRevision(s string) Revision {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return Revision{UploadTime: t}
+	}
+	return Revision{SourceCodeID: s}
+}
+
+func (r Revision) String() string {
+	if r.SourceCodeID != "" {
+		return r.SourceCodeID
+	}
+	return r.UploadTime.Format(time.RFC3339)
+}
+
+// Comparison compares the runs of a Benchmark for two revisions.
+type Comparison struct {
+	Benchmark Benchmark
+	// Number of runs of each revision.
+	OldRuns, NewRuns int
+	// Deltas holds one entry for each of MetricNames.
+	Deltas []Delta
+}
+
+// Delta compares the values of a metric for two revisions.
+type Delta struct {
+	// Measured is false if the metric was not measured in either revision
+	// (e.g., allocations of benchmarks run without -benchmem), in which
+	// case the other fields should be ignored.
+	Measured bool
+	// Mean of the values of each revision.
+	Old, New float64
+	// Percent is the relative change from Old to New, in percent.
+	Percent float64
+	// PValue of the Mann-Whitney U test of the hypothesis that the values
+	// of both revisions have the same distribution.
+	PValue float64
+}
+
+// Significant returns true if the difference between the revisions is
+// unlikely to be noise.
+func (d Delta) Significant() bool { return d.PValue < significanceLevel }
+
+// String returns the relative change from Old to New, e.g. "+12.34%", or "~"
+// if the change is not significant.
+func (d Delta) String() string {
+	if !d.Significant() {
+		return "~"
+	}
+	return fmt.Sprintf("%+.2f%%", d.Percent)
+}
+
+// MetricNames returns the names of the metrics compared in a Comparison, e.g.
+// "ns/op".
+func MetricNames() []string {
+	ret := make([]string, len(comparedMetrics))
+	for i, m := range comparedMetrics {
+		ret[i] = m.name
+	}
+	return ret
+}
+
+// Compare compares the runs of the revisions oldRev and newRev of each of the
+// benchmarks matching query. Benchmarks without runs for both revisions are
+// omitted.
+func Compare(store Store, query *Query, oldRev, newRev Revision) ([]Comparison, error) {
+	// Collect the benchmarks first, so that the store is not queried for
+	// runs while iterating over them.
+	var bms []Benchmark
+	bmarks := store.Benchmarks(query)
+	for bmarks.Advance() {
+		bms = append(bms, bmarks.Value())
+	}
+	err := bmarks.Err()
+	bmarks.Close()
+	if err != nil {
+		return nil, err
+	}
+	var ret []Comparison
+	for _, bm := range bms {
+		_, itr := store.Runs(bm.ID)
+		oldRuns, newRuns, err := selectRuns(itr, oldRev, newRev)
+		if err != nil {
+			return nil, err
+		}
+		if len(oldRuns) == 0 || len(newRuns) == 0 {
+			continue
+		}
+		ret = append(ret, compareRuns(bm, oldRuns, newRuns))
+	}
+	return ret, nil
+}
+
+// selectRuns returns the runs from itr, which are ordered from the most
+// recent, of each of the revisions.
+func selectRuns(itr RunIterator, oldRev, newRev Revision) (oldRuns, newRuns []ben.Run, err error) {
+	defer itr.Close()
+	var oldSel, newSel revisionSelector
+	for itr.Advance() {
+		run, code, uploaded := itr.Value()
+		if oldSel.match(oldRev, code, uploaded) {
+			oldRuns = append(oldRuns, run)
+		}
+		if newSel.match(newRev, code, uploaded) {
+			newRuns = append(newRuns, run)
+		}
+	}
+	return oldRuns, newRuns, itr.Err()
+}
+
+// revisionSelector tracks the upload selected for a Revision with an
+// UploadTime while iterating over runs ordered from the most recent.
+type revisionSelector struct {
+	upload time.Time
+}
+
+func (s *revisionSelector) match(r Revision, code string, uploaded time.Time) bool {
+	if r.SourceCodeID != "" {
+		return code == r.SourceCodeID
+	}
+	if uploaded.After(r.UploadTime) {
+		return false
+	}
+	if s.upload.IsZero() {
+		s.upload = uploaded
+	}
+	return uploaded.Equal(s.upload)
+}
+
+func compareRuns(bm Benchmark, oldRuns, newRuns []ben.Run) Comparison {
+	ret := Comparison{
+		Benchmark: bm,
+		OldRuns:   len(oldRuns),
+		NewRuns:   len(newRuns),
+	}
+	for _, m := range comparedMetrics {
+		oldValues, newValues := values(m, oldRuns), values(m, newRuns)
+		d := Delta{
+			Old:    mean(oldValues),
+			New:    mean(newValues),
+			PValue: mannWhitneyU(oldValues, newValues),
+		}
+		d.Measured = d.Old != 0 || d.New != 0
+		if d.Old != 0 {
+			d.Percent = 100 * (d.New - d.Old) / d.Old
+		}
+		ret.Deltas = append(ret.Deltas, d)
+	}
+	return ret
+}
+
+func values(m metric, runs []ben.Run) []float64 {
+	ret := make([]float64, len(runs))
+	for i, r := range runs {
+		ret[i] = m.value(r)
+	}
+	return ret
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	for _, test := range []struct {
+		x, y []float64
+		want float64
+	}{
+		{[]float64{1, 2, 3}, []float64{4, 5, 6}, 0.1},
+		{[]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{[]float64{1, 3, 5}, []float64{2, 4, 6}, 0.7},
+		{[]float64{1}, []float64{2}, 1},
+		{[]float64{1, 1, 1}, []float64{1, 1, 1}, 1},
+		// Ties use the normal approximation.
+		{[]float64{1, 1, 2, 2, 2}, []float64{3, 3, 4, 4, 4}, 0.0097},
+	} {
+		if got := mannWhitneyU(test.x, test.y); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("mannWhitneyU(%v, %v): got %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	store, cleanup := newSQLiteStore(t)
+	defer cleanup()
+	var (
+		scenario = ben.Scenario{
+			Cpu: ben.Cpu{Architecture: "amd64"},
+			Os:  ben.Os{Name: "linux"},
+		}
+		start = time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+	)
+	upload := func(code ben.SourceCode, day int, runs ...ben.Run) {
+		if err := store.Save(nil, scenario, code, "alice", start.AddDate(0, 0, day), runs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		upload("A", i,
+			ben.Run{Name: "BenchmarkFast", NanoSecsPerOp: float64(100 + i), AllocsPerOp: 2},
+			ben.Run{Name: "BenchmarkNoisy", NanoSecsPerOp: float64(100 + 10*(i%2))})
+		upload("B", 5+i,
+			ben.Run{Name: "BenchmarkFast", NanoSecsPerOp: float64(50 + i), AllocsPerOp: 2},
+			ben.Run{Name: "BenchmarkNoisy", NanoSecsPerOp: float64(105 + 10*(i%2))})
+	}
+	upload("C", 10, ben.Run{Name: "BenchmarkOther", NanoSecsPerOp: 1})
+
+	got, err := Compare(store, &Query{}, ParseRevision("A"), ParseRevision("B"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"BenchmarkFast":  {"-49.02%", "~", "~", "~"},
+		"BenchmarkNoisy": {"~", "~", "~", "~"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d comparisons, want %d", len(got), len(want))
+	}
+	for _, c := range got {
+		if c.OldRuns != 5 || c.NewRuns != 5 {
+			t.Errorf("%v: got %d+%d runs, want 5+5", c.Benchmark.Name, c.OldRuns, c.NewRuns)
+		}
+		for i, d := range c.Deltas {
+			if got, want := d.String(), want[c.Benchmark.Name][i]; got != want {
+				t.Errorf("%v %v: got %v, want %v", c.Benchmark.Name, MetricNames()[i], got, want)
+			}
+		}
+		if c.Deltas[1].Measured != (c.Benchmark.Name == "BenchmarkFast") {
+			t.Errorf("%v: allocs/op measured: %v", c.Benchmark.Name, c.Deltas[1].Measured)
+		}
+	}
+
+	// Upload times select the most recent upload at or before them.
+	got, err = Compare(store, &Query{Name: "Fast"}, ParseRevision("2016-02-14T12:00:00Z"), ParseRevision("2016-02-23T00:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d comparisons, want 1", len(got))
+	}
+	if d := got[0].Deltas[0]; d.Old != 100 || d.New != 54 || d.Significant() {
+		t.Errorf("got %+v, want 100 -> 54, not significant", d)
+	}
+}
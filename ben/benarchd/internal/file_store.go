@@ -0,0 +1,330 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"v.io/v23/context"
+)
+
+// NewFileStore returns a Store implementation that keeps all results in memory
+// and persists them in a journal of the changes made to the store, which is
+// created if it does not exist and replayed otherwise.
+//
+// Each upload is appended to the journal and synced before it is reported as
+// successful. Deletions and downsampling instead replace the journal by a
+// snapshot of the remaining results, so that its size and the time it takes
+// to replay it are bounded by the results in the store.
+func NewFileStore(filename string) (Store, error) {
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	s := &fileStore{memStore: newMemStore(), filename: filename, f: f}
+	if err := s.replay(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to replay %v: %v", filename, err)
+	}
+	return s, nil
+}
+
+type fileStore struct {
+	*memStore
+	mu       sync.Mutex // Serializes changes, so that they are journaled in order.
+	filename string
+	f        *os.File
+	err      error // Error that prevents further changes from being journaled.
+}
+
+// journalRecord is a change to the store, of which exactly one field is set.
+// The journal is a sequence of JSON-encoded records, one per line: an optional
+// snapshot followed by uploads.
+type journalRecord struct {
+	Snapshot *journalSnapshot `json:",omitempty"`
+	Save     *journalSave     `json:",omitempty"`
+}
+
+// journalSnapshot is the state of a memStore, including the IDs of its
+// benchmarks and uploads, so that they are preserved by compaction.
+type journalSnapshot struct {
+	LastBenchmark, LastUpload int64
+	Sources                   []ben.SourceCode
+	Uploads                   []journalUpload
+	Benchmarks                []journalBenchmark
+}
+
+type journalUpload struct {
+	ID   int64
+	Time time.Time
+	Code string
+	Env  ben.Environment
+}
+
+type journalBenchmark struct {
+	ID        int64
+	Benchmark Benchmark
+	Runs      []journalRun
+	// Medians of the custom metrics of the samples in each upload, by
+	// upload ID. The Stats of the samples are computed from Runs.
+	Metrics map[int64]map[string]float64 `json:",omitempty"`
+}
+
+type journalRun struct {
+	Upload int64
+	Run    ben.Run
+}
+
+type journalSave struct {
+	Scenario   ben.Scenario
+	Code       ben.SourceCode
+	Uploader   string
+	UploadTime time.Time
+	Runs       []ben.Run
+}
+
+func (s *fileStore) Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCode, uploader string, uploadTime time.Time, runs []ben.Run) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	if err := checkRuns(runs); err != nil {
+		return err
+	}
+	buf, err := encodeRecord(journalRecord{Save: &journalSave{scenario, code, uploader, uploadTime, runs}})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.memStore.Save(ctx, scenario, code, uploader, uploadTime, runs); err != nil {
+		return err
+	}
+	return s.journal(buf)
+}
+
+func (s *fileStore) Delete(selector archive.UploadSelector) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.memStore.Delete(selector)
+	if err != nil || n == 0 {
+		return n, err
+	}
+	return n, s.compact()
+}
+
+func (s *fileStore) Downsample(before time.Time, interval time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.memStore.Downsample(before, interval)
+	if err != nil || n == 0 {
+		return n, err
+	}
+	return n, s.compact()
+}
+
+// encodeRecord returns the line of the journal that records r. Uploads are
+// encoded before they are applied to the memStore, so that one which can't
+// be encoded leaves the store unchanged.
+func encodeRecord(r journalRecord) ([]byte, error) {
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode journal record: %v", err)
+	}
+	return append(buf, '\n'), nil
+}
+
+// journal appends the encoded record buf, which has been applied to
+// s.memStore, to the journal. If that fails, the journal no longer reflects
+// the results in memory, so all further changes fail until a restart, which
+// loses the change that was not journaled.
+func (s *fileStore) journal(buf []byte) error {
+	_, err := s.f.Write(buf)
+	if err == nil {
+		err = s.f.Sync()
+	}
+	if err != nil {
+		s.err = fmt.Errorf("failed to write journal, further changes are refused until a restart, which discards the last change: %v", err)
+		return s.err
+	}
+	return nil
+}
+
+// compact replaces the journal by a snapshot of s.memStore, after a change that
+// has been applied to it. The snapshot is written to a temporary file that is
+// renamed over the journal, so that a crash leaves either the old journal or
+// the snapshot. As in journal, a failure refuses all further changes.
+func (s *fileStore) compact() error {
+	buf, err := encodeRecord(journalRecord{Snapshot: s.snapshot()})
+	if err == nil {
+		err = s.replace(buf)
+	}
+	if err != nil {
+		s.err = fmt.Errorf("failed to compact journal, further changes are refused until a restart, which discards the last change: %v", err)
+		return s.err
+	}
+	return nil
+}
+
+// replace atomically replaces the journal by buf, and leaves s.f open on the
+// new journal, positioned at its end.
+func (s *fileStore) replace(buf []byte) error {
+	tmp := s.filename + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(buf)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.filename)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	s.f.Close()
+	s.f = f
+	// Sync the directory, so that the rename is persisted.
+	dir, err := os.Open(filepath.Dir(s.filename))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// snapshot returns the state of s.memStore.
+func (s *fileStore) snapshot() *journalSnapshot {
+	m := s.memStore
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap := &journalSnapshot{LastBenchmark: m.lastBenchmark, LastUpload: m.lastUpload}
+	for _, code := range m.sources {
+		snap.Sources = append(snap.Sources, code)
+	}
+	sort.Slice(snap.Sources, func(i, j int) bool { return snap.Sources[i] < snap.Sources[j] })
+	for id, u := range m.uploads {
+		snap.Uploads = append(snap.Uploads, journalUpload{id, u.time, u.code, u.env})
+	}
+	sort.Slice(snap.Uploads, func(i, j int) bool { return snap.Uploads[i].ID < snap.Uploads[j].ID })
+	for _, id := range m.sortedIDs() {
+		bm := m.benchmarks[id]
+		b := journalBenchmark{ID: id, Benchmark: bm.Benchmark}
+		for _, r := range bm.runs {
+			b.Runs = append(b.Runs, journalRun{r.upload, r.run})
+		}
+		if len(bm.metrics) > 0 {
+			b.Metrics = bm.metrics
+		}
+		snap.Benchmarks = append(snap.Benchmarks, b)
+	}
+	return snap
+}
+
+// restore replaces s.memStore by a memStore with the state in snap.
+func (s *fileStore) restore(snap *journalSnapshot) {
+	m := newMemStore()
+	m.lastBenchmark, m.lastUpload = snap.LastBenchmark, snap.LastUpload
+	for _, code := range snap.Sources {
+		m.sources[code.ID()] = code
+	}
+	for _, u := range snap.Uploads {
+		m.uploads[u.ID] = &memUpload{u.Time, u.Code, u.Env}
+	}
+	for _, b := range snap.Benchmarks {
+		bm := &memBenchmark{
+			Benchmark: b.Benchmark,
+			stats:     make(map[int64]Stats),
+			metrics:   make(map[int64]map[string]float64),
+		}
+		ns := make(map[int64][]float64)
+		for _, r := range b.Runs {
+			bm.runs = append(bm.runs, memRun{r.Run, r.Upload})
+			ns[r.Upload] = append(ns[r.Upload], r.Run.NanoSecsPerOp)
+		}
+		for u, samples := range ns {
+			bm.stats[u] = newStats(samples)
+		}
+		for u, metrics := range b.Metrics {
+			bm.metrics[u] = metrics
+		}
+		m.benchmarks[b.ID] = bm
+		m.keys[newMemBenchmarkKey(bm.Scenario, bm.Uploader, bm.Name)] = b.ID
+	}
+	s.memStore = m
+}
+
+// replay applies the records in the journal to s.memStore and leaves the file
+// positioned at its end. An incomplete last record, left by a crash while it
+// was being written, is discarded.
+func (s *fileStore) replay() error {
+	r := bufio.NewReader(s.f)
+	var offset int64
+	for line := 1; ; line++ {
+		buf, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			if len(buf) > 0 {
+				if err := s.f.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(len(buf))
+		if err := s.apply(bytes.TrimSpace(buf)); err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+	}
+	_, err := s.f.Seek(offset, io.SeekStart)
+	return err
+}
+
+func (s *fileStore) apply(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	var r journalRecord
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&r); err != nil {
+		return err
+	}
+	var err error
+	switch {
+	case r.Snapshot != nil:
+		s.restore(r.Snapshot)
+	case r.Save != nil:
+		err = s.memStore.Save(nil, r.Save.Scenario, r.Save.Code, r.Save.Uploader, r.Save.UploadTime, r.Save.Runs)
+	default:
+		err = fmt.Errorf("empty record")
+	}
+	return err
+}
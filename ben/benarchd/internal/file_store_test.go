@@ -0,0 +1,155 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+)
+
+func TestFileStoreJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "benarchd-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "journal")
+	scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+	start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+	save := func(store Store, hours int, ns float64) {
+		runs := []ben.Run{{Name: "BenchmarkA", NanoSecsPerOp: ns}}
+		if err := store.Save(nil, scenario, "commit", "alice", start.Add(time.Duration(hours)*time.Hour), runs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store, err := NewFileStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	save(store, 0, 1)
+	save(store, 1, 2)
+	// A crash while appending a record leaves it incomplete.
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"Save":{"Scenario":`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// The incomplete record is discarded, and new records follow the
+	// complete ones.
+	if store, err = NewFileStore(filename); err != nil {
+		t.Fatal(err)
+	}
+	save(store, 2, 3)
+	if store, err = NewFileStore(filename); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := latest(t, store), map[string]float64{"alice/BenchmarkA": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	_, itr := store.Lookup(scenario, "alice", "BenchmarkA")
+	var uploads []string
+	for itr.Advance() {
+		uploads = append(uploads, itr.UploadID())
+	}
+	itr.Close()
+	if want := []string{"3", "2", "1"}; !reflect.DeepEqual(uploads, want) {
+		t.Errorf("got uploads %v, want %v", uploads, want)
+	}
+
+	// Corrupt records are not.
+	if err := ioutil.WriteFile(filename, []byte("{\"Save\":{}}\n{\"Unknown\":{}}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(filename); err == nil {
+		t.Errorf("replaying a corrupt journal unexpectedly succeeded")
+	}
+}
+
+func TestFileStoreCompaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "benarchd-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "journal")
+	scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+	start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+	save := func(store Store, code ben.SourceCode, hours int, ns float64) {
+		runs := []ben.Run{{Name: "BenchmarkA", NanoSecsPerOp: ns}, {Name: "BenchmarkA", NanoSecsPerOp: ns + 1, Metrics: map[string]float64{"p99-ns": ns * 2}}}
+		if err := store.Save(nil, scenario, code, "alice", start.Add(time.Duration(hours)*time.Hour), runs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines := func() int {
+		buf, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.Count(buf, []byte("\n"))
+	}
+
+	store, err := NewFileStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		save(store, ben.SourceCode(fmt.Sprintf("commit%d", i)), i, float64(i+1))
+	}
+	if got, want := lines(), 4; got != want {
+		t.Errorf("got %d records, want %d", got, want)
+	}
+	// Deleting and downsampling replace the journal by a single snapshot.
+	if n, err := store.Delete(archive.UploadSelector{Upload: "2"}); err != nil || n != 1 {
+		t.Fatalf("got (%v, %v), want 1 upload deleted", n, err)
+	}
+	if got, want := lines(), 1; got != want {
+		t.Errorf("got %d records, want %d", got, want)
+	}
+	if n, err := store.Downsample(start.Add(3*time.Hour), 24*time.Hour); err != nil || n != 2 {
+		t.Fatalf("got (%v, %v), want 2 runs removed", n, err)
+	}
+	if got, want := lines(), 1; got != want {
+		t.Errorf("got %d records, want %d", got, want)
+	}
+	if _, err := os.Stat(filename + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file was not renamed: %v", err)
+	}
+
+	// Uploads are appended to the snapshot, and IDs are not reused after
+	// it is replayed.
+	save(store, "commit4", 4, 5)
+	if store, err = NewFileStore(filename); err != nil {
+		t.Fatal(err)
+	}
+	save(store, "commit5", 5, 6)
+	_, itr := store.Lookup(scenario, "alice", "BenchmarkA")
+	var uploads []string
+	for itr.Advance() {
+		if id := itr.UploadID(); len(uploads) == 0 || uploads[len(uploads)-1] != id {
+			uploads = append(uploads, id)
+		}
+	}
+	itr.Close()
+	if want := []string{"6", "5", "4", "1"}; !reflect.DeepEqual(uploads, want) {
+		t.Errorf("got uploads %v, want %v", uploads, want)
+	}
+	if _, err := store.DescribeSource(ben.SourceCode("commit1").ID()); err != ErrNotFound {
+		t.Errorf("got %v, want the source of the deleted upload to be removed", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vanadium/services/ben/archive"
+	v23 "v.io/v23"
+	"v.io/v23/context"
+)
+
+// hookTimeout bounds each attempt to deliver a notification to a hook.
+const hookTimeout = 30 * time.Second
+
+// Hook is notified of newly archived benchmark results.
+type Hook interface {
+	Notify(ctx *context.T, n archive.UploadNotification) error
+	// String describes the hook in the delivery log, without any
+	// credentials that its configuration may contain.
+	String() string
+}
+
+// ParseHooks returns the hooks configured by a comma-separated list of HTTP(S)
+// URLs, to which notifications are POSTed by NewWebhook, and Vanadium object
+// names of archive.UploadSubscriber servers, which are notified by
+// NewRPCHook.
+func ParseHooks(spec string) ([]Hook, error) {
+	var hooks []Hook
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		switch {
+		case len(s) == 0:
+			continue
+		case strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://"):
+			if _, err := url.Parse(s); err != nil {
+				return nil, fmt.Errorf("invalid hook %q: %v", s, err)
+			}
+			hooks = append(hooks, NewWebhook(s))
+		default:
+			hooks = append(hooks, NewRPCHook(s))
+		}
+	}
+	return hooks, nil
+}
+
+// NewWebhook returns a Hook that POSTs notifications, JSON-encoded, to the
+// given URL. Requests are signed by the default blessings of the principal of
+// the context of the notification, like uploads signed by archive.SignRequest,
+// and must be answered with a 2xx status.
+func NewWebhook(url string) Hook {
+	return &webhook{url: url, client: &http.Client{Timeout: hookTimeout}}
+}
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+func (h *webhook) Notify(ctx *context.T, n archive.UploadNotification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	p := v23.GetPrincipal(ctx)
+	blessings, _ := p.BlessingStore().Default()
+	if err := archive.SignRequest(p, blessings, req, body, time.Now()); err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%v: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func (h *webhook) String() string {
+	u, err := url.Parse(h.url)
+	if err != nil {
+		return "webhook"
+	}
+	// Credentials are typically in the user info or the query.
+	u.User, u.RawQuery = nil, ""
+	return u.String()
+}
+
+// NewRPCHook returns a Hook that delivers notifications to the
+// archive.UploadSubscriber server with the given Vanadium object name.
+func NewRPCHook(name string) Hook {
+	return rpcHook(name)
+}
+
+type rpcHook string
+
+func (h rpcHook) Notify(ctx *context.T, n archive.UploadNotification) error {
+	return archive.UploadSubscriberClient(string(h)).Notify(ctx, n)
+}
+
+func (h rpcHook) String() string { return string(h) }
+
+// RetryPolicy configures the retries of failed deliveries of notifications.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts to deliver a
+	// notification to a hook.
+	Attempts int
+	// Backoff is the delay before the first retry, which doubles with
+	// each further retry.
+	Backoff time.Duration
+}
+
+// Delivery is an entry of the delivery log of a Notifier, which records an
+// attempt to deliver a notification to a hook.
+type Delivery struct {
+	Hook    string    // Description of the hook (see Hook.String).
+	URL     string    // URL of the results of the notification.
+	Attempt int       // Starting at 1.
+	Time    time.Time // Time at which the attempt completed.
+	Error   string    `json:",omitempty"` // Empty if the notification was delivered.
+}
+
+// NewNotifier returns a Notifier that delivers notifications to hooks in the
+// background, as configured by retry, with ctx (which also determines the
+// blessings that sign webhook requests). Each attempt is logged via ctx and,
+// if log is not nil, written to it as a JSON-encoded Delivery per line.
+func NewNotifier(ctx *context.T, hooks []Hook, retry RetryPolicy, log io.Writer) *Notifier {
+	if retry.Attempts < 1 {
+		retry.Attempts = 1
+	}
+	return &Notifier{ctx: ctx, hooks: hooks, retry: retry, log: log}
+}
+
+// Notifier delivers notifications of newly archived results to hooks. A nil
+// Notifier has no hooks.
+type Notifier struct {
+	ctx     *context.T
+	hooks   []Hook
+	retry   RetryPolicy
+	pending sync.WaitGroup
+	mu      sync.Mutex // Serializes writes to log.
+	log     io.Writer
+}
+
+// Notify starts delivering n to all hooks, and returns without waiting for the
+// deliveries to complete.
+func (nf *Notifier) Notify(n archive.UploadNotification) {
+	if nf == nil {
+		return
+	}
+	for _, h := range nf.hooks {
+		nf.pending.Add(1)
+		go func(h Hook) {
+			defer nf.pending.Done()
+			nf.deliver(h, n)
+		}(h)
+	}
+}
+
+// Wait waits for the deliveries started by Notify to complete, successfully
+// or not.
+func (nf *Notifier) Wait() {
+	if nf != nil {
+		nf.pending.Wait()
+	}
+}
+
+// deliver attempts to deliver n to h until it succeeds, the attempts are
+// exhausted or nf.ctx is done.
+func (nf *Notifier) deliver(h Hook, n archive.UploadNotification) {
+	backoff := nf.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(nf.ctx, hookTimeout)
+		err := h.Notify(ctx, n)
+		cancel()
+		nf.record(Delivery{Hook: h.String(), URL: n.Url, Attempt: attempt, Time: time.Now()}, err)
+		if err == nil || attempt >= nf.retry.Attempts {
+			return
+		}
+		select {
+		case <-time.After(backoff):
+		case <-nf.ctx.Done():
+			return
+		}
+		backoff *= 2
+	}
+}
+
+func (nf *Notifier) record(d Delivery, err error) {
+	if err != nil {
+		d.Error = err.Error()
+		nf.ctx.Errorf("Failed to notify %v of %v (attempt %d of %d): %v", d.Hook, d.URL, d.Attempt, nf.retry.Attempts, err)
+	} else {
+		nf.ctx.Infof("Notified %v of %v", d.Hook, d.URL)
+	}
+	if nf.log == nil {
+		return
+	}
+	buf, err := json.Marshal(d)
+	if err != nil {
+		return
+	}
+	nf.mu.Lock()
+	defer nf.mu.Unlock()
+	if _, err := nf.log.Write(append(buf, '\n')); err != nil {
+		nf.ctx.Errorf("Failed to write the delivery log: %v", err)
+	}
+}
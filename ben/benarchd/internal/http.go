@@ -12,21 +12,45 @@ import (
 	"time"
 
 	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"v.io/v23/security/access"
 )
 
 // NewHTTPHandler returns a handler that provides web interface for browsing
-// benchmark results in store.
-func NewHTTPHandler(assets *Assets, store Store) http.Handler {
-	return &handler{assets, store}
+// benchmark results in store. Runs that regressed, as configured by policy,
+// are flagged. If auth is not nil, only the results that it authorizes
+// the authenticated caller to read are provided.
+func NewHTTPHandler(assets *Assets, store Store, policy RegressionPolicy, auth *Authenticator) http.Handler {
+	return &handler{assets, store, policy, auth}
 }
 
 type handler struct {
 	assets *Assets
 	store  Store
+	policy RegressionPolicy
+	auth   *Authenticator
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if file := path.Base(r.URL.Path); strings.HasSuffix(file, ".css") || strings.HasSuffix(file, ".js") {
+	if h.auth != nil && !isAsset(r) {
+		store, status, err := h.authorize(r)
+		if err != nil && strings.HasPrefix(r.URL.Path, apiPrefix) {
+			apiError(w, r, status, err)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(status)
+			fmt.Fprintln(w, "ERROR:", err)
+			return
+		}
+		h = &handler{h.assets, store, h.policy, nil}
+	}
+	if strings.HasPrefix(r.URL.Path, apiPrefix) {
+		h.api(w, r)
+		return
+	}
+	if isAsset(r) {
+		file := path.Base(r.URL.Path)
 		data, err := h.assets.File(file)
 		if err != nil {
 			w.WriteHeader(http.StatusNotFound)
@@ -41,6 +65,16 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Write(data) //nolint:errcheck
 		return
 	}
+	if oldRev, newRev := strings.TrimSpace(r.FormValue("old")), strings.TrimSpace(r.FormValue("new")); len(oldRev) > 0 && len(newRev) > 0 {
+		qstr := strings.TrimSpace(r.FormValue("q"))
+		query, err := ParseQuery(qstr)
+		if err != nil {
+			h.badQuery(w, qstr, err)
+			return
+		}
+		h.compare(w, query, ParseRevision(oldRev), ParseRevision(newRev))
+		return
+	}
 	if id := strings.TrimSpace(r.FormValue("id")); len(id) > 0 {
 		bm, itr := h.store.Runs(id)
 		defer itr.Close()
@@ -50,12 +84,7 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if qstr := strings.TrimSpace(r.FormValue("q")); len(qstr) > 0 {
 		query, err := ParseQuery(qstr)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			args := struct {
-				Query string
-				Error error
-			}{qstr, err}
-			h.executeTemplate(w, tmplBadQuery, args)
+			h.badQuery(w, qstr, err)
 			return
 		}
 		h.handleQuery(w, query)
@@ -68,6 +97,39 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.executeTemplate(w, tmplHome, nil)
 }
 
+// isAsset returns whether r requests a CSS or JavaScript file of the web
+// interface, which are available without authentication.
+func isAsset(r *http.Request) bool {
+	file := path.Base(r.URL.Path)
+	return !strings.HasPrefix(r.URL.Path, apiPrefix) && (strings.HasSuffix(file, ".css") || strings.HasSuffix(file, ".js"))
+}
+
+// authorize authenticates r and returns h.store restricted to the results
+// that h.auth authorizes the caller to read, or the HTTP status and error to
+// respond with if r is not authorized.
+func (h *handler) authorize(r *http.Request) (Store, int, error) {
+	names, err := h.auth.Authenticate(r, nil)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+	if err := h.auth.policy.authorize(names, access.Read); err != nil {
+		if len(names) == 0 {
+			return nil, http.StatusUnauthorized, fmt.Errorf("authentication required, log in with a URL obtained from %v", archive.LoginPath)
+		}
+		return nil, http.StatusForbidden, err
+	}
+	return readableStore{h.store, h.auth.policy, names}, http.StatusOK, nil
+}
+
+func (h *handler) badQuery(w http.ResponseWriter, qstr string, err error) {
+	w.WriteHeader(http.StatusBadRequest)
+	args := struct {
+		Query string
+		Error error
+	}{qstr, err}
+	h.executeTemplate(w, tmplBadQuery, args)
+}
+
 func (h *handler) handleQuery(w http.ResponseWriter, query *Query) {
 	bmarks := h.store.Benchmarks(query)
 	defer bmarks.Close()
@@ -124,11 +186,17 @@ func (h *handler) benchmarks(w http.ResponseWriter, query *Query, first Benchmar
 }
 
 func (h *handler) runs(w http.ResponseWriter, bm Benchmark, itr RunIterator) {
+	// Each item is an upload, with the medians of the results of its
+	// samples.
 	type item struct {
 		Run          ben.Run
 		SourceCodeID string
 		UploadTime   time.Time
+		UploadID     string
+		Stats        Stats
+		Env          ben.Environment
 		Index        int
+		Regressions  []archive.Regression
 	}
 	var (
 		cancel = make(chan struct{})
@@ -139,17 +207,37 @@ func (h *handler) runs(w http.ResponseWriter, bm Benchmark, itr RunIterator) {
 	go func() {
 		defer close(errs)
 		defer close(items)
-		idx := 0
-		for itr.Advance() {
-			idx++
-			r, s, t := itr.Value()
+		// Uploads are ordered from the most recent, so an item can be
+		// sent once the uploads it is compared to for regressions (the
+		// next h.policy.History ones) have been read.
+		var pending []item
+		send := func() bool {
+			it := pending[0]
+			pending = pending[1:]
+			history := make([]ben.Run, len(pending))
+			for i, p := range pending {
+				history[i] = p.Run
+			}
+			it.Regressions = h.policy.Check(it.Run, history)
 			select {
-			case items <- item{r, s, t, idx}:
+			case items <- it:
+				return true
 			case <-cancel:
+				return false
+			}
+		}
+		idx := 0
+		err := bySamples(itr, func(s Samples) bool {
+			idx++
+			pending = append(pending, item{Run: s.Run(), SourceCodeID: s.SourceCodeID, UploadTime: s.UploadTime, UploadID: s.UploadID, Stats: s.Stats, Env: s.Env, Index: idx})
+			return len(pending) <= h.policy.History || send()
+		})
+		for len(pending) > 0 {
+			if !send() {
 				return
 			}
 		}
-		if err := itr.Err(); err != nil {
+		if err != nil {
 			errs <- err
 		}
 	}()
@@ -165,14 +253,55 @@ func (h *handler) runs(w http.ResponseWriter, bm Benchmark, itr RunIterator) {
 	h.executeTemplate(w, tmplRuns, args)
 }
 
+func (h *handler) compare(w http.ResponseWriter, query *Query, oldRev, newRev Revision) {
+	comparisons, err := Compare(h.store, query, oldRev, newRev)
+	// Only present the metrics that were measured for some benchmark.
+	type metric struct {
+		Index int
+		Name  string
+	}
+	var metrics []metric
+	for i, name := range MetricNames() {
+		for _, c := range comparisons {
+			if c.Deltas[i].Measured {
+				metrics = append(metrics, metric{i, name})
+				break
+			}
+		}
+	}
+	args := struct {
+		Query       *Query
+		Old, New    Revision
+		Metrics     []metric
+		Comparisons []Comparison
+		Err         error
+	}{
+		Query:       query,
+		Old:         oldRev,
+		New:         newRev,
+		Metrics:     metrics,
+		Comparisons: comparisons,
+		Err:         err,
+	}
+	h.executeTemplate(w, tmplCompare, args)
+}
+
 func (h *handler) describeSource(w http.ResponseWriter, src string) {
-	w.Header().Set("Content-Type", "text/plain")
 	code, err := h.store.DescribeSource(src)
 	if err != nil {
+		w.Header().Set("Content-Type", "text/plain")
 		fmt.Fprintf(w, "ERROR:%v", err)
 		return
 	}
-	fmt.Fprintln(w, code)
+	args := struct {
+		ID     string
+		Code   ben.SourceCode
+		Source *ben.Source // Nil if Code is an opaque description.
+	}{ID: src, Code: code}
+	if s, ok := code.Source(); ok {
+		args.Source = &s
+	}
+	h.executeTemplate(w, tmplSource, args)
 }
 
 func (h *handler) executeTemplate(w http.ResponseWriter, tmpl string, args interface{}) {
@@ -0,0 +1,210 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vanadium/services/ben"
+)
+
+// matches returns true iff bm matches e, with the same semantics as the
+// condition that sqlCompiler translates e into. It must be called with s.mu
+// held.
+func (s *memStore) matches(bm *memBenchmark, e Expr) bool {
+	switch e := e.(type) {
+	case And:
+		for _, x := range e {
+			if !s.matches(bm, x) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, x := range e {
+			if s.matches(bm, x) {
+				return true
+			}
+		}
+		return false
+	case Not:
+		return !s.matches(bm, e.X)
+	case Term:
+		return s.term(bm, e)
+	case TimeRange:
+		for _, r := range bm.runs {
+			t := s.uploads[r.upload].time
+			if (e.Since.IsZero() || !t.Before(e.Since)) && (e.Until.IsZero() || !t.After(e.Until)) {
+				return true
+			}
+		}
+		return false
+	case Predicate:
+		return s.predicate(bm, e)
+	}
+	return false
+}
+
+func (s *memStore) term(bm *memBenchmark, t Term) bool {
+	// Like the LIKE operator, substrings and globs are case-insensitive.
+	contains := func(s, substr string) bool {
+		return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+	}
+	// Whether the environment of the upload of any run matches.
+	anyEnv := func(match func(env ben.Environment) bool) bool {
+		for _, r := range bm.runs {
+			if match(s.uploads[r.upload].env) {
+				return true
+			}
+		}
+		return false
+	}
+	switch t.Field {
+	case "name":
+		switch t.Pattern {
+		case Glob:
+			return globToRegexp(t.Value).MatchString(bm.Name)
+		case Regexp:
+			re, err := regexp.Compile(t.Value)
+			return err == nil && re.MatchString(bm.Name)
+		}
+		return contains(bm.Name, t.Value)
+	case "cpu":
+		cpu := bm.Scenario.Cpu
+		mhz, err := strconv.Atoi(t.Value)
+		return contains(cpu.Architecture, t.Value) || contains(cpu.Description, t.Value) || (err == nil && int64(mhz) == int64(cpu.ClockSpeedMhz))
+	case "os":
+		return contains(bm.Scenario.Os.Name, t.Value) || contains(bm.Scenario.Os.Version, t.Value)
+	case "uploader":
+		return contains(bm.Uploader, t.Value)
+	case "label":
+		return contains(bm.Scenario.Label, t.Value)
+	case "source":
+		for _, r := range bm.runs {
+			if code := s.uploads[r.upload].code; strings.HasPrefix(strings.ToLower(code), strings.ToLower(t.Value)) {
+				return true
+			}
+		}
+		return false
+	case "commit":
+		prefix := strings.ToLower(t.Value)
+		for _, r := range bm.runs {
+			for _, commit := range s.sources[s.uploads[r.upload].code].Commits() {
+				if strings.HasPrefix(strings.ToLower(commit), prefix) {
+					return true
+				}
+			}
+		}
+		return false
+	case "metric":
+		for _, metrics := range bm.metrics {
+			if _, ok := metrics[t.Value]; ok {
+				return true
+			}
+		}
+		return false
+	case "go":
+		return anyEnv(func(env ben.Environment) bool { return contains(env.GoVersion, t.Value) })
+	case "gomaxprocs", "cores":
+		n, err := strconv.Atoi(t.Value)
+		if err != nil {
+			return false
+		}
+		return anyEnv(func(env ben.Environment) bool {
+			if t.Field == "cores" {
+				return int64(env.Cores) == int64(n)
+			}
+			return int64(env.GoMaxProcs) == int64(n)
+		})
+	case "kernel":
+		return anyEnv(func(env ben.Environment) bool { return contains(env.Kernel, t.Value) })
+	case "governor":
+		return anyEnv(func(env ben.Environment) bool { return contains(env.CpuGovernor, t.Value) })
+	case "tag":
+		name, value, hasValue := t.tag()
+		return anyEnv(func(env ben.Environment) bool {
+			for k, v := range env.Tags {
+				if strings.EqualFold(k, name) && (!hasValue || strings.EqualFold(v, value)) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return false
+}
+
+func (s *memStore) predicate(bm *memBenchmark, p Predicate) bool {
+	var (
+		value float64
+		ok    bool
+	)
+	switch {
+	case p.Metric == "ns":
+		value, ok = bm.NanoSecsPerOp, true
+	case p.Metric == "mbps":
+		value, ok = bm.MegaBytesPerSec, true
+	default:
+		// The values of the most recently uploaded run.
+		for _, u := range s.byUploadTime(bm.uploadIDs()) {
+			if p.Custom {
+				if value, ok = bm.metrics[u][p.Metric]; ok {
+					break
+				}
+				continue
+			}
+			for _, r := range bm.runs {
+				if r.upload != u {
+					continue
+				}
+				value, ok = float64(r.run.AllocsPerOp), true
+				if p.Metric == "bytes" {
+					value = float64(r.run.AllocedBytesPerOp)
+				}
+				break
+			}
+			break
+		}
+	}
+	if !ok {
+		return false
+	}
+	switch p.Op {
+	case "<":
+		return value < p.Value
+	case "<=":
+		return value <= p.Value
+	case ">":
+		return value > p.Value
+	case ">=":
+		return value >= p.Value
+	case "=":
+		return value == p.Value
+	case "!=":
+		return value != p.Value
+	}
+	return false
+}
+
+// globToRegexp converts a glob into a case-insensitive regular expression
+// matching the same names as the pattern returned by globToLike.
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
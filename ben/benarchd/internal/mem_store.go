@@ -0,0 +1,488 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"v.io/v23/context"
+)
+
+// NewMemStore returns a Store implementation that keeps all results in memory,
+// which is useful for tests and local development. See NewFileStore for a
+// variant that persists them.
+func NewMemStore() Store {
+	return newMemStore()
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		benchmarks: make(map[int64]*memBenchmark),
+		keys:       make(map[memBenchmarkKey]int64),
+		uploads:    make(map[int64]*memUpload),
+		sources:    make(map[string]ben.SourceCode),
+	}
+}
+
+type memStore struct {
+	mu         sync.Mutex
+	benchmarks map[int64]*memBenchmark
+	keys       map[memBenchmarkKey]int64 // IDs of benchmarks, by key.
+	uploads    map[int64]*memUpload
+	sources    map[string]ben.SourceCode
+	// IDs of the last created benchmark and upload. Like the
+	// AUTO_INCREMENT columns of sqlStore, IDs are never reused.
+	lastBenchmark, lastUpload int64
+}
+
+// memBenchmarkKey identifies a benchmark by its name, scenario (without the
+// Env, which does not identify benchmarks) and uploader, with all but the name
+// lowercased as in sqlStore.
+type memBenchmarkKey struct {
+	cpu      ben.Cpu
+	os       ben.Os
+	label    string
+	uploader string
+	name     string
+}
+
+type memBenchmark struct {
+	Benchmark // Without the ID.
+	// Runs in the order they were saved, which is also the order of their
+	// uploads.
+	runs []memRun
+	// Stats and medians of the custom metrics of the samples in each
+	// upload, by upload ID.
+	stats   map[int64]Stats
+	metrics map[int64]map[string]float64
+}
+
+type memRun struct {
+	run    ben.Run // Without the Metrics.
+	upload int64
+}
+
+type memUpload struct {
+	time time.Time
+	code string
+	env  ben.Environment
+}
+
+func newMemBenchmarkKey(scenario ben.Scenario, uploader, name string) memBenchmarkKey {
+	cpu, os := scenario.Cpu, scenario.Os
+	cpu.Architecture = strings.ToLower(cpu.Architecture)
+	cpu.Description = strings.ToLower(cpu.Description)
+	os.Name = strings.ToLower(os.Name)
+	os.Version = strings.ToLower(os.Version)
+	return memBenchmarkKey{cpu, os, strings.ToLower(scenario.Label), strings.ToLower(uploader), name}
+}
+
+func (k memBenchmarkKey) scenario() ben.Scenario {
+	return ben.Scenario{Cpu: k.cpu, Os: k.os, Label: k.label}
+}
+
+func (s *memStore) Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCode, uploader string, uploadTime time.Time, runs []ben.Run) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	if err := checkRuns(runs); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	codeID := code.ID()
+	if _, ok := s.sources[codeID]; !ok {
+		s.sources[codeID] = code
+	}
+	s.lastUpload++
+	upload := s.lastUpload
+	env := scenario.Env
+	if env.Tags != nil {
+		env.Tags = make(map[string]string, len(scenario.Env.Tags))
+		for k, v := range scenario.Env.Tags {
+			env.Tags[k] = v
+		}
+	}
+	s.uploads[upload] = &memUpload{uploadTime, codeID, env}
+	// Repeated runs of a benchmark are samples of it, as in sqlStore.
+	var names []string
+	samples := make(map[string][]ben.Run)
+	for _, run := range runs {
+		if _, ok := samples[run.Name]; !ok {
+			names = append(names, run.Name)
+		}
+		samples[run.Name] = append(samples[run.Name], run)
+	}
+	for _, name := range names {
+		key := newMemBenchmarkKey(scenario, uploader, name)
+		id, ok := s.keys[key]
+		if !ok {
+			s.lastBenchmark++
+			id = s.lastBenchmark
+			s.keys[key] = id
+			s.benchmarks[id] = &memBenchmark{
+				Benchmark: Benchmark{Name: name, Scenario: key.scenario(), Uploader: key.uploader},
+				stats:     make(map[int64]Stats),
+				metrics:   make(map[int64]map[string]float64),
+			}
+		}
+		bm := s.benchmarks[id]
+		var (
+			ns, mbps []float64
+			metrics  = make(map[string][]float64)
+		)
+		for _, run := range samples[name] {
+			r := run
+			r.Metrics = nil
+			bm.runs = append(bm.runs, memRun{r, upload})
+			ns = append(ns, run.NanoSecsPerOp)
+			mbps = append(mbps, run.MegaBytesPerSec)
+			for unit, value := range run.Metrics {
+				metrics[unit] = append(metrics[unit], value)
+			}
+		}
+		bm.stats[upload] = newStats(ns)
+		bm.NanoSecsPerOp, bm.MegaBytesPerSec, bm.LastUpdate = median(ns), median(mbps), uploadTime
+		if len(metrics) > 0 {
+			bm.metrics[upload] = make(map[string]float64)
+			for unit, values := range metrics {
+				bm.metrics[upload][unit] = median(values)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *memStore) Benchmarks(query *Query) BenchmarkIterator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	filters := append([]Expr{
+		Term{Field: "name", Value: query.Name},
+		Term{Field: "cpu", Value: query.CPU},
+		Term{Field: "os", Value: query.OS},
+		Term{Field: "uploader", Value: query.Uploader},
+		Term{Field: "label", Value: query.Label},
+	}, query.Filters...)
+	var ids []int64
+	for id, bm := range s.benchmarks {
+		if s.matches(bm, And(filters)) {
+			ids = append(ids, id)
+		}
+	}
+	// Most recently updated first, as in sqlStore.
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := s.benchmarks[ids[i]], s.benchmarks[ids[j]]
+		if !a.LastUpdate.Equal(b.LastUpdate) {
+			return a.LastUpdate.After(b.LastUpdate)
+		}
+		return ids[i] > ids[j]
+	})
+	itr := &memBmItr{store: s, ids: ids}
+	for _, id := range ids {
+		itr.items = append(itr.items, s.benchmark(id))
+	}
+	return itr
+}
+
+func (s *memStore) Runs(id string) (Benchmark, RunIterator) {
+	key, err := strconv.ParseInt(id, 16, 64)
+	if err != nil {
+		return Benchmark{}, &nullRunsItr{nullItr{ErrNotFound}}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.benchmarks[key]; !ok {
+		return Benchmark{}, &nullRunsItr{nullItr{ErrNotFound}}
+	}
+	return s.benchmark(key), s.runsOf(key)
+}
+
+func (s *memStore) Lookup(scenario ben.Scenario, uploader, name string) (Benchmark, RunIterator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.keys[newMemBenchmarkKey(scenario, uploader, name)]
+	if !ok {
+		return Benchmark{}, &nullRunsItr{}
+	}
+	return s.benchmark(id), s.runsOf(id)
+}
+
+func (s *memStore) DescribeSource(id string) (ben.SourceCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code, ok := s.sources[id]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return code, nil
+}
+
+func (s *memStore) Delete(selector archive.UploadSelector) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload := int64(-1)
+	if len(selector.Upload) > 0 {
+		var err error
+		if upload, err = strconv.ParseInt(selector.Upload, 16, 64); err != nil {
+			return 0, fmt.Errorf("invalid upload ID %q", selector.Upload)
+		}
+	}
+	if upload < 0 && len(selector.Uploader) == 0 && len(selector.Label) == 0 {
+		return 0, fmt.Errorf("no uploads selected, at least one of Upload, Uploader or Label must be set")
+	}
+	var keys []runKey
+	uploads := make(map[int64]bool)
+	for _, id := range s.sortedIDs() {
+		bm := s.benchmarks[id]
+		if len(selector.Uploader) > 0 && bm.Uploader != strings.ToLower(selector.Uploader) {
+			continue
+		}
+		if len(selector.Label) > 0 && bm.Scenario.Label != strings.ToLower(selector.Label) {
+			continue
+		}
+		for _, u := range bm.uploadIDs() {
+			if upload < 0 || u == upload {
+				keys = append(keys, runKey{id, u})
+				uploads[u] = true
+			}
+		}
+	}
+	s.removeRuns(keys)
+	return len(uploads), nil
+}
+
+func (s *memStore) Downsample(before time.Time, interval time.Duration) (int, error) {
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid downsampling interval %v", interval)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var remove []runKey
+	for _, id := range s.sortedIDs() {
+		bm := s.benchmarks[id]
+		// The runs of a benchmark in an upload are kept or removed
+		// together, so each upload is represented by the mean of its
+		// runs, as in sqlStore.
+		buckets := make(map[int64][]runKey)
+		var starts []int64
+		means := make(map[int64]float64)
+		for _, u := range bm.uploadIDs() {
+			t := s.uploads[u].time
+			if !t.Before(before) {
+				continue
+			}
+			var ns []float64
+			for _, r := range bm.runs {
+				if r.upload == u {
+					ns = append(ns, r.run.NanoSecsPerOp)
+				}
+			}
+			means[u] = mean(ns)
+			start := t.UTC().Truncate(interval).UnixNano()
+			if _, ok := buckets[start]; !ok {
+				starts = append(starts, start)
+			}
+			buckets[start] = append(buckets[start], runKey{id, u})
+		}
+		for _, start := range starts {
+			keys := buckets[start]
+			if len(keys) < 2 {
+				continue
+			}
+			sort.SliceStable(keys, func(i, j int) bool { return means[keys[i].upload] < means[keys[j].upload] })
+			median := (len(keys) - 1) / 2
+			remove = append(remove, keys[:median]...)
+			remove = append(remove, keys[median+1:]...)
+		}
+	}
+	return s.removeRuns(remove), nil
+}
+
+// removeRuns is the equivalent of sqlStore.removeRuns. It must be called with
+// s.mu held.
+func (s *memStore) removeRuns(keys []runKey) int {
+	var removed int
+	for _, k := range keys {
+		bm := s.benchmarks[k.benchmark]
+		runs := bm.runs[:0]
+		for _, r := range bm.runs {
+			if r.upload == k.upload {
+				removed++
+				continue
+			}
+			runs = append(runs, r)
+		}
+		bm.runs = runs
+		delete(bm.stats, k.upload)
+		delete(bm.metrics, k.upload)
+	}
+	for _, k := range keys {
+		bm, ok := s.benchmarks[k.benchmark]
+		if !ok {
+			continue
+		}
+		if len(bm.runs) == 0 {
+			delete(s.benchmarks, k.benchmark)
+			delete(s.keys, newMemBenchmarkKey(bm.Scenario, bm.Uploader, bm.Name))
+			continue
+		}
+		latest := s.byUploadTime(bm.uploadIDs())[0]
+		stats := bm.stats[latest]
+		var mbps []float64
+		for _, r := range bm.runs {
+			if r.upload == latest {
+				mbps = append(mbps, r.run.MegaBytesPerSec)
+			}
+		}
+		bm.NanoSecsPerOp, bm.MegaBytesPerSec, bm.LastUpdate = stats.Median, median(mbps), s.uploads[latest].time
+	}
+	used := make(map[int64]bool)
+	for _, bm := range s.benchmarks {
+		for _, r := range bm.runs {
+			used[r.upload] = true
+		}
+	}
+	sources := make(map[string]bool)
+	for id, u := range s.uploads {
+		if !used[id] {
+			delete(s.uploads, id)
+			continue
+		}
+		sources[u.code] = true
+	}
+	for id := range s.sources {
+		if !sources[id] {
+			delete(s.sources, id)
+		}
+	}
+	return removed
+}
+
+// sortedIDs returns the IDs of all benchmarks in increasing order. It must be
+// called with s.mu held.
+func (s *memStore) sortedIDs() []int64 {
+	ids := make([]int64, 0, len(s.benchmarks))
+	for id := range s.benchmarks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// uploadIDs returns the IDs of the uploads with runs of bm, most recent first.
+func (bm *memBenchmark) uploadIDs() []int64 {
+	var ids []int64
+	for i := len(bm.runs) - 1; i >= 0; i-- {
+		if u := bm.runs[i].upload; len(ids) == 0 || ids[len(ids)-1] != u {
+			ids = append(ids, u)
+		}
+	}
+	return ids
+}
+
+// benchmark returns the Benchmark with the given id. It must be called with
+// s.mu held.
+func (s *memStore) benchmark(id int64) Benchmark {
+	ret := s.benchmarks[id].Benchmark
+	ret.ID = fmt.Sprintf("%x", id)
+	return ret
+}
+
+// runsOf returns an iterator over a copy of the runs of the benchmark with the
+// given id, most recently uploaded first. It must be called with s.mu held.
+func (s *memStore) runsOf(id int64) RunIterator {
+	bm := s.benchmarks[id]
+	itr := &memRunItr{}
+	for _, u := range s.byUploadTime(bm.uploadIDs()) {
+		upload := s.uploads[u]
+		for _, r := range bm.runs {
+			if r.upload != u {
+				continue
+			}
+			run := r.run
+			run.Metrics = bm.metrics[u]
+			itr.items = append(itr.items, memRunItem{run, upload.code, upload.time, u, bm.stats[u], upload.env})
+		}
+	}
+	return itr
+}
+
+// byUploadTime sorts uploads by decreasing upload time and ID, as in
+// sqlStore. It must be called with s.mu held.
+func (s *memStore) byUploadTime(uploads []int64) []int64 {
+	sort.SliceStable(uploads, func(i, j int) bool {
+		a, b := s.uploads[uploads[i]].time, s.uploads[uploads[j]].time
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return uploads[i] > uploads[j]
+	})
+	return uploads
+}
+
+type memBmItr struct {
+	store *memStore
+	items []Benchmark
+	ids   []int64
+	pos   int
+}
+
+func (i *memBmItr) Advance() bool {
+	if i.pos >= len(i.items) {
+		return false
+	}
+	i.pos++
+	return true
+}
+func (*memBmItr) Err() error         { return nil }
+func (*memBmItr) Close()             {}
+func (i *memBmItr) Value() Benchmark { return i.items[i.pos-1] }
+func (i *memBmItr) Runs() RunIterator {
+	i.store.mu.Lock()
+	defer i.store.mu.Unlock()
+	if _, ok := i.store.benchmarks[i.ids[i.pos-1]]; !ok {
+		// Deleted since the iterator was created.
+		return &nullRunsItr{}
+	}
+	return i.store.runsOf(i.ids[i.pos-1])
+}
+
+type memRunItem struct {
+	run    ben.Run
+	code   string
+	time   time.Time
+	upload int64
+	stats  Stats
+	env    ben.Environment
+}
+
+type memRunItr struct {
+	items []memRunItem
+	pos   int
+}
+
+func (i *memRunItr) Advance() bool {
+	if i.pos >= len(i.items) {
+		return false
+	}
+	i.pos++
+	return true
+}
+func (*memRunItr) Err() error { return nil }
+func (*memRunItr) Close()     {}
+func (i *memRunItr) Value() (ben.Run, string, time.Time) {
+	item := i.items[i.pos-1]
+	return item.run, item.code, item.time
+}
+func (i *memRunItr) UploadID() string             { return fmt.Sprintf("%x", i.items[i.pos-1].upload) }
+func (i *memRunItr) Stats() Stats                 { return i.items[i.pos-1].stats }
+func (i *memRunItr) Environment() ben.Environment { return i.items[i.pos-1].env }
@@ -0,0 +1,294 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"v.io/v23/security"
+	"v.io/v23/security/access"
+)
+
+// Policy configures who is authorized to read archived results
+// (access.Read), upload results (access.Write) and delete them
+// (access.Admin), by the blessing names of the caller. The zero Policy (and a
+// nil *Policy) authorizes anyone to read results, anyone with a recognizable
+// blessing name to upload them and nobody to delete them.
+type Policy struct {
+	// Permissions authorizes access to all results. Read and Write access
+	// is not restricted beyond the above if Permissions has no AccessList
+	// for the tag, Admin access is.
+	Permissions access.Permissions
+	// Rules additionally restrict access to the results of some labels
+	// or uploaders. All rules that apply to results must authorize an
+	// access to them.
+	Rules []Rule
+}
+
+// Rule restricts access to the results with a label and uploaded by
+// uploaders matched by a blessing pattern.
+type Rule struct {
+	Label       string                   // If not empty, the rule only applies to results with this label.
+	Uploader    security.BlessingPattern // If not empty, the rule only applies to results uploaded with blessing names matched by this pattern.
+	Permissions access.Permissions       // Permissions required to access the results, by tag.
+}
+
+// ReadPolicy reads a JSON-encoded Policy from a file. For example:
+//
+//	{
+//	  "Permissions": {
+//	    "Read":  {"In": ["dev.v.io:u"]},
+//	    "Write": {"In": ["dev.v.io:u:ci"]},
+//	    "Admin": {"In": ["dev.v.io:u:jane@example.com"]}
+//	  },
+//	  "Rules": [
+//	    {"Label": "nightly", "Permissions": {"Write": {"In": ["dev.v.io:u:ci:nightly"]}}},
+//	    {"Uploader": "dev.v.io:u:secret", "Permissions": {"Read": {"In": ["dev.v.io:u:secret"]}}}
+//	  ]
+//	}
+//
+// authorizes users blessed by dev.v.io to read results, except those uploaded
+// by dev.v.io:u:secret, only dev.v.io:u:ci to upload them, only
+// dev.v.io:u:ci:nightly with the "nightly" label, and
+// dev.v.io:u:jane@example.com to delete them.
+func ReadPolicy(filename string) (*Policy, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var p Policy
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %v", filename, err)
+	}
+	if err := checkTags(p.Permissions); err != nil {
+		return nil, fmt.Errorf("%v: %v", filename, err)
+	}
+	for i, r := range p.Rules {
+		if err := checkTags(r.Permissions); err != nil {
+			return nil, fmt.Errorf("%v: rule %d: %v", filename, i, err)
+		}
+	}
+	return &p, nil
+}
+
+func checkTags(perms access.Permissions) error {
+	for tag := range perms {
+		switch access.Tag(tag) {
+		case access.Read, access.Write, access.Admin:
+		default:
+			return fmt.Errorf("invalid tag %q, must be one of %v, %v or %v", tag, access.Read, access.Write, access.Admin)
+		}
+	}
+	return nil
+}
+
+// AddAdmin authorizes callers with blessing names matched by pattern to
+// delete any results.
+func (p *Policy) AddAdmin(pattern security.BlessingPattern) {
+	if p.Permissions == nil {
+		p.Permissions = access.Permissions{}
+	}
+	p.Permissions.Add(pattern, string(access.Admin))
+	for _, r := range p.Rules {
+		if _, ok := r.Permissions[string(access.Admin)]; ok {
+			r.Permissions.Add(pattern, string(access.Admin))
+		}
+	}
+}
+
+// authorize returns an error unless p authorizes a caller with blessing names
+// to access results with tag, without considering p.Rules.
+func (p *Policy) authorize(names []string, tag access.Tag) error {
+	var perms access.Permissions
+	if p != nil {
+		perms = p.Permissions
+	}
+	acl, ok := perms[string(tag)]
+	switch {
+	case ok && acl.Includes(names...):
+		return nil
+	case ok, tag == access.Admin:
+		return fmt.Errorf("%v not authorized for %v access", names, tag)
+	case tag == access.Write && len(names) == 0:
+		return fmt.Errorf("no recognizable blessing names")
+	}
+	return nil
+}
+
+// authorizeResults returns an error unless p authorizes a caller with blessing
+// names to access results with tag, a label and uploaded by uploader.
+func (p *Policy) authorizeResults(names []string, tag access.Tag, label, uploader string) error {
+	if err := p.authorize(names, tag); err != nil {
+		return err
+	}
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Rules {
+		if r.applies(label, uploader) {
+			if err := r.authorize(names, tag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// authorizeDelete returns an error unless p authorizes a caller with blessing
+// names to delete the uploads matched by selector. Since the labels and
+// uploaders of the matched uploads are unknown, all rules that may apply to
+// them must authorize the deletion.
+func (p *Policy) authorizeDelete(names []string, selector archive.UploadSelector) error {
+	if err := p.authorize(names, access.Admin); err != nil {
+		return err
+	}
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Rules {
+		if len(selector.Label) > 0 && len(r.Label) > 0 && !strings.EqualFold(selector.Label, r.Label) {
+			continue
+		}
+		if len(selector.Uploader) > 0 && len(r.Uploader) > 0 && !r.matchesUploader(selector.Uploader) {
+			continue
+		}
+		if err := r.authorize(names, access.Admin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readable returns whether p authorizes a caller with blessing names to read
+// the results of bm.
+func (p *Policy) readable(names []string, bm Benchmark) bool {
+	return p.authorizeResults(names, access.Read, bm.Scenario.Label, bm.Uploader) == nil
+}
+
+// readableAll returns whether p authorizes a caller with blessing names to read
+// all results, whatever their label and uploader.
+func (p *Policy) readableAll(names []string) bool {
+	if err := p.authorize(names, access.Read); err != nil {
+		return false
+	}
+	if p == nil {
+		return true
+	}
+	for _, r := range p.Rules {
+		if err := r.authorize(names, access.Read); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) applies(label, uploader string) bool {
+	if len(r.Label) > 0 && !strings.EqualFold(r.Label, label) {
+		return false
+	}
+	return len(r.Uploader) == 0 || r.matchesUploader(uploader)
+}
+
+// matchesUploader returns whether r.Uploader matches uploader, which is
+// formatted as the uploaders of results are stored (see server.archive),
+// ignoring case since Stores may not preserve it.
+func (r Rule) matchesUploader(uploader string) bool {
+	pattern := security.BlessingPattern(strings.ToLower(string(r.Uploader)))
+	return pattern.MatchedBy(strings.Split(strings.ToLower(uploader), string(security.NoExtension))...)
+}
+
+func (r Rule) authorize(names []string, tag access.Tag) error {
+	if acl, ok := r.Permissions[string(tag)]; ok && !acl.Includes(names...) {
+		return fmt.Errorf("%v not authorized for %v access to results with label %q uploaded by %q", names, tag, r.Label, r.Uploader)
+	}
+	return nil
+}
+
+// readableStore is a Store that only provides the results that policy
+// authorizes a caller with blessing names to read.
+type readableStore struct {
+	Store
+	policy *Policy
+	names  []string
+}
+
+func (s readableStore) Benchmarks(query *Query) BenchmarkIterator {
+	return &readableBmItr{s.Store.Benchmarks(query), s}
+}
+
+func (s readableStore) Runs(id string) (Benchmark, RunIterator) {
+	return s.filter(s.Store.Runs(id))
+}
+
+func (s readableStore) Lookup(scenario ben.Scenario, uploader, name string) (Benchmark, RunIterator) {
+	return s.filter(s.Store.Lookup(scenario, uploader, name))
+}
+
+// DescribeSource returns the source code description with the given ID if
+// the caller may read all results or, since descriptions are not associated
+// with labels or uploaders, if it may read results uploaded with it.
+func (s readableStore) DescribeSource(id string) (ben.SourceCode, error) {
+	code, err := s.Store.DescribeSource(id)
+	if err != nil || s.policy.readableAll(s.names) {
+		return code, err
+	}
+	// Collect the benchmarks before reading their runs, since stores may
+	// not support concurrent iterators.
+	var ids []string
+	bmItr := s.Benchmarks(&Query{Filters: []Expr{Term{Field: "source", Value: id}}})
+	for bmItr.Advance() {
+		ids = append(ids, bmItr.Value().ID)
+	}
+	err = bmItr.Err()
+	bmItr.Close()
+	if err != nil {
+		return "", err
+	}
+	for _, bmID := range ids {
+		_, itr := s.Store.Runs(bmID)
+		for itr.Advance() {
+			if _, codeID, _ := itr.Value(); codeID == id {
+				itr.Close()
+				return code, nil
+			}
+		}
+		err := itr.Err()
+		itr.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", ErrNotFound
+}
+
+func (s readableStore) filter(bm Benchmark, itr RunIterator) (Benchmark, RunIterator) {
+	if len(bm.ID) == 0 || s.policy.readable(s.names, bm) {
+		return bm, itr
+	}
+	itr.Close()
+	return Benchmark{}, &nullRunsItr{nullItr{ErrNotFound}}
+}
+
+type readableBmItr struct {
+	BenchmarkIterator
+	store readableStore
+}
+
+func (i *readableBmItr) Advance() bool {
+	for i.BenchmarkIterator.Advance() {
+		if i.store.policy.readable(i.store.names, i.Value()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vanadium/services/ben/archive"
+	"v.io/v23/security"
+	"v.io/v23/security/access"
+)
+
+const testPolicy = `{
+  "Permissions": {
+    "Read":  {"In": ["root"]},
+    "Write": {"In": ["root:ci", "root:alice"]},
+    "Admin": {"In": ["root:admin"]}
+  },
+  "Rules": [
+    {"Label": "nightly", "Permissions": {"Write": {"In": ["root:ci:nightly"]}}},
+    {"Uploader": "root:secret", "Permissions": {"Read": {"In": ["root:secret"]}, "Admin": {"In": ["root:admin:secret"]}}}
+  ]
+}`
+
+func writePolicy(t *testing.T, contents string) string {
+	dir, err := ioutil.TempDir("", "benarchd-policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	filename := filepath.Join(dir, "policy.json")
+	if err := ioutil.WriteFile(filename, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestPolicy(t *testing.T) {
+	policy, err := ReadPolicy(writePolicy(t, testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy.AddAdmin("root:superuser")
+	for _, test := range []struct {
+		names           []string
+		tag             access.Tag
+		label, uploader string
+		ok              bool
+	}{
+		{[]string{"root:bob"}, access.Read, "", "root:ci", true},
+		{nil, access.Read, "", "root:ci", false},
+		{[]string{"other:bob"}, access.Read, "", "root:ci", false},
+		{[]string{"root:bob"}, access.Read, "", "root:secret:laptop", false},
+		{[]string{"root:secret"}, access.Read, "", "ROOT:SECRET:laptop", true},
+		{[]string{"root:bob"}, access.Read, "", "root:ci" + string(security.NoExtension) + "root:secret", false},
+		{[]string{"root:ci"}, access.Write, "", "root:ci", true},
+		{[]string{"root:bob"}, access.Write, "", "root:bob", false},
+		{[]string{"root:ci"}, access.Write, "Nightly", "root:ci", false},
+		{[]string{"root:ci:nightly"}, access.Write, "nightly", "root:ci:nightly", true},
+		{[]string{"root:admin"}, access.Admin, "", "root:ci", true},
+		{[]string{"root:superuser"}, access.Admin, "", "root:ci", true},
+		{[]string{"root:ci"}, access.Admin, "", "root:ci", false},
+	} {
+		err := policy.authorizeResults(test.names, test.tag, test.label, test.uploader)
+		if (err == nil) != test.ok {
+			t.Errorf("%v %v access to results with label %q uploaded by %q: got %v, want authorized=%v", test.names, test.tag, test.label, test.uploader, err, test.ok)
+		}
+	}
+	for _, test := range []struct {
+		names    []string
+		selector archive.UploadSelector
+		ok       bool
+	}{
+		{[]string{"root:admin"}, archive.UploadSelector{Uploader: "root:ci"}, true},
+		// The selected uploads may include those of root:secret.
+		{[]string{"root:admin"}, archive.UploadSelector{Upload: "1"}, false},
+		{[]string{"root:admin"}, archive.UploadSelector{Uploader: "root:secret:laptop"}, false},
+		{[]string{"root:admin:secret"}, archive.UploadSelector{Uploader: "root:secret:laptop"}, true},
+		{[]string{"root:superuser"}, archive.UploadSelector{Label: "nightly"}, true},
+		{[]string{"root:ci"}, archive.UploadSelector{Uploader: "root:ci"}, false},
+	} {
+		err := policy.authorizeDelete(test.names, test.selector)
+		if (err == nil) != test.ok {
+			t.Errorf("%v deleting %+v: got %v, want authorized=%v", test.names, test.selector, err, test.ok)
+		}
+	}
+
+	// The default policy.
+	var none *Policy
+	if err := none.authorizeResults(nil, access.Read, "", "root:ci"); err != nil {
+		t.Error(err)
+	}
+	if err := none.authorizeResults(nil, access.Write, "", ""); err == nil {
+		t.Error("unauthenticated upload unexpectedly authorized")
+	}
+	if err := none.authorizeDelete([]string{"root:admin"}, archive.UploadSelector{Label: "ci"}); err == nil {
+		t.Error("deletion unexpectedly authorized")
+	}
+}
+
+func TestReadPolicyErrors(t *testing.T) {
+	for _, contents := range []string{
+		`{"Permissions": {"Debug": {"In": ["root"]}}}`,
+		`{"Rules": [{"Permissions": {"Resolve": {"In": ["root"]}}}]}`,
+		`{"Permission": {}}`,
+		`{`,
+	} {
+		if _, err := ReadPolicy(writePolicy(t, contents)); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", contents)
+		}
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expr is a node in the abstract syntax tree of a query, which matches a set of
+// benchmarks. String returns the expression in the syntax accepted by
+// ParseQuery.
+type Expr interface {
+	String() string
+}
+
+// And matches the benchmarks matched by all of its operands.
+type And []Expr
+
+// Or matches the benchmarks matched by any of its operands.
+type Or []Expr
+
+// Not matches the benchmarks not matched by X.
+type Not struct{ X Expr }
+
+// PatternKind identifies how the Value of a Term is matched.
+type PatternKind int
+
+const (
+	// Substring matches values containing the pattern.
+	Substring PatternKind = iota
+	// Glob matches values matching the pattern in its entirety, where '*'
+	// matches any sequence of characters and '?' any single character.
+	Glob
+	// Regexp matches values containing a match of the regular expression.
+	Regexp
+)
+
+// Term matches the benchmarks whose Field matches Value. The Field is one of:
+//
+//	name     - Name of the benchmark.
+//	cpu      - Architecture, description or clock speed (in MHz) of the CPU.
+//	os       - Name or version of the operating system.
+//	uploader - Identity of the uploader.
+//	label    - Label assigned by the uploader.
+//	source   - Prefix of the ID of the source code of any run of the benchmark.
+//	commit   - Prefix of a commit (see ben.SourceCode.Commits) of the source
+//	           code of any run of the benchmark.
+//	metric   - Unit of a custom metric reported by any run of the benchmark.
+//
+// and the following fields of the environment (see ben.Scenario.Env) of any run
+// of the benchmark:
+//
+//	go         - Version of the Go toolchain.
+//	gomaxprocs - GOMAXPROCS.
+//	cores      - Number of CPU cores.
+//	kernel     - Version of the kernel.
+//	governor   - Governor of the CPU frequency.
+//	tag        - Name of a tag, or name=value of a tag.
+//
+// Pattern can only be Glob or Regexp for the name field.
+type Term struct {
+	Field   string
+	Value   string
+	Pattern PatternKind
+}
+
+// tag returns the name of the tag that a term of the tag field matches, and
+// its value if the term specifies it.
+func (t Term) tag() (name, value string, hasValue bool) {
+	if idx := strings.Index(t.Value, "="); idx >= 0 {
+		return t.Value[:idx], t.Value[idx+1:], true
+	}
+	return t.Value, "", false
+}
+
+// TimeRange matches the benchmarks with runs uploaded at or after Since (if
+// non-zero) and at or before Until (if non-zero).
+type TimeRange struct {
+	Since, Until time.Time
+	// Text is the original representation of the range in the query.
+	Text string
+}
+
+// Predicate matches the benchmarks for which the most recently uploaded value
+// of Metric compares to Value as specified by Op.
+type Predicate struct {
+	Metric string // One of "ns", "allocs", "bytes" or "mbps", or the unit of a custom metric.
+	Custom bool   // Whether Metric is a custom metric (see ben.Run.Metrics).
+	Op     string // One of "<", "<=", ">", ">=", "=" or "!=".
+	Value  float64
+	// Text is the original representation of Value in the query.
+	Text string
+}
+
+func (e And) String() string { return joinExprs(e, " ") }
+func (e Or) String() string  { return joinExprs(e, " OR ") }
+func (e Not) String() string { return "-" + parenthesize(e.X) }
+
+func (t Term) String() string {
+	value := t.Value
+	if t.Pattern == Regexp {
+		value = "/" + value + "/"
+	}
+	return t.Field + ":" + quote(value)
+}
+
+func (r TimeRange) String() string { return r.Text }
+
+func (p Predicate) String() string {
+	if p.Custom {
+		return "metric:" + quote(p.Metric) + p.Op + p.Text
+	}
+	return p.Metric + p.Op + p.Text
+}
+
+func joinExprs(exprs []Expr, sep string) string {
+	strs := make([]string, len(exprs))
+	for i, e := range exprs {
+		strs[i] = parenthesize(e)
+	}
+	return strings.Join(strs, sep)
+}
+
+func parenthesize(e Expr) string {
+	switch e := e.(type) {
+	case And:
+		if len(e) > 1 {
+			return "(" + e.String() + ")"
+		}
+	case Or:
+		if len(e) > 1 {
+			return "(" + e.String() + ")"
+		}
+	}
+	return e.String()
+}
+
+// quote returns in, quoted if necessary for it to be parsed as a single term.
+func quote(in string) string {
+	switch in {
+	case "OR", "AND", "NOT", "|":
+		return fmt.Sprintf("%q", in)
+	}
+	if strings.IndexFunc(in, unicode.IsSpace) >= 0 || strings.ContainsAny(in, "()") || strings.HasPrefix(in, "-") {
+		return fmt.Sprintf("%q", in)
+	}
+	return in
+}
+
+var (
+	predicateRE       = regexp.MustCompile(`^(?i:(ns|allocs|bytes|mbps))(<=|>=|!=|<|>|=)(.+)$`)
+	customPredicateRE = regexp.MustCompile(`^(?i:metric):(".+"|[^"]+?)(<=|>=|!=|<|>|=)([^<>=!]+)$`)
+	termFields        = []string{"name", "cpu", "os", "uploader", "label", "source", "commit", "metric", "go", "gomaxprocs", "cores", "kernel", "governor", "tag"}
+)
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokLParen
+	tokRParen
+	tokNot
+	tokOr
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits query into tokens, without breaking quoted strings and
+// regular expressions.
+func tokenize(query string) ([]token, error) {
+	var (
+		tokens []token
+		word   strings.Builder
+		inq    bool // Within a quoted string.
+		inre   bool // Within a /regexp/.
+		commit = func() {
+			if word.Len() == 0 {
+				return
+			}
+			switch w := word.String(); w {
+			case "OR", "|":
+				tokens = append(tokens, token{kind: tokOr})
+			case "NOT":
+				tokens = append(tokens, token{kind: tokNot})
+			case "AND":
+				// Terms are ANDed by default.
+			default:
+				tokens = append(tokens, token{kind: tokWord, text: w})
+			}
+			word.Reset()
+		}
+	)
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inq = !inq
+			word.WriteRune(r)
+		case inq:
+			word.WriteRune(r)
+		case r == '/' && inre:
+			inre = strings.HasSuffix(word.String(), `\`)
+			word.WriteRune(r)
+		case inre:
+			word.WriteRune(r)
+		case r == '/' && (word.Len() == 0 || strings.HasSuffix(word.String(), ":")):
+			inre = true
+			word.WriteRune(r)
+		case unicode.IsSpace(r):
+			commit()
+		case r == '(' && word.Len() == 0:
+			tokens = append(tokens, token{kind: tokLParen})
+		case r == ')':
+			commit()
+			tokens = append(tokens, token{kind: tokRParen})
+		case r == '-' && word.Len() == 0:
+			tokens = append(tokens, token{kind: tokNot})
+		default:
+			word.WriteRune(r)
+		}
+	}
+	if inq {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inre {
+		return nil, fmt.Errorf("unterminated regular expression")
+	}
+	commit()
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpr parses a query into an Expr, which is nil for an empty query.
+func parseExpr(query string) (Expr, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.describe(p.tokens[p.pos]))
+	}
+	return e, nil
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return token{}, false
+}
+
+func (p *parser) describe(t token) string {
+	switch t.kind {
+	case tokLParen:
+		return "("
+	case tokRParen:
+		return ")"
+	case tokNot:
+		return "-"
+	case tokOr:
+		return "OR"
+	}
+	return t.text
+}
+
+func (p *parser) or() (Expr, error) {
+	var operands Or
+	for {
+		e, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, e)
+		if t, ok := p.peek(); !ok || t.kind != tokOr {
+			break
+		}
+		p.pos++
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	var operands And
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind == tokOr || t.kind == tokRParen {
+			break
+		}
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, e)
+	}
+	operands = mergeTimeRanges(operands)
+	switch len(operands) {
+	case 0:
+		if t, ok := p.peek(); ok {
+			return nil, fmt.Errorf("unexpected %q", p.describe(t))
+		}
+		return nil, fmt.Errorf("unexpected end of query")
+	case 1:
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	t, _ := p.peek()
+	p.pos++
+	switch t.kind {
+	case tokNot:
+		if _, ok := p.peek(); !ok {
+			return nil, fmt.Errorf("unexpected end of query")
+		}
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{e}, nil
+	case tokLParen:
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || t.kind != tokRParen {
+			return nil, fmt.Errorf("unbalanced parentheses")
+		}
+		p.pos++
+		return e, nil
+	case tokWord:
+		return parseTerm(t.text)
+	}
+	return nil, fmt.Errorf("unexpected %q", p.describe(t))
+}
+
+func parseTerm(text string) (Expr, error) {
+	if m := predicateRE.FindStringSubmatch(text); m != nil {
+		if p, ok := parsePredicate(strings.ToLower(m[1]), m[2], unquote(m[3])); ok {
+			return p, nil
+		}
+		// Not a number, so treat it as part of a name (e.g. bytes=large).
+	}
+	if m := customPredicateRE.FindStringSubmatch(text); m != nil {
+		p, ok := parsePredicate(unquote(m[1]), m[2], unquote(m[3]))
+		if !ok {
+			return nil, fmt.Errorf("invalid value %q for %v", p.Text, p.Metric)
+		}
+		p.Custom = true
+		return p, nil
+	}
+	field, value := "name", text
+	if idx := strings.Index(text, ":"); idx > 0 {
+		prefix := strings.ToLower(text[:idx])
+		switch prefix {
+		case "since", "until":
+			return parseTimeRange(prefix, unquote(text[idx+1:]), text)
+		}
+		for _, f := range termFields {
+			if f == prefix {
+				field, value = f, text[idx+1:]
+				break
+			}
+		}
+	}
+	t := Term{Field: field, Value: unquote(value)}
+	if field != "name" {
+		return t, nil
+	}
+	switch {
+	case len(t.Value) > 1 && strings.HasPrefix(t.Value, "/") && strings.HasSuffix(t.Value, "/"):
+		t.Value, t.Pattern = t.Value[1:len(t.Value)-1], Regexp
+		if _, err := regexp.Compile(t.Value); err != nil {
+			return nil, err
+		}
+	case strings.ContainsAny(t.Value, "*?"):
+		t.Pattern = Glob
+	}
+	return t, nil
+}
+
+// parsePredicate returns the Predicate comparing metric to value, which is a
+// number or, for ns, a duration (e.g. 1.5ms).
+func parsePredicate(metric, op, value string) (Predicate, bool) {
+	p := Predicate{Metric: metric, Op: op, Text: value}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil && metric == "ns" {
+		if d, derr := time.ParseDuration(value); derr == nil {
+			f, err = float64(d.Nanoseconds()), nil
+		}
+	}
+	p.Value = f
+	return p, err == nil
+}
+
+// mergeTimeRanges replaces all the TimeRanges in operands with a single one,
+// so that since: and until: select the runs uploaded in between.
+func mergeTimeRanges(operands And) And {
+	var (
+		ret    And
+		merged TimeRange
+		idx    = -1
+	)
+	for _, e := range operands {
+		r, ok := e.(TimeRange)
+		if !ok {
+			ret = append(ret, e)
+			continue
+		}
+		if idx < 0 {
+			idx = len(ret)
+			ret = append(ret, nil)
+		}
+		if r.Since.After(merged.Since) {
+			merged.Since = r.Since
+		}
+		if !r.Until.IsZero() && (merged.Until.IsZero() || r.Until.Before(merged.Until)) {
+			merged.Until = r.Until
+		}
+		if len(merged.Text) > 0 {
+			merged.Text += " "
+		}
+		merged.Text += r.Text
+	}
+	if idx >= 0 {
+		ret[idx] = merged
+	}
+	return ret
+}
+
+// parseTimeRange parses the since: and until: operators, which accept dates
+// (e.g. 2016-02-14), which are inclusive, and times in RFC 3339 format.
+func parseTimeRange(op, value, text string) (Expr, error) {
+	r := TimeRange{Text: text}
+	t, err := time.Parse(time.RFC3339, value)
+	date := false
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("invalid time %q for %v, must be a date (2006-01-02) or RFC 3339 time", value, op)
+		}
+		date = true
+	}
+	if op == "since" {
+		r.Since = t
+	} else {
+		if date {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		r.Until = t
+	}
+	return r, nil
+}
+
+func unquote(s string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(s, `"`), `"`)
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+)
+
+// RegressionPolicy configures the detection of regressions, i.e., of runs
+// whose results are worse than those previously archived for the same
+// Benchmark.
+type RegressionPolicy struct {
+	// History is the number of most recently archived runs of a Benchmark
+	// that a new run is compared to. Zero disables regression detection.
+	History int
+	// Threshold is the minimum relative increase over the median of the
+	// previous results (e.g., 0.1 for 10%) that is flagged as a regression.
+	Threshold float64
+	// MaxPValue, if non-zero, additionally requires that the probability
+	// of a result at least as bad as the new one, assuming that the
+	// previous results are normally distributed around their mean, is at
+	// most MaxPValue. It is only applied when there are at least two
+	// previous results.
+	MaxPValue float64
+}
+
+// metric describes a measurement recorded in a ben.Run.
+type metric struct {
+	name  string
+	value func(ben.Run) float64
+}
+
+// metrics enumerates the ben.Run fields that are checked for regressions. For
+// all of them, larger values are worse.
+var metrics = []metric{
+	{"ns/op", func(r ben.Run) float64 { return r.NanoSecsPerOp }},
+	{"allocs/op", func(r ben.Run) float64 { return float64(r.AllocsPerOp) }},
+	{"B/op", func(r ben.Run) float64 { return float64(r.AllocedBytesPerOp) }},
+}
+
+// Check returns the metrics of run that regressed when compared to history,
+// the previously archived runs (or medians of the samples of each upload) of
+// the same Benchmark with the most recent first. Runs beyond p.History are
+// ignored.
+func (p RegressionPolicy) Check(run ben.Run, history []ben.Run) []archive.Regression {
+	if p.History <= 0 || len(history) == 0 {
+		return nil
+	}
+	if len(history) > p.History {
+		history = history[:p.History]
+	}
+	var ret []archive.Regression
+	for _, m := range metrics {
+		after := m.value(run)
+		previous := values(m, history)
+		before := median(previous)
+		// A zero value typically means that the metric was not
+		// measured (e.g., allocations without -benchmem).
+		if after == 0 || before == 0 || after <= before*(1+p.Threshold) {
+			continue
+		}
+		var pvalue float64
+		if len(previous) > 1 {
+			pvalue = pValue(after, previous)
+			if p.MaxPValue > 0 && pvalue > p.MaxPValue {
+				continue
+			}
+		}
+		ret = append(ret, archive.Regression{
+			Name:   run.Name,
+			Metric: m.name,
+			Before: before,
+			After:  after,
+			PValue: pvalue,
+		})
+	}
+	return ret
+}
+
+// Detect checks each of runs for regressions against the runs of the same
+// Benchmark archived in store. Multiple runs of a benchmark are samples of it,
+// and both they and the samples of each previous upload are represented by
+// their medians.
+func (p RegressionPolicy) Detect(store Store, scenario ben.Scenario, uploader string, runs []ben.Run) ([]archive.Regression, error) {
+	if p.History <= 0 {
+		return nil, nil
+	}
+	var names []string
+	samples := make(map[string][]ben.Run)
+	for _, run := range runs {
+		if _, ok := samples[run.Name]; !ok {
+			names = append(names, run.Name)
+		}
+		samples[run.Name] = append(samples[run.Name], run)
+	}
+	var ret []archive.Regression
+	for _, name := range names {
+		history, err := p.history(store, scenario, uploader, name)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, p.Check(medianRun(samples[name]), history)...)
+	}
+	return ret, nil
+}
+
+func (p RegressionPolicy) history(store Store, scenario ben.Scenario, uploader, name string) ([]ben.Run, error) {
+	_, itr := store.Lookup(scenario, uploader, name)
+	defer itr.Close()
+	var ret []ben.Run
+	err := bySamples(itr, func(s Samples) bool {
+		ret = append(ret, s.Run())
+		return len(ret) < p.History
+	})
+	return ret, err
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+)
+
+func TestRegressionCheck(t *testing.T) {
+	var (
+		policy = RegressionPolicy{History: 3, Threshold: 0.1, MaxPValue: 0.05}
+		steady = []ben.Run{
+			{NanoSecsPerOp: 100, AllocsPerOp: 10},
+			{NanoSecsPerOp: 101, AllocsPerOp: 10},
+			{NanoSecsPerOp: 99, AllocsPerOp: 10},
+		}
+		noisy = []ben.Run{
+			{NanoSecsPerOp: 50},
+			{NanoSecsPerOp: 100},
+			{NanoSecsPerOp: 150},
+		}
+	)
+	for i, test := range []struct {
+		policy  RegressionPolicy
+		run     ben.Run
+		history []ben.Run
+		want    []string
+	}{
+		{policy, ben.Run{NanoSecsPerOp: 105, AllocsPerOp: 10}, steady, nil},
+		{policy, ben.Run{NanoSecsPerOp: 50, AllocsPerOp: 5}, steady, nil},
+		{policy, ben.Run{NanoSecsPerOp: 150, AllocsPerOp: 10}, steady, []string{"ns/op"}},
+		{policy, ben.Run{NanoSecsPerOp: 100, AllocsPerOp: 12}, steady, []string{"allocs/op"}},
+		// AllocsPerOp not measured.
+		{policy, ben.Run{NanoSecsPerOp: 100}, steady, nil},
+		// Too noisy to be significant.
+		{policy, ben.Run{NanoSecsPerOp: 160}, noisy, nil},
+		{RegressionPolicy{History: 3, Threshold: 0.1}, ben.Run{NanoSecsPerOp: 160}, noisy, []string{"ns/op"}},
+		// A single previous run.
+		{policy, ben.Run{NanoSecsPerOp: 160}, noisy[1:2], []string{"ns/op"}},
+		// Runs beyond History are ignored.
+		{RegressionPolicy{History: 1, Threshold: 0.1}, ben.Run{NanoSecsPerOp: 120}, append([]ben.Run{{NanoSecsPerOp: 200}}, steady...), nil},
+		// Detection disabled.
+		{RegressionPolicy{}, ben.Run{NanoSecsPerOp: 1000}, steady, nil},
+	} {
+		var got []string
+		for _, r := range test.policy.Check(test.run, test.history) {
+			got = append(got, r.Metric)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestRegressionDetect(t *testing.T) {
+	store, cleanup := newSQLiteStore(t)
+	defer cleanup()
+	var (
+		policy   = RegressionPolicy{History: 5, Threshold: 0.1}
+		scenario = ben.Scenario{
+			Cpu: ben.Cpu{Architecture: "amd64", Description: "Intel(R) Core(TM) i7"},
+			Os:  ben.Os{Name: "linux", Version: "Ubuntu 14.04"},
+		}
+		other = ben.Scenario{
+			Cpu: ben.Cpu{Architecture: "arm64"},
+			Os:  ben.Os{Name: "linux"},
+		}
+		start = time.Now()
+	)
+	upload := func(scenario ben.Scenario, uploader string, offset int, runs ...ben.Run) []archive.Regression {
+		regressions, err := policy.Detect(store, scenario, uploader, runs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Save(nil, scenario, "code", uploader, start.Add(time.Duration(offset)*time.Second), runs); err != nil {
+			t.Fatal(err)
+		}
+		return regressions
+	}
+	if got := upload(scenario, "alice", 0, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 100}, ben.Run{Name: "BenchmarkB", NanoSecsPerOp: 10}); len(got) > 0 {
+		t.Errorf("got %v, want no regressions", got)
+	}
+	// Regressions are detected only against the same benchmark, scenario
+	// and uploader.
+	if got := upload(other, "alice", 1, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 200}); len(got) > 0 {
+		t.Errorf("got %v, want no regressions", got)
+	}
+	if got := upload(scenario, "bob", 2, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 200}); len(got) > 0 {
+		t.Errorf("got %v, want no regressions", got)
+	}
+	got := upload(scenario, "alice", 3, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 105}, ben.Run{Name: "BenchmarkB", NanoSecsPerOp: 20})
+	want := []archive.Regression{{Name: "BenchmarkB", Metric: "ns/op", Before: 10, After: 20}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := got[0].String(), "ns/op +100.0% (10 -> 20)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	// Repeated samples are compared by their median, so a single noisy
+	// sample is not a regression.
+	if got := upload(scenario, "alice", 4, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 104}, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 300}, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 106}); len(got) > 0 {
+		t.Errorf("got %v, want no regressions", got)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"time"
+
+	"v.io/v23/context"
+)
+
+// RetentionPolicy configures the downsampling of old runs, i.e., the removal
+// of all but one representative run per benchmark and Interval among the runs
+// that are older than KeepAll.
+type RetentionPolicy struct {
+	// KeepAll is the age up to which all runs are kept. Zero disables
+	// downsampling.
+	KeepAll time.Duration
+	// Interval is the length of the time intervals (e.g., 24h for daily)
+	// within which only the run with the median ns/op of older runs of a
+	// benchmark is kept.
+	Interval time.Duration
+}
+
+// Apply downsamples the runs in store that are older than p.KeepAll at time
+// now and returns the number of runs removed.
+func (p RetentionPolicy) Apply(store Store, now time.Time) (int, error) {
+	if p.KeepAll <= 0 {
+		return 0, nil
+	}
+	return store.Downsample(now.Add(-p.KeepAll), p.Interval)
+}
+
+// Run applies p to store every period until ctx is done.
+func (p RetentionPolicy) Run(ctx *context.T, store Store, period time.Duration) {
+	if p.KeepAll <= 0 {
+		return
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if n, err := p.Apply(store, time.Now()); err != nil {
+			ctx.Errorf("Failed to downsample runs older than %v: %v", p.KeepAll, err)
+		} else if n > 0 {
+			ctx.Infof("Downsampled runs older than %v: %d runs removed", p.KeepAll, n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -15,43 +15,95 @@ import (
 	"v.io/v23/context"
 	"v.io/v23/rpc"
 	"v.io/v23/security"
+	"v.io/v23/security/access"
+	"v.io/v23/vdl"
 	"v.io/v23/verror"
 )
 
 // NewArchiver returns an archive.BenchmarkArchiver server that uses
 // store to persist data and provides a UI to browse archived benchmark
-// results at url.
-func NewArchiver(store Store, url string) archive.BenchmarkArchiverServerStub {
+// results at url. Uploaded results are checked for regressions as
+// configured by policy, and access to results is subject to access, which
+// must also be enforced by Authorizer. Archived uploads are announced to
+// the hooks of notifier, which may be nil.
+func NewArchiver(store Store, url string, policy RegressionPolicy, access *Policy, notifier *Notifier) archive.BenchmarkArchiverServerStub {
 	return archive.BenchmarkArchiverServer(&server{
-		url:   url,
-		store: store,
+		url:      url,
+		store:    store,
+		policy:   policy,
+		access:   access,
+		notifier: notifier,
 	})
 }
 
 // Authorizer implements an authorization policy that authorizes callers with
-// any recognizeable blessing name.
-func Authorizer() security.Authorizer { return authorizer{} }
+// any recognizeable blessing name to invoke methods, subject to the
+// Permissions of policy for the access.Tag of the method. The Rules of policy
+// are enforced by the server returned by NewArchiver.
+func Authorizer(policy *Policy) security.Authorizer {
+	return authorizer{policy}
+}
 
 type server struct {
-	url   string
-	store Store
+	url      string
+	store    Store
+	policy   RegressionPolicy
+	access   *Policy
+	notifier *Notifier
+}
+
+func (s *server) Archive(ctx *context.T, call rpc.ServerCall, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run) (string, []archive.Regression, error) {
+	if err := validate(scenario, runs); err != nil {
+		return "", nil, err
+	}
+	uploaderBlessings, _ := security.RemoteBlessingNames(ctx, call.Security())
+	return s.archive(ctx, uploaderBlessings, scenario, code, runs)
+}
+
+func (s *server) Delete(ctx *context.T, call rpc.ServerCall, selector archive.UploadSelector) (int32, error) {
+	blessings, _ := security.RemoteBlessingNames(ctx, call.Security())
+	if err := s.access.authorizeDelete(blessings, selector); err != nil {
+		return 0, verror.ErrNoAccess.Errorf(ctx, "access denied: %v", err)
+	}
+	n, err := s.store.Delete(selector)
+	if err != nil {
+		return 0, err
+	}
+	ctx.Infof("%v deleted %d uploads matching %+v", blessings, n, selector)
+	return int32(n), nil
 }
 
-func (s *server) Archive(ctx *context.T, call rpc.ServerCall, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run) (string, error) {
-	// Various fields must be set.
+// validate returns an error if fields of scenario that must be set are not,
+// or if runs have values that are not finite numbers, which can't be stored.
+func validate(scenario ben.Scenario, runs []ben.Run) error {
 	if len(scenario.Cpu.Architecture) == 0 {
-		return "", fmt.Errorf("Cpu.Architecture must be specified")
+		return fmt.Errorf("Cpu.Architecture must be specified")
 	}
 	if len(scenario.Os.Name) == 0 {
-		return "", fmt.Errorf("Os.Name must be specified")
+		return fmt.Errorf("Os.Name must be specified")
 	}
-	uploaderBlessings, _ := security.RemoteBlessingNames(ctx, call.Security())
+	return checkRuns(runs)
+}
+
+// archive saves runs uploaded by the holder of uploaderBlessings and returns
+// the URL of the results and the regressions they exhibit.
+func (s *server) archive(ctx *context.T, uploaderBlessings []string, scenario ben.Scenario, code ben.SourceCode, runs []ben.Run) (string, []archive.Regression, error) {
 	// If there are multiple blessing names pack them into a single
 	// string using NoExtension - which cannot be a substring in
 	// any of the blessing names.
 	uploader := strings.Join(uploaderBlessings, string(security.NoExtension))
-	if err := s.store.Save(ctx, scenario, code, uploader, time.Now(), runs); err != nil {
-		return "", err
+	if err := s.access.authorizeResults(uploaderBlessings, access.Write, scenario.Label, uploader); err != nil {
+		return "", nil, verror.ErrNoAccess.Errorf(ctx, "access denied: %v", err)
+	}
+	// Compare with the previously archived results before adding these
+	// to them.
+	regressions, err := s.policy.Detect(s.store, scenario, uploader, runs)
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	if err := s.store.Save(ctx, scenario, code, uploader, now, runs); err != nil {
+		return "", nil, err
 	}
 	// Construct a query that will retrieve these results.
 	q := Query{
@@ -59,15 +111,56 @@ func (s *server) Archive(ctx *context.T, call rpc.ServerCall, scenario ben.Scena
 		OS:       scenario.Os.Version,
 		Uploader: uploader,
 	}
-	return fmt.Sprintf("%s%s", s.url, url.QueryEscape(q.String())), nil
+	resultsURL := fmt.Sprintf("%s%s", s.url, url.QueryEscape(q.String()))
+	s.notifier.Notify(uploadNotification(resultsURL, uploader, scenario, code, now, runs, regressions))
+	return resultsURL, regressions, nil
 }
 
-type authorizer struct{}
+// uploadNotification summarizes an archived upload for hooks.
+func uploadNotification(url, uploader string, scenario ben.Scenario, code ben.SourceCode, uploaded time.Time, runs []ben.Run, regressions []archive.Regression) archive.UploadNotification {
+	n := archive.UploadNotification{
+		Url:         url,
+		Uploader:    uploader,
+		Scenario:    scenario,
+		Code:        code,
+		SourceId:    code.ID(),
+		UploadTime:  uploaded,
+		Runs:        int32(len(runs)),
+		Regressions: regressions,
+	}
+	seen := make(map[string]bool)
+	for _, r := range runs {
+		if !seen[r.Name] {
+			seen[r.Name] = true
+			n.Benchmarks = append(n.Benchmarks, r.Name)
+		}
+	}
+	return n
+}
 
-func (authorizer) Authorize(ctx *context.T, call security.Call) error {
+type authorizer struct {
+	policy *Policy
+}
+
+func (a authorizer) Authorize(ctx *context.T, call security.Call) error {
 	got, rejected := security.RemoteBlessingNames(ctx, call)
-	if len(got) > 0 {
-		return nil
+	if len(got) == 0 {
+		return verror.ErrNoAccess.Errorf(ctx, "access denied: %v", fmt.Errorf("refuse to store data for clients with no recognizable names (rejected names: %v)", rejected))
+	}
+	if tag, ok := accessTag(call.MethodTags()); ok {
+		if err := a.policy.authorize(got, tag); err != nil {
+			return verror.ErrNoAccess.Errorf(ctx, "access denied: %v", err)
+		}
+	}
+	return nil
+}
+
+// accessTag returns the access.Tag among the tags of a method.
+func accessTag(tags []*vdl.Value) (access.Tag, bool) {
+	for _, tag := range tags {
+		if tag.Type() == vdl.TypeOf(access.Read) {
+			return access.Tag(tag.RawString()), true
+		}
 	}
-	return verror.ErrNoAccess.Errorf(ctx, "access denied: %v", fmt.Errorf("refuse to store data for clients with no recognizable names (rejected names: %v)", rejected))
+	return "", false
 }
@@ -0,0 +1,92 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	v23 "v.io/v23"
+	"v.io/v23/context"
+	"v.io/v23/verror"
+	_ "v.io/x/ref/runtime/factories/generic"
+	"v.io/x/ref/test"
+	"v.io/x/ref/test/testutil"
+)
+
+func TestDeleteRPC(t *testing.T) {
+	ctx, shutdown := test.V23Init()
+	defer shutdown()
+	store, cleanup := newSQLiteStore(t)
+	defer cleanup()
+	policy := &Policy{}
+	policy.AddAdmin("test-blessing:admin")
+	_, server, err := v23.WithNewServer(ctx, "", NewArchiver(store, "http://benarchd/?q=", RegressionPolicy{}, policy, nil), Authorizer(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := server.Status().Endpoints[0].Name()
+
+	idp := testutil.IDProviderFromPrincipal(v23.GetPrincipal(ctx))
+	client := func(extension string) *context.T {
+		p := testutil.NewPrincipal()
+		if err := idp.Bless(p, extension); err != nil {
+			t.Fatal(err)
+		}
+		ctx, err := v23.WithPrincipal(ctx, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ctx
+	}
+	alice, admin := client("alice"), client("admin")
+
+	scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+	runs := []ben.Run{{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: 5}}
+	stub := archive.BenchmarkArchiverClient(name)
+	if _, _, err := stub.Archive(alice, scenario, "commit", runs); err != nil {
+		t.Fatal(err)
+	}
+	selector := archive.UploadSelector{Uploader: "test-blessing:alice"}
+	if _, err := stub.Delete(alice, selector); verror.ErrorID(err) != verror.ErrNoAccess.ID {
+		t.Errorf("got %v, want %v", err, verror.ErrNoAccess.ID)
+	}
+	if n, err := stub.Delete(admin, selector); err != nil || n != 1 {
+		t.Errorf("got (%v, %v), want (1, nil)", n, err)
+	}
+	if n, err := stub.Delete(admin, selector); err != nil || n != 0 {
+		t.Errorf("got (%v, %v), want (0, nil)", n, err)
+	}
+}
+
+func TestArchiveRPC(t *testing.T) {
+	ctx, shutdown := test.V23Init()
+	defer shutdown()
+	store := NewMemStore()
+	policy := &Policy{}
+	_, server, err := v23.WithNewServer(ctx, "", NewArchiver(store, "http://benarchd/?q=", RegressionPolicy{}, policy, nil), Authorizer(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub := archive.BenchmarkArchiverClient(server.Status().Endpoints[0].Name())
+
+	// Values that are not finite numbers can't be stored.
+	scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+	for _, run := range []ben.Run{
+		{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: math.NaN()},
+		{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: 5, MegaBytesPerSec: math.Inf(1)},
+		{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: 5, Metrics: map[string]float64{"p99-ns": math.Inf(-1)}},
+	} {
+		if _, _, err := stub.Archive(ctx, scenario, "commit", []ben.Run{run}); err == nil || !strings.Contains(err.Error(), "BenchmarkA") {
+			t.Errorf("Archive(%+v): got error %v", run, err)
+		}
+	}
+	if _, _, err := stub.Archive(ctx, scenario, "commit", []ben.Run{{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: 5}}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLite3Driver is the name of the database/sql driver to use for opening
+// sqlite3 databases for NewSQLStore. It is the go-sqlite3 driver extended with
+// the REGEXP operator, which is required for regular expressions in queries.
+const SQLite3Driver = "sqlite3_benarchd"
+
+func init() {
+	var (
+		mu    sync.Mutex
+		cache = map[string]*regexp.Regexp{}
+	)
+	match := func(re, s string) (bool, error) {
+		mu.Lock()
+		r, ok := cache[re]
+		mu.Unlock()
+		if !ok {
+			var err error
+			if r, err = regexp.Compile(re); err != nil {
+				return false, err
+			}
+			mu.Lock()
+			cache[re] = r
+			mu.Unlock()
+		}
+		return r.MatchString(s), nil
+	}
+	sql.Register(SQLite3Driver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("regexp", match, true)
+		},
+	})
+}
+
+// sqlCompiler translates an Expr into a condition on the rows selected by
+// searchBenchmarksSQL, using MySQL syntax (see sqlStore.tweakSQL).
+type sqlCompiler struct {
+	strings.Builder
+	args []interface{}
+}
+
+func (c *sqlCompiler) compile(e Expr) {
+	switch e := e.(type) {
+	case And:
+		c.join(e, " AND ")
+	case Or:
+		c.join(e, " OR ")
+	case Not:
+		c.WriteString("NOT ")
+		c.join([]Expr{e.X}, "")
+	case Term:
+		c.term(e)
+	case TimeRange:
+		c.WriteString("EXISTS (SELECT 1 FROM Run INNER JOIN Upload ON (Run.Upload = Upload.ID) WHERE Run.Benchmark = Benchmark.ID")
+		if !e.Since.IsZero() {
+			c.WriteString(" AND TIMESTAMP(Upload.Timestamp) >= TIMESTAMP(?)")
+			c.args = append(c.args, e.Since)
+		}
+		if !e.Until.IsZero() {
+			c.WriteString(" AND TIMESTAMP(Upload.Timestamp) <= TIMESTAMP(?)")
+			c.args = append(c.args, e.Until)
+		}
+		c.WriteString(")")
+	case Predicate:
+		c.predicate(e)
+	}
+}
+
+func (c *sqlCompiler) join(exprs []Expr, sep string) {
+	c.WriteString("(")
+	for i, e := range exprs {
+		if i > 0 {
+			c.WriteString(sep)
+		}
+		c.compile(e)
+	}
+	c.WriteString(")")
+}
+
+func (c *sqlCompiler) term(t Term) {
+	switch t.Field {
+	case "name":
+		switch t.Pattern {
+		case Glob:
+			c.WriteString("Benchmark.Name LIKE ? ESCAPE '!'")
+			c.args = append(c.args, globToLike(t.Value))
+		case Regexp:
+			c.WriteString("Benchmark.Name REGEXP ?")
+			c.args = append(c.args, t.Value)
+		default:
+			c.WriteString("Benchmark.Name LIKE CONCAT('%',?,'%') ESCAPE '!'")
+			c.args = append(c.args, escapeLike(t.Value))
+		}
+	case "cpu":
+		mhz, err := strconv.Atoi(t.Value)
+		if err != nil {
+			mhz = -1
+		}
+		c.WriteString("(CPU.Architecture LIKE CONCAT('%',LOWER(?),'%') ESCAPE '!' OR CPU.Description LIKE CONCAT('%',LOWER(?),'%') ESCAPE '!' OR CPU.ClockSpeedMHz = ?)")
+		c.args = append(c.args, escapeLike(t.Value), escapeLike(t.Value), mhz)
+	case "os":
+		c.WriteString("(OS.Name LIKE CONCAT('%',LOWER(?),'%') ESCAPE '!' OR OS.Version LIKE CONCAT('%',LOWER(?),'%') ESCAPE '!')")
+		c.args = append(c.args, escapeLike(t.Value), escapeLike(t.Value))
+	case "uploader":
+		c.WriteString("Scenario.Uploader LIKE CONCAT('%',LOWER(?),'%') ESCAPE '!'")
+		c.args = append(c.args, escapeLike(t.Value))
+	case "label":
+		c.WriteString("Scenario.Label LIKE CONCAT('%',LOWER(?),'%') ESCAPE '!'")
+		c.args = append(c.args, escapeLike(t.Value))
+	case "source":
+		c.WriteString("EXISTS (SELECT 1 FROM Run INNER JOIN Upload ON (Run.Upload = Upload.ID) WHERE Run.Benchmark = Benchmark.ID AND Upload.SourceCode LIKE CONCAT(?,'%') ESCAPE '!')")
+		c.args = append(c.args, escapeLike(t.Value))
+	case "commit":
+		c.WriteString("EXISTS (SELECT 1 FROM Run INNER JOIN Upload ON (Run.Upload = Upload.ID) INNER JOIN SourceCommit ON (Upload.SourceCode = SourceCommit.SourceCode) WHERE Run.Benchmark = Benchmark.ID AND SourceCommit.Hash LIKE CONCAT(?,'%') ESCAPE '!')")
+		c.args = append(c.args, escapeLike(t.Value))
+	case "go":
+		c.uploadEnvironment("UploadEnvironment.GoVersion LIKE CONCAT('%',?,'%') ESCAPE '!'", escapeLike(t.Value))
+	case "gomaxprocs", "cores":
+		n, err := strconv.Atoi(t.Value)
+		if err != nil {
+			n = -1
+		}
+		column := "UploadEnvironment.GoMaxProcs"
+		if t.Field == "cores" {
+			column = "UploadEnvironment.Cores"
+		}
+		c.uploadEnvironment(column+" = ?", n)
+	case "kernel":
+		c.uploadEnvironment("UploadEnvironment.Kernel LIKE CONCAT('%',?,'%') ESCAPE '!'", escapeLike(t.Value))
+	case "governor":
+		c.uploadEnvironment("UploadEnvironment.CPUGovernor LIKE CONCAT('%',?,'%') ESCAPE '!'", escapeLike(t.Value))
+	case "tag":
+		name, value, hasValue := t.tag()
+		c.WriteString("EXISTS (SELECT 1 FROM Run INNER JOIN UploadTag ON (Run.Upload = UploadTag.Upload) WHERE Run.Benchmark = Benchmark.ID AND LOWER(UploadTag.Name) = LOWER(?)")
+		c.args = append(c.args, name)
+		if hasValue {
+			c.WriteString(" AND LOWER(UploadTag.Value) = LOWER(?)")
+			c.args = append(c.args, value)
+		}
+		c.WriteString(")")
+	case "metric":
+		c.WriteString("EXISTS (SELECT 1 FROM Metric WHERE Metric.Benchmark = Benchmark.ID AND Metric.Name = ?)")
+		c.args = append(c.args, t.Value)
+	}
+}
+
+// uploadEnvironment writes the condition that the environment of the upload of
+// any run of the benchmark satisfies cond, which has a single argument.
+func (c *sqlCompiler) uploadEnvironment(cond string, arg interface{}) {
+	c.WriteString("EXISTS (SELECT 1 FROM Run INNER JOIN UploadEnvironment ON (Run.Upload = UploadEnvironment.Upload) WHERE Run.Benchmark = Benchmark.ID AND " + cond + ")")
+	c.args = append(c.args, arg)
+}
+
+func (c *sqlCompiler) predicate(p Predicate) {
+	// Benchmarks without a value of the metric do not match, and so they
+	// do match the negation of the predicate.
+	c.WriteString("COALESCE(")
+	switch {
+	case p.Custom:
+		c.WriteString("(SELECT Metric.Value FROM Metric INNER JOIN Upload ON (Metric.Upload = Upload.ID) WHERE Metric.Benchmark = Benchmark.ID AND Metric.Name = ? ORDER BY Upload.Timestamp DESC LIMIT 1)")
+		c.args = append(c.args, p.Metric)
+	case p.Metric == "ns":
+		c.WriteString("Benchmark.NanoSecsPerOp")
+	case p.Metric == "mbps":
+		c.WriteString("Benchmark.MegaBytesPerSec")
+	default:
+		// Only the ns/op and MB/s of the latest run are cached in the
+		// Benchmark table.
+		column := "Run.AllocsPerOp"
+		if p.Metric == "bytes" {
+			column = "Run.AllocedBytesPerOp"
+		}
+		c.WriteString("(SELECT " + column + " FROM Run INNER JOIN Upload ON (Run.Upload = Upload.ID) WHERE Run.Benchmark = Benchmark.ID ORDER BY Upload.Timestamp DESC LIMIT 1)")
+	}
+	op := p.Op
+	if op == "!=" {
+		op = "<>"
+	}
+	c.WriteString(" " + op + " ?, 0)")
+	c.args = append(c.args, p.Value)
+}
+
+// escapeLike escapes the wildcards of LIKE ... ESCAPE '!' in s, so that a
+// pattern containing it matches s literally.
+func escapeLike(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '!' || r == '%' || r == '_' {
+			b.WriteRune('!')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// globToLike converts a glob into a pattern for LIKE ... ESCAPE '!'.
+func globToLike(glob string) string {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '!', '%', '_':
+			b.WriteRune('!')
+			b.WriteRune(r)
+		case '*':
+			b.WriteRune('%')
+		case '?':
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"fmt"
+
+	"github.com/vanadium/services/internal/dbutil"
+)
+
+// SQLStoreSchema is the name under which dbutil.Migrate records the version of
+// the schema of the tables of the Store returned by NewSQLStore.
+const SQLStoreSchema = "benarchd"
+
+// SQLMigrations returns the migrations of SQLStoreSchema, in the dialect of the
+// given driver (see NewSQLStore). New migrations must be appended, and existing
+// ones must never be changed.
+func SQLMigrations(driver string) []dbutil.Migration {
+	s := &sqlStore{driver: driver}
+	createTable := func(name, schema string) string {
+		return s.tweakSQL(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", name, schema))
+	}
+	// One might have considered using a single table for all Scenario
+	// related information with a UNIQUE constraint on all the fields
+	// (e.g., UNIQUE(CPUArchitecture, CPUDescription, CPUMHz, OSName, OSVersion, ...))
+	//
+	// However, in MySQL (and Google Cloud SQL) there are limits on the
+	// size of an index key (and the UNIQUE constraint implies an index
+	// over all the included fields). For example, in Google Cloud SQL,
+	// the limit is 3072 bytes, which is easily met by 6 VARCHAR(255)
+	// columns (since VARCHAR(x) consumes 3x bytes in the index key).
+	//
+	// Instead, normalize the bejesus about of the fields and create
+	// multiple tables (at the cost of additional SELECT statements
+	// to find out IDs of previously inserted values).
+	return []dbutil.Migration{
+		{
+			// Tables created before schema versions were recorded
+			// may already exist.
+			Description: "Create the tables of benchmark runs",
+			Statements: []string{
+				createTable("CPU", `
+ID            INTEGER PRIMARY KEY AUTO_INCREMENT,
+Architecture  VARCHAR(255),
+Description   VARCHAR(255),
+ClockSpeedMHz INTEGER,
+
+UNIQUE(Architecture, Description, ClockSpeedMHz)
+`),
+				createTable("OS", `
+ID      INTEGER PRIMARY KEY AUTO_INCREMENT,
+Name    VARCHAR(255),
+Version VARCHAR(255),
+
+UNIQUE(Name, Version)
+`),
+				createTable("Scenario", `
+ID       INTEGER PRIMARY KEY AUTO_INCREMENT,
+CPU      INTEGER,
+OS       INTEGER,
+Uploader VARCHAR(255),
+Label    VARCHAR(255),
+
+UNIQUE(CPU, OS, Uploader, Label),
+
+FOREIGN KEY(CPU) REFERENCES CPU(ID),
+FOREIGN KEY(OS)  REFERENCES OS(ID)
+`),
+				createTable("SourceCode", `
+ID          CHAR(64) PRIMARY KEY,
+Description TEXT
+`),
+				createTable("Upload", `
+ID               INTEGER PRIMARY KEY AUTO_INCREMENT,
+Timestamp        DATETIME,
+SourceCode       CHAR(64),
+
+FOREIGN KEY(SourceCode) REFERENCES SourceCode(ID)
+`),
+				createTable("Benchmark", `
+ID              INTEGER PRIMARY KEY AUTO_INCREMENT,
+Scenario        INTEGER,
+Name            VARCHAR(255),
+NanoSecsPerOp   DOUBLE,
+MegaBytesPerSec DOUBLE,
+LastUpdate      DATETIME,
+
+UNIQUE(Scenario, Name),
+
+FOREIGN KEY(Scenario) REFERENCES Scenario(ID)
+`),
+				createTable("Run", `
+Benchmark         INTEGER,
+Upload            INTEGER,
+Iterations        INTEGER,
+NanoSecsPerOp     DOUBLE,
+AllocsPerOp       INTEGER,
+AllocedBytesPerOp INTEGER,
+MegaBytesPerSec   DOUBLE,
+Parallelism       INTEGER,
+
+FOREIGN KEY(Benchmark) REFERENCES Benchmark(ID),
+FOREIGN KEY(Upload) REFERENCES Upload(ID)
+`),
+			},
+		},
+		{
+			Description: "Add custom metrics of runs",
+			Statements: []string{
+				// Custom metrics (ben.Run.Metrics) of the runs of a
+				// benchmark in an upload.
+				createTable("Metric", `
+Benchmark INTEGER,
+Upload    INTEGER,
+Name      VARCHAR(255),
+Value     DOUBLE,
+
+UNIQUE(Benchmark, Upload, Name),
+
+FOREIGN KEY(Benchmark) REFERENCES Benchmark(ID),
+FOREIGN KEY(Upload) REFERENCES Upload(ID)
+`),
+			},
+		},
+		{
+			Description: "Add statistics of the samples of benchmarks",
+			Statements: []string{
+				// Statistics of the samples (i.e., runs) of a
+				// benchmark in an upload.
+				createTable("RunStats", `
+Benchmark             INTEGER,
+Upload                INTEGER,
+Samples               INTEGER,
+MinNanoSecsPerOp      DOUBLE,
+MedianNanoSecsPerOp   DOUBLE,
+MeanNanoSecsPerOp     DOUBLE,
+StdDevNanoSecsPerOp   DOUBLE,
+CILowNanoSecsPerOp    DOUBLE,
+CIHighNanoSecsPerOp   DOUBLE,
+MedianMegaBytesPerSec DOUBLE,
+
+UNIQUE(Benchmark, Upload),
+
+FOREIGN KEY(Benchmark) REFERENCES Benchmark(ID),
+FOREIGN KEY(Upload) REFERENCES Upload(ID)
+`),
+			},
+		},
+		{
+			Description: "Index runs by benchmark and upload",
+			Statements: []string{
+				"CREATE INDEX RunByBenchmark ON Run (Benchmark, Upload)",
+			},
+		},
+		{
+			Description: "Index uploads by time",
+			Statements: []string{
+				"CREATE INDEX UploadByTimestamp ON Upload (Timestamp)",
+			},
+		},
+		{
+			Description: "Add the commits of source code",
+			Statements: []string{
+				// Commits of the structured source code
+				// descriptions (see ben.SourceCode.Commits).
+				createTable("SourceCommit", `
+SourceCode CHAR(64),
+Hash       VARCHAR(255),
+
+UNIQUE(SourceCode, Hash),
+
+FOREIGN KEY(SourceCode) REFERENCES SourceCode(ID)
+`),
+			},
+		},
+		{
+			Description: "Add the commits of previously archived source code",
+			Statements: []string{
+				// Previously archived descriptions are opaque, and
+				// those that are their own IDs are taken to be
+				// commits.
+				"INSERT INTO SourceCommit (SourceCode, Hash) SELECT ID, Description FROM SourceCode WHERE ID = Description",
+			},
+		},
+		{
+			Description: "Add the environments of uploads",
+			Statements: []string{
+				// The environment (ben.Scenario.Env) of an upload,
+				// which does not identify its benchmarks.
+				createTable("UploadEnvironment", `
+Upload      INTEGER PRIMARY KEY,
+GoVersion   VARCHAR(255),
+GoMaxProcs  INTEGER,
+Cores       INTEGER,
+MemoryBytes BIGINT,
+Kernel      VARCHAR(255),
+CPUGovernor VARCHAR(255),
+
+FOREIGN KEY(Upload) REFERENCES Upload(ID)
+`),
+			},
+		},
+		{
+			Description: "Add the tags of uploads",
+			Statements: []string{
+				// The tags of the environment of an upload.
+				createTable("UploadTag", `
+Upload INTEGER,
+Name   VARCHAR(255),
+Value  VARCHAR(255),
+
+UNIQUE(Upload, Name),
+
+FOREIGN KEY(Upload) REFERENCES Upload(ID)
+`),
+			},
+		},
+	}
+}
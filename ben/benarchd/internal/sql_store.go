@@ -7,16 +7,53 @@ package internal
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"github.com/vanadium/services/internal/dbutil"
 	"v.io/v23/context"
 )
 
 const driverSqlite3 = "sqlite3"
 
+// searchBenchmarksSQL selects the benchmarks matching the fields of a Query.
+// Full text search using LIKE CONCAT('%',?,'%')
+// This can't be good!
+//
+// Conditions for the Filters of the Query are inserted at filtersMarker.
+const searchBenchmarksSQL = `
+SELECT
+	Benchmark.ID,
+	Benchmark.Name,
+	Benchmark.NanoSecsPerOp,
+	Benchmark.MegaBytesPerSec,
+	Benchmark.LastUpdate,
+	OS.Name,
+	OS.Version,
+	CPU.Architecture,
+	CPU.Description,
+	CPU.ClockSpeedMHz,
+	Scenario.Uploader,
+	Scenario.Label
+FROM Benchmark
+INNER JOIN Scenario ON (Benchmark.Scenario = Scenario.ID)
+INNER JOIN OS ON (Scenario.OS = OS.ID)
+INNER JOIN CPU ON (Scenario.CPU = CPU.ID)
+WHERE Benchmark.Name LIKE CONCAT('%',?,'%')
+AND (OS.Name LIKE CONCAT('%',LOWER(?),'%') OR OS.Version LIKE CONCAT('%',LOWER(?),'%'))
+AND (CPU.Architecture LIKE CONCAT('%',LOWER(?),'%') OR CPU.Description LIKE CONCAT('%',LOWER(?),'%') OR CPU.ClockSpeedMHz = ?)
+AND Scenario.Uploader LIKE CONCAT('%',LOWER(?),'%')
+AND Scenario.Label LIKE CONCAT('%',LOWER(?),'%')
+/*filters*/
+ORDER BY Benchmark.LastUpdate DESC
+`
+
+const filtersMarker = "/*filters*/"
+
 // NewSQLStore returns a Store implementation that uses the provided database
 // for persistent storage.
 func NewSQLStore(driver string, db *sql.DB) (Store, error) {
@@ -38,14 +75,30 @@ type sqlStore struct {
 	insertCPU, selectCPU             *sql.Stmt
 	insertScenario, selectScenario   *sql.Stmt
 	insertSourceCode                 *sql.Stmt
+	insertSourceCommit               *sql.Stmt
 	insertUpload                     *sql.Stmt
+	insertEnvironment, insertTag     *sql.Stmt
 	insertBenchmark, selectBenchmark *sql.Stmt
 	updateBenchmark                  *sql.Stmt
 	insertRun                        *sql.Stmt
 	selectRunsByBenchmark            *sql.Stmt
+	insertMetric                     *sql.Stmt
+	insertStats, deleteStats         *sql.Stmt
+	selectMetricsByBenchmark         *sql.Stmt
+	selectTagsByBenchmark            *sql.Stmt
 	selectSourceCode                 *sql.Stmt
 	searchBenchmarks                 *sql.Stmt
 	describeBenchmark                *sql.Stmt
+	lookupBenchmark                  *sql.Stmt
+	selectRunsBefore                 *sql.Stmt
+	deleteMetrics, deleteRuns        *sql.Stmt
+	selectLatestRun                  *sql.Stmt
+	deleteBenchmark                  *sql.Stmt
+	deleteUnusedEnvironments         *sql.Stmt
+	deleteUnusedTags                 *sql.Stmt
+	deleteUnusedUploads              *sql.Stmt
+	deleteUnusedSourceCommits        *sql.Stmt
+	deleteUnusedSourceCode           *sql.Stmt
 }
 
 // tweakSQL returns a SQL string appropriate for the database implementation
@@ -56,6 +109,9 @@ func (s *sqlStore) tweakSQL(mysql string) string {
 		stmt = strings.ReplaceAll(stmt, "INSERT IGNORE", "INSERT OR IGNORE")
 		stmt = strings.ReplaceAll(stmt, "CONCAT('%',?,'%')", "'%'||?||'%'")
 		stmt = strings.ReplaceAll(stmt, "CONCAT('%',LOWER(?),'%')", "'%'||LOWER(?)||'%'")
+		stmt = strings.ReplaceAll(stmt, "CONCAT(?,'%')", "?||'%'")
+		stmt = strings.ReplaceAll(stmt, "TIMESTAMP(Upload.Timestamp)", "julianday(Upload.Timestamp)")
+		stmt = strings.ReplaceAll(stmt, "TIMESTAMP(?)", "julianday(?)")
 		return stmt
 	}
 	return mysql
@@ -65,6 +121,9 @@ func (s *sqlStore) Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCo
 	if len(runs) == 0 {
 		return nil
 	}
+	if err := checkRuns(runs); err != nil {
+		return err
+	}
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
@@ -87,6 +146,11 @@ func (s *sqlStore) Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCo
 	if _, err := tx.Stmt(s.insertSourceCode).Exec(codeID, string(code)); err != nil {
 		return tagerr("sourcecode", err)
 	}
+	for _, commit := range code.Commits() {
+		if _, err := tx.Stmt(s.insertSourceCommit).Exec(codeID, commit); err != nil {
+			return tagerr("sourcecommit", err)
+		}
+	}
 	result, err := tx.Stmt(s.insertUpload).Exec(uploadTime, codeID)
 	if err != nil {
 		return tagerr("upload", err)
@@ -95,17 +159,58 @@ func (s *sqlStore) Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCo
 	if err != nil {
 		return err
 	}
+	env := scenario.Env
+	if _, err := tx.Stmt(s.insertEnvironment).Exec(upload, env.GoVersion, env.GoMaxProcs, env.Cores, env.MemoryBytes, env.Kernel, env.CpuGovernor); err != nil {
+		return tagerr("environment", err)
+	}
+	for name, value := range env.Tags {
+		if _, err := tx.Stmt(s.insertTag).Exec(upload, name, value); err != nil {
+			return tagerr("tag", err)
+		}
+	}
+	// Repeated runs of a benchmark (e.g., from "go test -count") are
+	// samples of it, which are summarized in the RunStats table.
+	var names []string
+	samples := make(map[string][]ben.Run)
 	for _, run := range runs {
-		bm, err := s.insertAndGetID(tx, s.insertBenchmark, s.selectBenchmark, scn, run.Name)
+		if _, ok := samples[run.Name]; !ok {
+			names = append(names, run.Name)
+		}
+		samples[run.Name] = append(samples[run.Name], run)
+	}
+	for _, name := range names {
+		bm, err := s.insertAndGetID(tx, s.insertBenchmark, s.selectBenchmark, scn, name)
 		if err != nil {
 			return tagerr("benchmark", err)
 		}
+		var (
+			ns, mbps []float64
+			metrics  = make(map[string][]float64)
+		)
+		for _, run := range samples[name] {
+			if _, err := tx.Stmt(s.insertRun).Exec(bm, upload, run.Iterations, run.NanoSecsPerOp, run.AllocsPerOp, run.AllocedBytesPerOp, run.MegaBytesPerSec, run.Parallelism); err != nil {
+				return tagerr("run", err)
+			}
+			ns = append(ns, run.NanoSecsPerOp)
+			mbps = append(mbps, run.MegaBytesPerSec)
+			for unit, value := range run.Metrics {
+				metrics[unit] = append(metrics[unit], value)
+			}
+		}
+		stats := newStats(ns)
+		if _, err := tx.Stmt(s.insertStats).Exec(bm, upload, stats.Samples, stats.Min, stats.Median, stats.Mean, stats.StdDev, stats.CILow, stats.CIHigh, median(mbps)); err != nil {
+			return tagerr("stats", err)
+		}
 		// Cache this "latest" result values
-		if _, err := tx.Stmt(s.updateBenchmark).Exec(run.NanoSecsPerOp, run.MegaBytesPerSec, uploadTime, bm); err != nil {
+		if _, err := tx.Stmt(s.updateBenchmark).Exec(stats.Median, median(mbps), uploadTime, bm); err != nil {
 			return tagerr("update_benchmark", err)
 		}
-		if _, err := tx.Stmt(s.insertRun).Exec(bm, upload, run.Iterations, run.NanoSecsPerOp, run.AllocsPerOp, run.AllocedBytesPerOp, run.MegaBytesPerSec, run.Parallelism); err != nil {
-			return tagerr("run", err)
+		// Custom metrics are identified by the benchmark and upload of
+		// their run, so the median of the samples is kept.
+		for unit, values := range metrics {
+			if _, err := tx.Stmt(s.insertMetric).Exec(bm, upload, unit, median(values)); err != nil {
+				return tagerr("metric", err)
+			}
 		}
 	}
 	return tx.Commit()
@@ -114,9 +219,208 @@ func (s *sqlStore) Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCo
 func (s *sqlStore) DescribeSource(id string) (ben.SourceCode, error) {
 	var str string
 	err := s.selectSourceCode.QueryRow(id).Scan(&str)
+	if err == sql.ErrNoRows {
+		err = ErrNotFound
+	}
 	return ben.SourceCode(str), err
 }
 
+func (s *sqlStore) Delete(selector archive.UploadSelector) (int, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if len(selector.Upload) > 0 {
+		id, err := strconv.ParseInt(selector.Upload, 16, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid upload ID %q", selector.Upload)
+		}
+		where = append(where, "Run.Upload = ?")
+		args = append(args, id)
+	}
+	if len(selector.Uploader) > 0 {
+		where = append(where, "Scenario.Uploader = LOWER(?)")
+		args = append(args, selector.Uploader)
+	}
+	if len(selector.Label) > 0 {
+		where = append(where, "Scenario.Label = LOWER(?)")
+		args = append(args, selector.Label)
+	}
+	if len(where) == 0 {
+		return 0, fmt.Errorf("no uploads selected, at least one of Upload, Uploader or Label must be set")
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// If tx.Commit is called, then this tx.Rollback is a no-op
+	defer tx.Rollback() //nolint:errcheck
+	rows, err := tx.Query(`
+SELECT DISTINCT Run.Benchmark, Run.Upload
+FROM Run
+INNER JOIN Benchmark ON (Run.Benchmark = Benchmark.ID)
+INNER JOIN Scenario ON (Benchmark.Scenario = Scenario.ID)
+WHERE `+strings.Join(where, " AND "), args...)
+	if err != nil {
+		return 0, tagerr("select", err)
+	}
+	var keys []runKey
+	uploads := make(map[int64]bool)
+	for rows.Next() {
+		var k runKey
+		if err := rows.Scan(&k.benchmark, &k.upload); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		keys = append(keys, k)
+		uploads[k.upload] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if _, err := s.removeRuns(tx, keys); err != nil {
+		return 0, err
+	}
+	return len(uploads), tx.Commit()
+}
+
+func (s *sqlStore) Downsample(before time.Time, interval time.Duration) (int, error) {
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid downsampling interval %v", interval)
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// If tx.Commit is called, then this tx.Rollback is a no-op
+	defer tx.Rollback() //nolint:errcheck
+	rows, err := tx.Stmt(s.selectRunsBefore).Query(before)
+	if err != nil {
+		return 0, tagerr("select", err)
+	}
+	// The runs of a benchmark in an upload are kept or removed together,
+	// so each upload is represented by the mean of its runs.
+	type sample struct {
+		n   int
+		sum float64
+	}
+	type bucket struct {
+		benchmark int64
+		start     int64
+	}
+	samples := make(map[runKey]*sample)
+	buckets := make(map[bucket][]runKey)
+	for rows.Next() {
+		var (
+			k  runKey
+			t  time.Time
+			ns float64
+		)
+		if err := rows.Scan(&k.benchmark, &k.upload, &t, &ns); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		smp, ok := samples[k]
+		if !ok {
+			smp = &sample{}
+			samples[k] = smp
+			b := bucket{k.benchmark, t.UTC().Truncate(interval).UnixNano()}
+			buckets[b] = append(buckets[b], k)
+		}
+		smp.n++
+		smp.sum += ns
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	var remove []runKey
+	for _, keys := range buckets {
+		if len(keys) < 2 {
+			continue
+		}
+		mean := func(i int) float64 { return samples[keys[i]].sum / float64(samples[keys[i]].n) }
+		sort.Slice(keys, func(i, j int) bool { return mean(i) < mean(j) })
+		median := (len(keys) - 1) / 2
+		remove = append(remove, keys[:median]...)
+		remove = append(remove, keys[median+1:]...)
+	}
+	removed, err := s.removeRuns(tx, remove)
+	if err != nil {
+		return 0, err
+	}
+	return removed, tx.Commit()
+}
+
+// runKey identifies the runs of a benchmark in an upload.
+type runKey struct{ benchmark, upload int64 }
+
+// removeRuns removes the runs (and their custom metrics) identified by keys,
+// updates the "latest" values cached in the Benchmark table to reflect the
+// remaining runs and removes benchmarks, uploads and source code descriptions
+// that are no longer referred to by any run. It returns the number of runs
+// removed.
+func (s *sqlStore) removeRuns(tx *sql.Tx, keys []runKey) (int, error) {
+	var removed int64
+	benchmarks := make(map[int64]bool)
+	for _, k := range keys {
+		if _, err := tx.Stmt(s.deleteMetrics).Exec(k.benchmark, k.upload); err != nil {
+			return 0, tagerr("delete_metrics", err)
+		}
+		if _, err := tx.Stmt(s.deleteStats).Exec(k.benchmark, k.upload); err != nil {
+			return 0, tagerr("delete_stats", err)
+		}
+		result, err := tx.Stmt(s.deleteRuns).Exec(k.benchmark, k.upload)
+		if err != nil {
+			return 0, tagerr("delete_runs", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		removed += n
+		benchmarks[k.benchmark] = true
+	}
+	if len(benchmarks) == 0 {
+		return 0, nil
+	}
+	for bm := range benchmarks {
+		var (
+			ns, mbps float64
+			t        time.Time
+		)
+		switch err := tx.Stmt(s.selectLatestRun).QueryRow(bm).Scan(&ns, &mbps, &t); err {
+		case nil:
+			if _, err := tx.Stmt(s.updateBenchmark).Exec(ns, mbps, t, bm); err != nil {
+				return 0, tagerr("update_benchmark", err)
+			}
+		case sql.ErrNoRows:
+			if _, err := tx.Stmt(s.deleteBenchmark).Exec(bm); err != nil {
+				return 0, tagerr("delete_benchmark", err)
+			}
+		default:
+			return 0, tagerr("latest_run", err)
+		}
+	}
+	if _, err := tx.Stmt(s.deleteUnusedEnvironments).Exec(); err != nil {
+		return 0, tagerr("delete_environments", err)
+	}
+	if _, err := tx.Stmt(s.deleteUnusedTags).Exec(); err != nil {
+		return 0, tagerr("delete_tags", err)
+	}
+	if _, err := tx.Stmt(s.deleteUnusedUploads).Exec(); err != nil {
+		return 0, tagerr("delete_uploads", err)
+	}
+	if _, err := tx.Stmt(s.deleteUnusedSourceCommits).Exec(); err != nil {
+		return 0, tagerr("delete_sourcecommits", err)
+	}
+	if _, err := tx.Stmt(s.deleteUnusedSourceCode).Exec(); err != nil {
+		return 0, tagerr("delete_sourcecode", err)
+	}
+	return int(removed), nil
+}
+
 type nullItr struct{ err error }
 
 func (*nullItr) Advance() bool { return false }
@@ -131,6 +435,9 @@ func (i *nullBmItr) Runs() RunIterator { return &nullRunsItr{} }
 type nullRunsItr struct{ nullItr }
 
 func (i *nullRunsItr) Value() (ben.Run, string, time.Time) { return ben.Run{}, "", time.Time{} }
+func (i *nullRunsItr) UploadID() string                    { return "" }
+func (i *nullRunsItr) Stats() Stats                        { return Stats{} }
+func (i *nullRunsItr) Environment() ben.Environment        { return ben.Environment{} }
 
 type sqlItr struct {
 	rows    *sql.Rows
@@ -148,8 +455,8 @@ func (i *sqlItr) Err() error {
 
 type sqlBmItr struct {
 	sqlItr
-	id         int64 // BenchmarkID of the last scanned row.
-	selectRuns *sql.Stmt
+	id    int64 // BenchmarkID of the last scanned row.
+	store *sqlStore
 }
 
 func (i *sqlBmItr) Value() Benchmark {
@@ -170,23 +477,26 @@ func (i *sqlBmItr) Value() Benchmark {
 	ret.ID = fmt.Sprintf("%x", i.id)
 	return ret
 }
-func (i *sqlBmItr) Runs() RunIterator {
-	rows, err := i.selectRuns.Query(i.id)
-	if err != nil {
-		return &nullRunsItr{nullItr{err}}
-	}
-	return &sqlRunItr{sqlItr: sqlItr{rows: rows}}
-}
+func (i *sqlBmItr) Runs() RunIterator { return i.store.runsOf(i.id) }
 
 type sqlRunItr struct {
 	sqlItr
+	upload  int64                        // ID of the upload of the last scanned row.
+	stats   Stats                        // Stats of the last scanned row.
+	env     ben.Environment              // Environment of the last scanned row.
+	metrics map[int64]map[string]float64 // Custom metrics of runs, by upload.
+	tags    map[int64]map[string]string  // Tags of uploads, by upload.
 }
 
 func (i *sqlRunItr) Value() (ben.Run, string, time.Time) {
 	var (
-		r ben.Run
-		s string
-		t time.Time
+		r       ben.Run
+		s       string
+		t       time.Time
+		samples sql.NullInt64
+		stats   [6]sql.NullFloat64
+		envStrs [3]sql.NullString
+		envInts [3]sql.NullInt64
 	)
 	i.scanErr = i.rows.Scan(
 		&r.Name,
@@ -198,47 +508,185 @@ func (i *sqlRunItr) Value() (ben.Run, string, time.Time) {
 		&r.Parallelism,
 		&t,
 		&s,
+		&i.upload,
+		&samples,
+		&stats[0],
+		&stats[1],
+		&stats[2],
+		&stats[3],
+		&stats[4],
+		&stats[5],
+		&envStrs[0],
+		&envInts[0],
+		&envInts[1],
+		&envInts[2],
+		&envStrs[1],
+		&envStrs[2],
 	)
+	// Runs archived before the RunStats table was introduced have no
+	// stats.
+	i.stats = Stats{
+		Samples: int(samples.Int64),
+		Min:     stats[0].Float64,
+		Median:  stats[1].Float64,
+		Mean:    stats[2].Float64,
+		StdDev:  stats[3].Float64,
+		CILow:   stats[4].Float64,
+		CIHigh:  stats[5].Float64,
+	}
+	// Uploads archived before the UploadEnvironment table was introduced
+	// have no environment.
+	i.env = ben.Environment{
+		GoVersion:   envStrs[0].String,
+		GoMaxProcs:  uint32(envInts[0].Int64),
+		Cores:       uint32(envInts[1].Int64),
+		MemoryBytes: uint64(envInts[2].Int64),
+		Kernel:      envStrs[1].String,
+		CpuGovernor: envStrs[2].String,
+		Tags:        i.tags[i.upload],
+	}
+	r.Metrics = i.metrics[i.upload]
 	return r, s, t
 }
 
+func (i *sqlRunItr) UploadID() string             { return fmt.Sprintf("%x", i.upload) }
+func (i *sqlRunItr) Stats() Stats                 { return i.stats }
+func (i *sqlRunItr) Environment() ben.Environment { return i.env }
+
 func (s *sqlStore) Benchmarks(query *Query) BenchmarkIterator {
 	cpuMHz, err := strconv.Atoi(query.CPU)
 	if err != nil {
 		cpuMHz = -1
 	}
-	rows, err := s.searchBenchmarks.Query(query.Name, query.OS, query.OS, query.CPU, query.CPU, cpuMHz, query.Uploader, query.Label)
+	args := []interface{}{query.Name, query.OS, query.OS, query.CPU, query.CPU, cpuMHz, query.Uploader, query.Label}
+	var rows *sql.Rows
+	if len(query.Filters) == 0 {
+		rows, err = s.searchBenchmarks.Query(args...)
+	} else {
+		var c sqlCompiler
+		for _, f := range query.Filters {
+			c.WriteString("AND ")
+			c.compile(f)
+			c.WriteString("\n")
+		}
+		stmt := strings.Replace(searchBenchmarksSQL, filtersMarker, c.String(), 1)
+		rows, err = s.db.Query(s.tweakSQL(stmt), append(args, c.args...)...)
+	}
 	if err != nil {
 		return &nullBmItr{nullItr: nullItr{err}}
 	}
-	return &sqlBmItr{sqlItr: sqlItr{rows: rows}, selectRuns: s.selectRunsByBenchmark}
+	return &sqlBmItr{sqlItr: sqlItr{rows: rows}, store: s}
 }
 
 func (s *sqlStore) Runs(id string) (Benchmark, RunIterator) {
 	key, err := strconv.ParseInt(id, 16, 64)
 	if err != nil {
-		return Benchmark{}, &nullRunsItr{nullItr{err}}
+		// No benchmark has an ID that is not a hexadecimal number.
+		return Benchmark{}, &nullRunsItr{nullItr{ErrNotFound}}
+	}
+	return s.runs(s.describeBenchmark.QueryRow(key))
+}
+
+func (s *sqlStore) Lookup(scenario ben.Scenario, uploader, name string) (Benchmark, RunIterator) {
+	bm, itr := s.runs(s.lookupBenchmark.QueryRow(
+		scenario.Cpu.Architecture,
+		scenario.Cpu.Description,
+		scenario.Cpu.ClockSpeedMhz,
+		scenario.Os.Name,
+		scenario.Os.Version,
+		uploader,
+		scenario.Label,
+		name))
+	if itr.Err() == ErrNotFound {
+		return Benchmark{}, &nullRunsItr{}
 	}
-	row := s.describeBenchmark.QueryRow(key)
-	var bm Benchmark
+	return bm, itr
+}
+
+// runs returns the Benchmark described by row, which is the result of the
+// describeBenchmark or lookupBenchmark statements, and an iterator over its
+// runs.
+func (s *sqlStore) runs(row *sql.Row) (Benchmark, RunIterator) {
+	var (
+		bm  Benchmark
+		key int64
+	)
 	if err := row.Scan(
 		&key,
 		&bm.Name,
+		&bm.NanoSecsPerOp,
+		&bm.MegaBytesPerSec,
+		&bm.LastUpdate,
 		&bm.Scenario.Os.Name,
 		&bm.Scenario.Os.Version,
 		&bm.Scenario.Cpu.Architecture,
 		&bm.Scenario.Cpu.Description,
 		&bm.Scenario.Cpu.ClockSpeedMhz,
 		&bm.Uploader,
-		&bm.Scenario.Label); err != nil {
+		&bm.Scenario.Label); err == sql.ErrNoRows {
+		return bm, &nullRunsItr{nullItr{ErrNotFound}}
+	} else if err != nil {
 		return bm, &nullRunsItr{nullItr{err}}
 	}
-	bm.ID = id
-	rows, err := s.selectRunsByBenchmark.Query(key)
+	bm.ID = fmt.Sprintf("%x", key)
+	return bm, s.runsOf(key)
+}
+
+// runsOf returns an iterator over the runs of the benchmark with the given key.
+func (s *sqlStore) runsOf(key int64) RunIterator {
+	// Read all custom metrics and tags upfront rather than querying them
+	// for each run while iterating over the runs.
+	metrics := make(map[int64]map[string]float64)
+	rows, err := s.selectMetricsByBenchmark.Query(key)
 	if err != nil {
-		return bm, &nullRunsItr{nullItr{err}}
+		return &nullRunsItr{nullItr{err}}
 	}
-	return bm, &sqlRunItr{sqlItr: sqlItr{rows: rows}}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			upload int64
+			name   string
+			value  float64
+		)
+		if err := rows.Scan(&upload, &name, &value); err != nil {
+			return &nullRunsItr{nullItr{err}}
+		}
+		if metrics[upload] == nil {
+			metrics[upload] = make(map[string]float64)
+		}
+		metrics[upload][name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return &nullRunsItr{nullItr{err}}
+	}
+	rows.Close()
+	tags := make(map[int64]map[string]string)
+	tagRows, err := s.selectTagsByBenchmark.Query(key)
+	if err != nil {
+		return &nullRunsItr{nullItr{err}}
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var (
+			upload      int64
+			name, value string
+		)
+		if err := tagRows.Scan(&upload, &name, &value); err != nil {
+			return &nullRunsItr{nullItr{err}}
+		}
+		if tags[upload] == nil {
+			tags[upload] = make(map[string]string)
+		}
+		tags[upload][name] = value
+	}
+	if err := tagRows.Err(); err != nil {
+		return &nullRunsItr{nullItr{err}}
+	}
+	tagRows.Close()
+	if rows, err = s.selectRunsByBenchmark.Query(key); err != nil {
+		return &nullRunsItr{nullItr{err}}
+	}
+	return &sqlRunItr{sqlItr: sqlItr{rows: rows}, metrics: metrics, tags: tags}
 }
 
 func (s *sqlStore) insertAndGetID(tx *sql.Tx, insrt, slct *sql.Stmt, args ...interface{}) (int64, error) {
@@ -291,6 +739,18 @@ func (s *sqlStore) initStmts() error {
 			&s.insertSourceCode,
 			"INSERT IGNORE INTO SourceCode (ID, Description) VALUES (?, ?)",
 		},
+		{
+			&s.insertSourceCommit,
+			"INSERT IGNORE INTO SourceCommit (SourceCode, Hash) VALUES (?, ?)",
+		},
+		{
+			&s.insertEnvironment,
+			"INSERT INTO UploadEnvironment (Upload, GoVersion, GoMaxProcs, Cores, MemoryBytes, Kernel, CPUGovernor) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		},
+		{
+			&s.insertTag,
+			"INSERT INTO UploadTag (Upload, Name, Value) VALUES (?, ?, ?)",
+		},
 		{
 			&s.selectSourceCode,
 			"SELECT Description FROM SourceCode WHERE ID=?",
@@ -321,18 +781,47 @@ VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		{
 			&s.selectRunsByBenchmark,
 			`
-SELECT Benchmark.Name, Run.Iterations, Run.NanoSecsPerOp, Run.AllocsPerOp, Run.AllocedBytesPerOp, Run.MegaBytesPerSec, Run.Parallelism, Upload.Timestamp, Upload.SourceCode
+SELECT Benchmark.Name, Run.Iterations, Run.NanoSecsPerOp, Run.AllocsPerOp, Run.AllocedBytesPerOp, Run.MegaBytesPerSec, Run.Parallelism, Upload.Timestamp, Upload.SourceCode, Upload.ID,
+	RunStats.Samples, RunStats.MinNanoSecsPerOp, RunStats.MedianNanoSecsPerOp, RunStats.MeanNanoSecsPerOp, RunStats.StdDevNanoSecsPerOp, RunStats.CILowNanoSecsPerOp, RunStats.CIHighNanoSecsPerOp,
+	UploadEnvironment.GoVersion, UploadEnvironment.GoMaxProcs, UploadEnvironment.Cores, UploadEnvironment.MemoryBytes, UploadEnvironment.Kernel, UploadEnvironment.CPUGovernor
 FROM Run
 INNER JOIN Benchmark ON (Run.Benchmark = Benchmark.ID)
 INNER JOIN Upload ON (Run.Upload = Upload.ID)
+LEFT JOIN RunStats ON (RunStats.Benchmark = Run.Benchmark AND RunStats.Upload = Run.Upload)
+LEFT JOIN UploadEnvironment ON (UploadEnvironment.Upload = Run.Upload)
 WHERE Benchmark.ID = ?
-ORDER BY Upload.Timestamp DESC
+ORDER BY Upload.Timestamp DESC, Upload.ID DESC
+`,
+		},
+		{
+			&s.insertMetric,
+			"REPLACE INTO Metric (Benchmark, Upload, Name, Value) VALUES (?, ?, ?, ?)",
+		},
+		{
+			&s.insertStats,
+			`
+INSERT INTO RunStats (Benchmark, Upload, Samples, MinNanoSecsPerOp, MedianNanoSecsPerOp, MeanNanoSecsPerOp, StdDevNanoSecsPerOp, CILowNanoSecsPerOp, CIHighNanoSecsPerOp, MedianMegaBytesPerSec)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `,
 		},
+		{
+			&s.deleteStats,
+			"DELETE FROM RunStats WHERE Benchmark = ? AND Upload = ?",
+		},
+		{
+			&s.selectMetricsByBenchmark,
+			"SELECT Upload, Name, Value FROM Metric WHERE Benchmark = ?",
+		},
+		{
+			&s.selectTagsByBenchmark,
+			"SELECT DISTINCT UploadTag.Upload, UploadTag.Name, UploadTag.Value FROM UploadTag INNER JOIN Run ON (Run.Upload = UploadTag.Upload) WHERE Run.Benchmark = ?",
+		},
 		{
 			&s.searchBenchmarks,
-			// Full text search using LIKE CONCAT('%',?,'%')
-			// This can't be good!
+			searchBenchmarksSQL,
+		},
+		{
+			&s.describeBenchmark,
 			`
 SELECT
 	Benchmark.ID,
@@ -345,26 +834,24 @@ SELECT
 	CPU.Architecture,
 	CPU.Description,
 	CPU.ClockSpeedMHz,
-	Scenario.Uploader, 
+	Scenario.Uploader,
 	Scenario.Label
 FROM Benchmark
 INNER JOIN Scenario ON (Benchmark.Scenario = Scenario.ID)
 INNER JOIN OS ON (Scenario.OS = OS.ID)
 INNER JOIN CPU ON (Scenario.CPU = CPU.ID)
-WHERE Benchmark.Name LIKE CONCAT('%',?,'%')
-AND (OS.Name LIKE CONCAT('%',LOWER(?),'%') OR OS.Version LIKE CONCAT('%',LOWER(?),'%'))
-AND (CPU.Architecture LIKE CONCAT('%',LOWER(?),'%') OR CPU.Description LIKE CONCAT('%',LOWER(?),'%') OR CPU.ClockSpeedMHz = ?)
-AND Scenario.Uploader LIKE CONCAT('%',LOWER(?),'%')
-AND Scenario.Label LIKE CONCAT('%',LOWER(?),'%')
-ORDER BY Benchmark.LastUpdate DESC
+WHERE Benchmark.ID = ?
 `,
 		},
 		{
-			&s.describeBenchmark,
+			&s.lookupBenchmark,
 			`
 SELECT
 	Benchmark.ID,
 	Benchmark.Name,
+	Benchmark.NanoSecsPerOp,
+	Benchmark.MegaBytesPerSec,
+	Benchmark.LastUpdate,
 	OS.Name,
 	OS.Version,
 	CPU.Architecture,
@@ -376,129 +863,87 @@ FROM Benchmark
 INNER JOIN Scenario ON (Benchmark.Scenario = Scenario.ID)
 INNER JOIN OS ON (Scenario.OS = OS.ID)
 INNER JOIN CPU ON (Scenario.CPU = CPU.ID)
-WHERE Benchmark.ID = ?
+WHERE CPU.Architecture = LOWER(?) AND CPU.Description = LOWER(?) AND CPU.ClockSpeedMHz = ?
+AND OS.Name = LOWER(?) AND OS.Version = LOWER(?)
+AND Scenario.Uploader = LOWER(?) AND Scenario.Label = LOWER(?)
+AND Benchmark.Name = ?
 `,
 		},
-	}
-	for _, stmt := range stmts {
-		var err error
-		sql := s.tweakSQL(stmt.sql)
-		if *stmt.stmt, err = s.db.Prepare(sql); err != nil {
-			return fmt.Errorf("failed to prepare [%s]: %v", strings.Join(strings.Fields(sql), " "), err)
-		}
-	}
-	return nil
-}
-
-func (s *sqlStore) initDB() error {
-	if s.driver == driverSqlite3 {
-		// https://www.sqlite.org/foreignkeys.html#fk_enable
-		if _, err := s.db.Exec("PRAGMA foreign_keys=on"); err != nil {
-			return err
-		}
-	}
-	// One might have considered using a single table for all Scenario
-	// related information with a UNIQUE constraint on all the fields
-	// (e.g., UNIQUE(CPUArchitecture, CPUDescription, CPUMHz, OSName, OSVersion, ...))
-	//
-	// However, in MySQL (and Google Cloud SQL) there are limits on the
-	// size of an index key (and the UNIQUE constraint implies an index
-	// over all the included fields). For example, in Google Cloud SQL,
-	// the limit is 3072 bytes, which is easily met by 6 VARCHAR(255)
-	// columns (since VARCHAR(x) consumes 3x bytes in the index key).
-	//
-	// Instead, normalize the bejesus about of the fields and create
-	// multiple tables (at the cost of additional SELECT statements
-	// to find out IDs of previously inserted values).
-	tables := []struct{ Name, Schema string }{
-		{
-			"CPU",
+		{
+			&s.selectRunsBefore,
 			`
-ID            INTEGER PRIMARY KEY AUTO_INCREMENT,
-Architecture  VARCHAR(255),
-Description   VARCHAR(255),
-ClockSpeedMHz INTEGER,
-
-UNIQUE(Architecture, Description, ClockSpeedMHz)
+SELECT Run.Benchmark, Run.Upload, Upload.Timestamp, Run.NanoSecsPerOp
+FROM Run
+INNER JOIN Upload ON (Run.Upload = Upload.ID)
+WHERE TIMESTAMP(Upload.Timestamp) < TIMESTAMP(?)
 `,
 		},
 		{
-			"OS", `
-ID      INTEGER PRIMARY KEY AUTO_INCREMENT,
-Name    VARCHAR(255),
-Version VARCHAR(255),
-
-UNIQUE(Name, Version)
-`,
+			&s.deleteMetrics,
+			"DELETE FROM Metric WHERE Benchmark = ? AND Upload = ?",
 		},
 		{
-			"Scenario", `
-ID       INTEGER PRIMARY KEY AUTO_INCREMENT,
-CPU      INTEGER,
-OS       INTEGER,
-Uploader VARCHAR(255),
-Label    VARCHAR(255),
-
-UNIQUE(CPU, OS, Uploader, Label),
-
-FOREIGN KEY(CPU) REFERENCES CPU(ID),
-FOREIGN KEY(OS)  REFERENCES OS(ID)
-`,
+			&s.deleteRuns,
+			"DELETE FROM Run WHERE Benchmark = ? AND Upload = ?",
 		},
 		{
-			"SourceCode", `
-ID          CHAR(64) PRIMARY KEY,
-Description TEXT
+			&s.selectLatestRun,
+			`
+SELECT COALESCE(RunStats.MedianNanoSecsPerOp, Run.NanoSecsPerOp), COALESCE(RunStats.MedianMegaBytesPerSec, Run.MegaBytesPerSec), Upload.Timestamp
+FROM Run
+INNER JOIN Upload ON (Run.Upload = Upload.ID)
+LEFT JOIN RunStats ON (RunStats.Benchmark = Run.Benchmark AND RunStats.Upload = Run.Upload)
+WHERE Run.Benchmark = ?
+ORDER BY Upload.Timestamp DESC
+LIMIT 1
 `,
 		},
 		{
-			"Upload", `
-ID               INTEGER PRIMARY KEY AUTO_INCREMENT,
-Timestamp        DATETIME,
-SourceCode       CHAR(64),
-
-FOREIGN KEY(SourceCode) REFERENCES SourceCode(ID)
-`,
+			&s.deleteBenchmark,
+			"DELETE FROM Benchmark WHERE ID = ?",
 		},
 		{
-			"Benchmark", `
-ID              INTEGER PRIMARY KEY AUTO_INCREMENT,
-Scenario        INTEGER,
-Name            VARCHAR(255),
-NanoSecsPerOp   DOUBLE,
-MegaBytesPerSec DOUBLE,
-LastUpdate      DATETIME,
-
-UNIQUE(Scenario, Name),
-
-FOREIGN KEY(Scenario) REFERENCES Scenario(ID)
-`,
+			&s.deleteUnusedEnvironments,
+			"DELETE FROM UploadEnvironment WHERE NOT EXISTS (SELECT 1 FROM Run WHERE Run.Upload = UploadEnvironment.Upload)",
 		},
 		{
-			"Run", `
-Benchmark         INTEGER,
-Upload            INTEGER,
-Iterations        INTEGER,
-NanoSecsPerOp     DOUBLE,
-AllocsPerOp       INTEGER,
-AllocedBytesPerOp INTEGER,
-MegaBytesPerSec   DOUBLE,
-Parallelism       INTEGER,
-
-FOREIGN KEY(Benchmark) REFERENCES Benchmark(ID),
-FOREIGN KEY(Upload) REFERENCES Upload(ID)
-`,
+			&s.deleteUnusedTags,
+			"DELETE FROM UploadTag WHERE NOT EXISTS (SELECT 1 FROM Run WHERE Run.Upload = UploadTag.Upload)",
+		},
+		{
+			&s.deleteUnusedUploads,
+			"DELETE FROM Upload WHERE NOT EXISTS (SELECT 1 FROM Run WHERE Run.Upload = Upload.ID)",
+		},
+		{
+			&s.deleteUnusedSourceCommits,
+			"DELETE FROM SourceCommit WHERE NOT EXISTS (SELECT 1 FROM Upload WHERE Upload.SourceCode = SourceCommit.SourceCode)",
+		},
+		{
+			&s.deleteUnusedSourceCode,
+			"DELETE FROM SourceCode WHERE NOT EXISTS (SELECT 1 FROM Upload WHERE Upload.SourceCode = SourceCode.ID)",
 		},
 	}
-	for _, t := range tables {
-		stmt := s.tweakSQL(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", t.Name, t.Schema))
-		if _, err := s.db.Exec(stmt); err != nil {
-			return fmt.Errorf("failed to create %v table (SQL: [%s]): %v", t.Name, strings.Join(strings.Fields(stmt), " "), err)
+	for _, stmt := range stmts {
+		var err error
+		sql := s.tweakSQL(stmt.sql)
+		if *stmt.stmt, err = s.db.Prepare(sql); err != nil {
+			return fmt.Errorf("failed to prepare [%s]: %v", strings.Join(strings.Fields(sql), " "), err)
 		}
 	}
 	return nil
 }
 
+func (s *sqlStore) initDB() error {
+	if s.driver == driverSqlite3 {
+		// https://www.sqlite.org/foreignkeys.html#fk_enable
+		if _, err := s.db.Exec("PRAGMA foreign_keys=on"); err != nil {
+			return err
+		}
+	}
+	_, err := dbutil.Migrate(s.db, SQLStoreSchema, SQLMigrations(s.driver))
+	return err
+}
+
 func tagerr(tag string, err error) error {
 	return fmt.Errorf("[%s]: %v", tag, err)
 }
@@ -0,0 +1,191 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"math"
+	"sort"
+)
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// newStats returns the Stats of samples.
+func newStats(samples []float64) Stats {
+	if len(samples) == 0 {
+		return Stats{}
+	}
+	s := Stats{
+		Samples: len(samples),
+		Min:     samples[0],
+		Median:  median(samples),
+		Mean:    mean(samples),
+	}
+	for _, v := range samples {
+		s.Min = math.Min(s.Min, v)
+	}
+	s.CILow, s.CIHigh = s.Mean, s.Mean
+	if n := len(samples); n > 1 {
+		var variance float64
+		for _, v := range samples {
+			variance += (v - s.Mean) * (v - s.Mean)
+		}
+		s.StdDev = math.Sqrt(variance / float64(n-1))
+		delta := tQuantile(n-1) * s.StdDev / math.Sqrt(float64(n))
+		s.CILow, s.CIHigh = s.Mean-delta, s.Mean+delta
+	}
+	return s
+}
+
+// tQuantiles are the 0.975 quantiles of Student's t-distribution with 1 to 30
+// degrees of freedom.
+var tQuantiles = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tQuantile returns the 0.975 quantile of Student's t-distribution with df
+// degrees of freedom, i.e., the multiple of the standard error of a mean
+// that bounds its two-sided 95% confidence interval.
+func tQuantile(df int) float64 {
+	if df <= len(tQuantiles) {
+		return tQuantiles[df-1]
+	}
+	return 1.96
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// pValue returns the one-sided probability of observing a value at least as
+// large as x in a normal distribution with the mean and standard deviation of
+// samples.
+func pValue(x float64, samples []float64) float64 {
+	var (
+		m        = mean(samples)
+		variance float64
+	)
+	for _, s := range samples {
+		variance += (s - m) * (s - m)
+	}
+	stddev := math.Sqrt(variance / float64(len(samples)-1))
+	if stddev == 0 {
+		if x > m {
+			return 0
+		}
+		return 1
+	}
+	return math.Erfc((x-m)/stddev/math.Sqrt2) / 2
+}
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U test of the
+// hypothesis that x and y are drawn from the same distribution. It is the test
+// used by benchstat (https://godoc.org/golang.org/x/perf/cmd/benchstat).
+//
+// The exact distribution of U is used for small samples without ties and a
+// normal approximation otherwise.
+func mannWhitneyU(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	type sample struct {
+		value float64
+		first bool
+	}
+	all := make([]sample, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, sample{v, true})
+	}
+	for _, v := range y {
+		all = append(all, sample{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+	// Assign ranks (starting at 1), averaging the ranks of ties.
+	var (
+		rank1 float64 // Sum of the ranks of x.
+		ties  float64 // Sum of t^3-t over groups of t tied values.
+	)
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].first {
+				rank1 += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	u := rank1 - float64(n1*(n1+1))/2
+	if ties == 0 && n1 <= 50 && n2 <= 50 {
+		dist := uDist(n1, n2)
+		var total, below, above float64
+		for k, c := range dist {
+			total += c
+			if float64(k) <= u {
+				below += c
+			}
+			if float64(k) >= u {
+				above += c
+			}
+		}
+		return math.Min(1, 2*math.Min(below, above)/total)
+	}
+	n := float64(n1 + n2)
+	mu := float64(n1*n2) / 2
+	sigma := math.Sqrt(float64(n1*n2) / 12 * ((n + 1) - ties/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := math.Max(0, math.Abs(u-mu)-0.5) / sigma
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// uDist returns the number of ways in which each value of the Mann-Whitney U
+// statistic can be obtained for samples of sizes n1 and n2 without ties.
+func uDist(n1, n2 int) []float64 {
+	// f(m, n)[u] = f(m-1, n)[u-n] + f(m, n-1)[u], with f(0, n) = f(m, 0) = [1].
+	prev := make([][]float64, n2+1)
+	for n := range prev {
+		prev[n] = []float64{1}
+	}
+	for m := 1; m <= n1; m++ {
+		cur := make([][]float64, n2+1)
+		cur[0] = []float64{1}
+		for n := 1; n <= n2; n++ {
+			d := make([]float64, m*n+1)
+			for k, c := range prev[n] {
+				d[k+n] += c
+			}
+			for k, c := range cur[n-1] {
+				d[k] += c
+			}
+			cur[n] = d
+		}
+		prev = cur
+	}
+	return prev[n2]
+}
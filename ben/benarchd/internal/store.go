@@ -5,20 +5,59 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
-	"unicode"
 
 	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
 	"v.io/v23/context"
 )
 
+// ErrNotFound is the error returned by Store methods when the requested
+// benchmark or source code has not been archived.
+var ErrNotFound = errors.New("not found")
+
 type Store interface {
+	// Save archives runs, all of which must have finite values (see
+	// checkRuns).
 	Save(ctx *context.T, scenario ben.Scenario, code ben.SourceCode, uploader string, uploadTime time.Time, runs []ben.Run) error
 	Benchmarks(query *Query) BenchmarkIterator
 	Runs(benchmarkID string) (Benchmark, RunIterator)
+	// Lookup is like Runs, but identifies the Benchmark by its name,
+	// scenario and uploader. The returned iterator is empty if no runs of
+	// the benchmark have been archived.
+	Lookup(scenario ben.Scenario, uploader, name string) (Benchmark, RunIterator)
 	DescribeSource(id string) (ben.SourceCode, error)
+	// Delete removes the runs of the uploads matching selector and
+	// returns the number of uploads removed.
+	Delete(selector archive.UploadSelector) (int, error)
+	// Downsample keeps a single run per interval of each benchmark among
+	// the runs uploaded before the given time, the one with the median
+	// NanoSecsPerOp, and returns the number of runs removed.
+	Downsample(before time.Time, interval time.Duration) (int, error)
+}
+
+// checkRuns returns an error if runs have values that are not finite numbers,
+// which can be neither stored in SQL databases nor encoded in JSON.
+func checkRuns(runs []ben.Run) error {
+	finite := func(f float64) bool { return !math.IsNaN(f) && !math.IsInf(f, 0) }
+	for _, r := range runs {
+		if !finite(r.NanoSecsPerOp) {
+			return fmt.Errorf("invalid ns/op %v of %v", r.NanoSecsPerOp, r.Name)
+		}
+		if !finite(r.MegaBytesPerSec) {
+			return fmt.Errorf("invalid MB/s %v of %v", r.MegaBytesPerSec, r.Name)
+		}
+		for metric, v := range r.Metrics {
+			if !finite(v) {
+				return fmt.Errorf("invalid %v %v of %v", metric, v, r.Name)
+			}
+		}
+	}
+	return nil
 }
 
 type Query struct {
@@ -27,6 +66,8 @@ type Query struct {
 	OS       string
 	Uploader string
 	Label    string
+	// Filters are further conditions that the benchmarks must satisfy.
+	Filters []Expr
 }
 
 // Benchmark identifies a particular (Benchmark, Scenario, Uploader) tuple.
@@ -65,107 +106,227 @@ type BenchmarkIterator interface {
 type RunIterator interface {
 	Iterator
 	Value() (run ben.Run, sourceCodeID string, uploadTime time.Time)
+	// UploadID returns the ID of the upload of the run last returned by
+	// Value.
+	UploadID() string
+	// Stats returns the statistics of the samples of the benchmark in the
+	// upload of the run last returned by Value, i.e., of all the runs of
+	// the benchmark in that upload. Stats().Samples is zero if they are
+	// unknown.
+	Stats() Stats
+	// Environment returns the environment (see ben.Scenario.Env) recorded
+	// with the upload of the run last returned by Value.
+	Environment() ben.Environment
+}
+
+// Stats summarizes the NanoSecsPerOp of multiple samples of a benchmark (e.g.,
+// from "go test -bench -count=N").
+type Stats struct {
+	Samples                   int
+	Min, Median, Mean, StdDev float64
+	// CILow and CIHigh bound the 95% confidence interval of Mean, and are
+	// equal to it if there is a single sample.
+	CILow, CIHigh float64
+}
+
+// NoisePercent returns the half-width of the confidence interval as a
+// percentage of Mean.
+func (s Stats) NoisePercent() float64 {
+	if s.Mean == 0 {
+		return 0
+	}
+	return (s.CIHigh - s.CILow) / 2 / s.Mean * 100
 }
 
 func (q *Query) String() string {
 	var fields []string
-	quot := func(in string) string {
-		if strings.ContainsRune(in, ' ') {
-			return fmt.Sprintf("%q", in)
-		}
-		return in
-	}
 	if len(q.Name) > 0 {
-		fields = append(fields, quot(q.Name))
+		fields = append(fields, quote(q.Name))
 	}
 	if len(q.CPU) > 0 {
-		fields = append(fields, "cpu:"+quot(q.CPU))
+		fields = append(fields, "cpu:"+quote(q.CPU))
 	}
 	if len(q.OS) > 0 {
-		fields = append(fields, "os:"+quot(q.OS))
+		fields = append(fields, "os:"+quote(q.OS))
 	}
 	if len(q.Uploader) > 0 {
-		fields = append(fields, "uploader:"+quot(q.Uploader))
+		fields = append(fields, "uploader:"+quote(q.Uploader))
 	}
 	if len(q.Label) > 0 {
-		fields = append(fields, "label:"+quot(q.Label))
+		fields = append(fields, "label:"+quote(q.Label))
+	}
+	for _, f := range q.Filters {
+		fields = append(fields, parenthesize(f))
 	}
 	return strings.Join(fields, " ")
 }
 
 // ParseQuery converts a query string into a structured Query object.
 //
-// The query language supports setting each field in the Query object at most
-// once and the query will be an AND of all terms. A more expressive query
-// language is left as an exercise to a future enthusiast.
+// A query is a sequence of terms, all of which must match. A term is one of:
+//
+//	<name>              Benchmarks whose name contains <name>. Names containing
+//	                    '*' or '?' are globs matching the entire name, and
+//	                    names of the form /<regexp>/ are regular expressions.
+//	cpu:<cpu>           Benchmarks run on a matching CPU.
+//	os:<os>             Benchmarks run on a matching operating system.
+//	uploader:<who>      Benchmarks uploaded by a matching uploader.
+//	label:<label>       Benchmarks with a matching label.
+//	source:<id>         Benchmarks with runs of source code whose ID starts with <id>.
+//	commit:<hash>       Benchmarks with runs of source code with a commit (see
+//	                    ben.SourceCode.Commits) whose hash starts with <hash>.
+//	metric:<unit>       Benchmarks with runs reporting a custom metric (see
+//	                    ben.Run.Metrics) in <unit>, e.g. metric:p99-ns.
+//	go:<version>        Benchmarks with runs built by a matching Go toolchain,
+//	                    e.g. go:1.22.
+//	gomaxprocs:<n>      Benchmarks with runs with GOMAXPROCS=<n>.
+//	cores:<n>           Benchmarks with runs on machines with <n> CPU cores.
+//	kernel:<version>    Benchmarks with runs on a matching kernel.
+//	governor:<name>     Benchmarks with runs with a matching CPU governor.
+//	tag:<name>=<value>  Benchmarks with runs tagged by the uploader with <name>
+//	                    and <value> (or any value if =<value> is omitted),
+//	                    e.g. tag:ci=nightly.
+//	since:<time>        Benchmarks with runs uploaded at or after <time>.
+//	until:<time>        Benchmarks with runs uploaded at or before <time>.
+//	<metric><op><value> Benchmarks whose latest value of <metric> (ns, allocs,
+//	                    bytes or mbps) compares to <value> using <op> (<, <=,
+//	                    >, >=, = or !=), e.g. ns>1000 or ns<=1.5ms. Custom
+//	                    metrics are compared with metric:<unit><op><value>,
+//	                    e.g. metric:p99-ns>1e6.
+//
+// Times are dates (2006-01-02) or RFC 3339 times. Terms can be combined with
+// OR (or |), negated by prefixing them with - (or NOT), and grouped with
+// parentheses. Values containing spaces or parentheses must be quoted.
+//
+// The plain cpu:, os:, uploader:, label: and name terms of the top-level
+// conjunction are stored in the corresponding fields of the Query, and each of
+// them can appear at most once. All other terms are stored in Filters.
 func ParseQuery(query string) (*Query, error) {
-	tokens, err := split(query)
+	expr, err := parseExpr(query)
 	if err != nil {
 		return nil, err
 	}
+	var terms []Expr
+	switch e := expr.(type) {
+	case nil:
+	case And:
+		terms = e
+	default:
+		terms = []Expr{e}
+	}
 	var ret Query
-	for _, f := range tokens {
-		var err error
-		var set bool
-		set = set || trySetField(&ret.CPU, &err, "cpu:", f)
-		set = set || trySetField(&ret.OS, &err, "os:", f)
-		set = set || trySetField(&ret.Uploader, &err, "uploader:", f)
-		set = set || trySetField(&ret.Label, &err, "label:", f)
-		if !set {
-			trySetField(&ret.Name, &err, "", f)
+	for _, e := range terms {
+		t, ok := e.(Term)
+		if !ok || t.Pattern != Substring {
+			ret.Filters = append(ret.Filters, e)
+			continue
 		}
-		if err != nil {
-			return nil, err
+		var dst *string
+		switch t.Field {
+		case "name":
+			dst = &ret.Name
+		case "cpu":
+			dst = &ret.CPU
+		case "os":
+			dst = &ret.OS
+		case "uploader":
+			dst = &ret.Uploader
+		case "label":
+			dst = &ret.Label
+		default:
+			ret.Filters = append(ret.Filters, e)
+			continue
 		}
+		if len(*dst) > 0 {
+			if t.Field == "name" {
+				return nil, fmt.Errorf("name already set, use OR or a pattern to match multiple names")
+			}
+			return nil, fmt.Errorf("operator %q already set, use OR to match multiple values", t.Field+":")
+		}
+		*dst = t.Value
 	}
 	return &ret, nil
 }
 
-func trySetField(dst *string, err *error, prefix, value string) bool {
-	if *err != nil {
-		return false
-	}
-	if len(value) < len(prefix) || strings.ToLower(value[:len(prefix)]) != prefix {
-		return false
+// Samples are the runs of a benchmark in an upload.
+type Samples struct {
+	Runs         []ben.Run
+	SourceCodeID string
+	UploadTime   time.Time
+	UploadID     string
+	Stats        Stats
+	Env          ben.Environment
+}
+
+// Run returns a run whose results are the medians of those of the samples.
+func (s Samples) Run() ben.Run {
+	return medianRun(s.Runs)
+}
+
+// bySamples calls fn with the runs returned by itr grouped by upload, until it
+// returns false, and returns the error of itr.
+func bySamples(itr RunIterator, fn func(Samples) bool) error {
+	var (
+		cur  Samples
+		done bool
+	)
+	flush := func() {
+		if len(cur.Runs) == 0 || done {
+			return
+		}
+		if cur.Stats.Samples == 0 {
+			ns := make([]float64, len(cur.Runs))
+			for i, r := range cur.Runs {
+				ns[i] = r.NanoSecsPerOp
+			}
+			cur.Stats = newStats(ns)
+		}
+		done = !fn(cur)
 	}
-	if len(*dst) > 0 {
-		*err = fmt.Errorf("operator %q already set", prefix)
+	for !done && itr.Advance() {
+		run, code, uploaded := itr.Value()
+		if id := itr.UploadID(); len(cur.Runs) == 0 || id != cur.UploadID {
+			flush()
+			cur = Samples{SourceCodeID: code, UploadTime: uploaded, UploadID: id, Stats: itr.Stats(), Env: itr.Environment()}
+		}
+		cur.Runs = append(cur.Runs, run)
 	}
-	*dst = strings.TrimSuffix(strings.TrimPrefix(value[len(prefix):], `"`), `"`)
-	return true
+	flush()
+	return itr.Err()
 }
 
-// split is liked strings.Split except that it doesn't break quoted strings.
-func split(input string) ([]string, error) {
-	if len(input) == 0 {
-		return nil, nil
+// medianRun returns a run of the benchmark of samples whose results are the
+// medians of those of samples.
+func medianRun(samples []ben.Run) ben.Run {
+	if len(samples) == 1 {
+		return samples[0]
 	}
 	var (
-		tokens []string
-		start  int
-		inq    bool
-		commit = func(end int) {
-			if end > start {
-				tokens = append(tokens, input[start:end])
-			}
-		}
+		ns, allocs, bytes, mbps, iterations []float64
+		metrics                             = make(map[string][]float64)
 	)
-	for idx, r := range input {
-		switch {
-		case r == '"' && !inq:
-			inq = true
-		case r == '"' && inq:
-			commit(idx + 1)
-			start = idx + 1
-			inq = false
-		case unicode.IsSpace(r) && !inq:
-			commit(idx)
-			start = idx + 1
+	for _, r := range samples {
+		ns = append(ns, r.NanoSecsPerOp)
+		allocs = append(allocs, float64(r.AllocsPerOp))
+		bytes = append(bytes, float64(r.AllocedBytesPerOp))
+		mbps = append(mbps, r.MegaBytesPerSec)
+		iterations = append(iterations, float64(r.Iterations))
+		for unit, value := range r.Metrics {
+			metrics[unit] = append(metrics[unit], value)
 		}
 	}
-	if inq {
-		return tokens, fmt.Errorf("unterminated quote")
+	ret := samples[0]
+	ret.NanoSecsPerOp = median(ns)
+	ret.AllocsPerOp = uint64(median(allocs))
+	ret.AllocedBytesPerOp = uint64(median(bytes))
+	ret.MegaBytesPerSec = median(mbps)
+	ret.Iterations = uint64(median(iterations))
+	ret.Metrics = nil
+	if len(metrics) > 0 {
+		ret.Metrics = make(map[string]float64)
+		for unit, values := range metrics {
+			ret.Metrics[unit] = median(values)
+		}
 	}
-	commit(len(input))
-	return tokens, nil
+	return ret
 }
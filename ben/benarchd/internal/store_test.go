@@ -5,10 +5,118 @@
 package internal
 
 import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"github.com/vanadium/services/internal/dbutil"
 )
 
+// newSQLiteStore returns a Store backed by an in-memory sqlite3 database and a
+// function to release it.
+func newSQLiteStore(t *testing.T) (Store, func()) {
+	db, err := sql.Open(SQLite3Driver, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each connection would have its own in-memory database.
+	db.SetMaxOpenConns(1)
+	store, err := NewSQLStore("sqlite3", db)
+	if err != nil {
+		db.Close()
+		t.Fatal(err)
+	}
+	return store, func() { db.Close() }
+}
+
+// newFileStore returns a Store backed by a journal in a temporary file and a
+// function that checks that the journal replays to the same results and
+// removes it.
+func newFileStore(t *testing.T) (Store, func()) {
+	dir, err := ioutil.TempDir("", "benarchd-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(dir, "journal")
+	store, err := NewFileStore(filename)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return store, func() {
+		defer os.RemoveAll(dir)
+		replayed, err := NewFileStore(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := dumpStore(t, replayed), dumpStore(t, store); got != want {
+			t.Errorf("replayed journal:\n%s\nwant:\n%s", got, want)
+		}
+	}
+}
+
+// storeImpls are the Store implementations that the tests using forEachStore
+// run against, i.e., which must have the same semantics.
+var storeImpls = []struct {
+	name string
+	new  func(t *testing.T) (Store, func())
+}{
+	{"sqlite3", newSQLiteStore},
+	{"memory", func(*testing.T) (Store, func()) { return NewMemStore(), func() {} }},
+	{"file", newFileStore},
+}
+
+// forEachStore runs test as a subtest against a new, empty store of each of
+// storeImpls.
+func forEachStore(t *testing.T, test func(t *testing.T, store Store)) {
+	for _, impl := range storeImpls {
+		t.Run(impl.name, func(t *testing.T) {
+			store, cleanup := impl.new(t)
+			defer cleanup()
+			test(t, store)
+		})
+	}
+}
+
+// dumpStore returns a description of all the benchmarks and runs in store.
+func dumpStore(t *testing.T, store Store) string {
+	var (
+		b   strings.Builder
+		ids []string
+	)
+	bmItr := store.Benchmarks(&Query{})
+	for bmItr.Advance() {
+		ids = append(ids, bmItr.Value().ID)
+	}
+	if err := bmItr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	bmItr.Close()
+	for _, id := range ids {
+		bm, itr := store.Runs(id)
+		fmt.Fprintf(&b, "%v %v %+v %v %v %v %v\n", bm.ID, bm.Name, bm.Scenario, bm.Uploader, bm.NanoSecsPerOp, bm.MegaBytesPerSec, bm.LastUpdate.UTC())
+		for itr.Advance() {
+			run, code, uploaded := itr.Value()
+			fmt.Fprintf(&b, "\t%+v %v %v %v %+v %v\n", run, code, uploaded.UTC(), itr.UploadID(), itr.Stats(), itr.Environment())
+		}
+		if err := itr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		itr.Close()
+	}
+	return b.String()
+}
+
 func TestParseQueryAndString(t *testing.T) {
 	for _, test := range []struct {
 		q    string
@@ -58,3 +166,534 @@ func TestParseQueryErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestParseQueryFilters(t *testing.T) {
+	for _, test := range []struct {
+		q    string
+		want Query
+	}{
+		{"os:linux OR os:darwin", Query{Filters: []Expr{Or{Term{Field: "os", Value: "linux"}, Term{Field: "os", Value: "darwin"}}}}},
+		{"os:linux | os:darwin", Query{Filters: []Expr{Or{Term{Field: "os", Value: "linux"}, Term{Field: "os", Value: "darwin"}}}}},
+		{"Verify -cpu:arm", Query{Name: "Verify", Filters: []Expr{Not{Term{Field: "cpu", Value: "arm"}}}}},
+		{"Verify NOT (cpu:arm OR label:slow)", Query{Name: "Verify", Filters: []Expr{Not{Or{Term{Field: "cpu", Value: "arm"}, Term{Field: "label", Value: "slow"}}}}}},
+		{"Benchmark*Sign?", Query{Filters: []Expr{Term{Field: "name", Value: "Benchmark*Sign?", Pattern: Glob}}}},
+		{"/^Benchmark(Sign|Verify)$/ os:linux", Query{OS: "linux", Filters: []Expr{Term{Field: "name", Value: "^Benchmark(Sign|Verify)$", Pattern: Regexp}}}},
+		{"source:abc123", Query{Filters: []Expr{Term{Field: "source", Value: "abc123"}}}},
+		{"commit:9c1e", Query{Filters: []Expr{Term{Field: "commit", Value: "9c1e"}}}},
+		{"go:1.22 tag:ci=nightly", Query{Filters: []Expr{Term{Field: "go", Value: "1.22"}, Term{Field: "tag", Value: "ci=nightly"}}}},
+		{"ns>1000 allocs<=2 bytes!=0 mbps>=1.5", Query{Filters: []Expr{
+			Predicate{Metric: "ns", Op: ">", Value: 1000, Text: "1000"},
+			Predicate{Metric: "allocs", Op: "<=", Value: 2, Text: "2"},
+			Predicate{Metric: "bytes", Op: "!=", Value: 0, Text: "0"},
+			Predicate{Metric: "mbps", Op: ">=", Value: 1.5, Text: "1.5"},
+		}}},
+		{"ns<1.5ms", Query{Filters: []Expr{Predicate{Metric: "ns", Op: "<", Value: 1.5e6, Text: "1.5ms"}}}},
+		{"metric:p99-ns>=1e6 metric:msgs/s", Query{Filters: []Expr{
+			Predicate{Metric: "p99-ns", Custom: true, Op: ">=", Value: 1e6, Text: "1e6"},
+			Term{Field: "metric", Value: "msgs/s"},
+		}}},
+		{`metric:"p99 ns"!=0`, Query{Filters: []Expr{Predicate{Metric: "p99 ns", Custom: true, Op: "!=", Value: 0, Text: "0"}}}},
+		{"bytes=large", Query{Name: "bytes=large"}},
+		{"since:2016-02-14 Sign until:2016-02-15T10:00:00Z", Query{Name: "Sign", Filters: []Expr{TimeRange{
+			Since: time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2016, 2, 15, 10, 0, 0, 0, time.UTC),
+			Text:  "since:2016-02-14 until:2016-02-15T10:00:00Z",
+		}}}},
+		{"until:2016-02-14", Query{Filters: []Expr{TimeRange{Until: time.Date(2016, 2, 14, 23, 59, 59, 999999999, time.UTC), Text: "until:2016-02-14"}}}},
+		{`cpu:"Intel(R) Core(TM)" (Sign OR Verify)`, Query{CPU: "Intel(R) Core(TM)", Filters: []Expr{Or{Term{Field: "name", Value: "Sign"}, Term{Field: "name", Value: "Verify"}}}}},
+	} {
+		got, err := ParseQuery(test.q)
+		if err != nil {
+			t.Errorf("Failed to parse query [%v]: %v", test.q, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, test.want) {
+			t.Errorf("[%v] Got %#v, want %#v", test.q, *got, test.want)
+			continue
+		}
+		if got, err = ParseQuery(test.want.String()); err != nil {
+			t.Errorf("Failed to parse stringified query [%v]: %v", test.want.String(), err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, test.want) {
+			t.Errorf("[%v] Got %#v, want %#v", test.want.String(), *got, test.want)
+		}
+	}
+	for _, test := range []string{
+		"Verify OR",
+		"(Verify",
+		"Verify)",
+		"-",
+		`"Verify`,
+		"/(/",
+		"/unterminated",
+		"since:yesterday",
+		"metric:p99-ns>slow",
+	} {
+		if got, err := ParseQuery(test); err == nil {
+			t.Errorf("[%v]: Unexpectedly succeeded parsing into %#v", test, got)
+		}
+	}
+}
+
+func TestQueryFilters(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		linux := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}, Env: ben.Environment{
+			GoVersion:   "go1.21.5",
+			GoMaxProcs:  8,
+			Cores:       8,
+			Kernel:      "5.15.0-91-generic",
+			CpuGovernor: "powersave",
+			Tags:        map[string]string{"ci": "nightly"},
+		}}
+		// The environment does not identify benchmarks.
+		upgraded := linux
+		upgraded.Env = ben.Environment{GoVersion: "go1.22.1", GoMaxProcs: 16, Cores: 16, Tags: map[string]string{"ci": "presubmit"}}
+		darwin := ben.Scenario{Cpu: ben.Cpu{Architecture: "arm64"}, Os: ben.Os{Name: "darwin"}, Label: "laptop"}
+		day := time.Date(2016, 2, 14, 12, 0, 0, 0, time.UTC)
+		manifest := ben.Source{
+			Repository: "https://github.com/vanadium/services",
+			Commit:     "9c1e5a",
+			Projects:   []ben.Project{{Name: "core", Repository: "https://github.com/vanadium/core", Commit: "7d2f0b"}},
+		}
+		uploads := []struct {
+			scenario ben.Scenario
+			code     ben.SourceCode
+			when     time.Time
+			runs     []ben.Run
+		}{
+			{linux, "aaaa", day, []ben.Run{
+				{Name: "BenchmarkSign", NanoSecsPerOp: 2000, AllocsPerOp: 5, Metrics: map[string]float64{"p99-ns": 2500}},
+				{Name: "BenchmarkVerify", NanoSecsPerOp: 500, AllocsPerOp: 1},
+				{Name: "BenchmarkVerify_Cached", NanoSecsPerOp: 50},
+			}},
+			{darwin, "bbbb", day.AddDate(0, 0, 1), []ben.Run{
+				{Name: "BenchmarkSign", NanoSecsPerOp: 3000, AllocsPerOp: 5, MegaBytesPerSec: 10},
+			}},
+			{upgraded, manifest.SourceCode(), day.AddDate(0, 0, 2), []ben.Run{
+				{Name: "BenchmarkSign", NanoSecsPerOp: 1000, Metrics: map[string]float64{"p99-ns": 1200, "sigs/s": 1e6}},
+			}},
+		}
+		for _, u := range uploads {
+			if err := store.Save(nil, u.scenario, u.code, "alice", u.when, u.runs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, test := range []struct {
+			q    string
+			want []string
+		}{
+			{"Sign", []string{"BenchmarkSign linux", "BenchmarkSign darwin"}},
+			{"os:linux OR label:laptop", []string{"BenchmarkSign linux", "BenchmarkSign darwin", "BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"-os:linux", []string{"BenchmarkSign darwin"}},
+			{"Verify NOT Cached", []string{"BenchmarkVerify linux"}},
+			{"BenchmarkVerify*", []string{"BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"Benchmark?erify", []string{"BenchmarkVerify linux"}},
+			{"Verify_*", nil},
+			{"/^BenchmarkVerify$/ OR /Sign/ cpu:arm", []string{"BenchmarkSign darwin", "BenchmarkVerify linux"}},
+			{"source:bb", []string{"BenchmarkSign darwin"}},
+			{"source:aa Sign", []string{"BenchmarkSign linux"}},
+			{"commit:aa", []string{"BenchmarkSign linux", "BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"commit:9C1E", []string{"BenchmarkSign linux"}},
+			{"commit:7d2f", []string{"BenchmarkSign linux"}},
+			{"commit:ffff", nil},
+			// LIKE wildcards match literally, as in the memory store.
+			{"source:_", nil},
+			{"commit:%", nil},
+			{"commit:9c1e5_", nil},
+			{"kernel:5_15", nil},
+			{"Verify_", []string{"BenchmarkVerify_Cached linux"}},
+			{"go:1.22", []string{"BenchmarkSign linux"}},
+			{"go:go1.21 Verify", []string{"BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"gomaxprocs:8", []string{"BenchmarkSign linux", "BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"cores:16", []string{"BenchmarkSign linux"}},
+			{"cores:many", nil},
+			{"kernel:5.15 Cached", []string{"BenchmarkVerify_Cached linux"}},
+			{"governor:performance", nil},
+			{"tag:ci", []string{"BenchmarkSign linux", "BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"tag:CI=Presubmit", []string{"BenchmarkSign linux"}},
+			{"-tag:ci=nightly", []string{"BenchmarkSign darwin"}},
+			{"since:2016-02-15", []string{"BenchmarkSign linux", "BenchmarkSign darwin"}},
+			{"until:2016-02-14", []string{"BenchmarkVerify linux", "BenchmarkVerify_Cached linux", "BenchmarkSign linux"}},
+			{"since:2016-02-15T00:00:00Z until:2016-02-15T12:00:00Z", []string{"BenchmarkSign darwin"}},
+			{"ns>1000", []string{"BenchmarkSign darwin"}},
+			{"ns>=1µs", []string{"BenchmarkSign linux", "BenchmarkSign darwin"}},
+			{"allocs>0", []string{"BenchmarkSign darwin", "BenchmarkVerify linux"}},
+			{"mbps!=0", []string{"BenchmarkSign darwin"}},
+			{"metric:p99-ns", []string{"BenchmarkSign linux"}},
+			{"-metric:p99-ns os:linux", []string{"BenchmarkVerify linux", "BenchmarkVerify_Cached linux"}},
+			{"metric:p99-ns>2000", nil},
+			{"metric:p99-ns<2000", []string{"BenchmarkSign linux"}},
+		} {
+			query, err := ParseQuery(test.q)
+			if err != nil {
+				t.Errorf("[%v]: %v", test.q, err)
+				continue
+			}
+			itr := store.Benchmarks(query)
+			var got []string
+			for itr.Advance() {
+				bm := itr.Value()
+				got = append(got, bm.Name+" "+bm.Scenario.Os.Name)
+			}
+			if err := itr.Err(); err != nil {
+				t.Errorf("[%v]: %v", test.q, err)
+			}
+			itr.Close()
+			sort.Strings(got)
+			sort.Strings(test.want)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("[%v]: got %v, want %v", test.q, got, test.want)
+			}
+		}
+	})
+}
+
+func TestRunsAndSources(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "AMD64", ClockSpeedMhz: 2400}, Os: ben.Os{Name: "Linux", Version: "5.4"}, Label: "CI"}
+		start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+		envs := []ben.Environment{{}, {GoVersion: "go1.22.1", GoMaxProcs: 4, MemoryBytes: 1 << 34, Tags: map[string]string{"ci": "nightly"}}}
+		for i, code := range []ben.SourceCode{"commit0", "commit1"} {
+			runs := []ben.Run{{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: float64(i + 1)}}
+			s := scenario
+			s.Env = envs[i]
+			if err := store.Save(nil, s, code, "Alice", start.Add(time.Duration(i)*time.Hour), runs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Scenarios and uploaders are case-insensitive.
+		bm, itr := store.Lookup(ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64", ClockSpeedMhz: 2400}, Os: ben.Os{Name: "linux", Version: "5.4"}, Label: "ci"}, "alice", "BenchmarkA")
+		itr.Close()
+		want := Benchmark{
+			ID:            bm.ID,
+			Name:          "BenchmarkA",
+			Scenario:      ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64", ClockSpeedMhz: 2400}, Os: ben.Os{Name: "linux", Version: "5.4"}, Label: "ci"},
+			Uploader:      "alice",
+			NanoSecsPerOp: 2,
+			LastUpdate:    bm.LastUpdate,
+		}
+		if !reflect.DeepEqual(bm, want) || !bm.LastUpdate.Equal(start.Add(time.Hour)) {
+			t.Errorf("got %+v, want %+v", bm, want)
+		}
+		got, itr := store.Runs(bm.ID)
+		defer itr.Close()
+		if !reflect.DeepEqual(got, bm) {
+			t.Errorf("got %+v, want %+v", got, bm)
+		}
+		// Most recently uploaded first.
+		var codes []string
+		for i := 1; itr.Advance(); i-- {
+			run, code, uploaded := itr.Value()
+			if run.NanoSecsPerOp != float64(i+1) || !uploaded.Equal(start.Add(time.Duration(i)*time.Hour)) {
+				t.Errorf("got run %+v uploaded at %v", run, uploaded)
+			}
+			if got := itr.Environment(); !reflect.DeepEqual(got, envs[i]) {
+				t.Errorf("got environment %#v, want %#v", got, envs[i])
+			}
+			codes = append(codes, code)
+		}
+		if err := itr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{ben.SourceCode("commit1").ID(), ben.SourceCode("commit0").ID()}; !reflect.DeepEqual(codes, want) {
+			t.Errorf("got source code IDs %v, want %v", codes, want)
+		}
+		for _, id := range codes {
+			if code, err := store.DescribeSource(id); err != nil || code.ID() != id {
+				t.Errorf("got (%q, %v) for %v", code, err, id)
+			}
+		}
+		if _, err := store.DescribeSource("unknown"); err != ErrNotFound {
+			t.Errorf("got %v, want ErrNotFound", err)
+		}
+		for _, id := range []string{"ffff", "not-hex"} {
+			if _, itr := store.Runs(id); itr.Err() != ErrNotFound {
+				t.Errorf("%v: got %v, want ErrNotFound", id, itr.Err())
+			}
+		}
+		// Lookups of benchmarks that have not been archived are empty.
+		if _, itr := store.Lookup(scenario, "bob", "BenchmarkA"); itr.Advance() || itr.Err() != nil {
+			t.Errorf("got runs (%v) of an unknown benchmark", itr.Err())
+		}
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+		start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+		want := []map[string]float64{
+			{"p99-ns": 20, "msgs/s": 1e3},
+			nil,
+			{"p99-ns": 10},
+		}
+		for i, metrics := range want {
+			runs := []ben.Run{{Name: "BenchmarkA", Iterations: 10, NanoSecsPerOp: 5, Metrics: metrics}}
+			if err := store.Save(nil, scenario, "commit", "alice", start.Add(time.Duration(i)*time.Hour), runs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_, itr := store.Lookup(scenario, "alice", "BenchmarkA")
+		defer itr.Close()
+		var got []map[string]float64
+		for itr.Advance() {
+			run, _, _ := itr.Value()
+			got = append([]map[string]float64{run.Metrics}, got...)
+		}
+		if err := itr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+// latest returns the ns/op of the latest run of each benchmark in store, as
+// cached in Benchmark, keyed by uploader and name.
+func latest(t *testing.T, store Store) map[string]float64 {
+	itr := store.Benchmarks(&Query{})
+	defer itr.Close()
+	ret := make(map[string]float64)
+	for itr.Advance() {
+		bm := itr.Value()
+		ret[bm.Uploader+"/"+bm.Name] = bm.NanoSecsPerOp
+	}
+	if err := itr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestSaveNonFinite(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+		now := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+		for _, run := range []ben.Run{
+			{Name: "BenchmarkA", NanoSecsPerOp: math.NaN()},
+			{Name: "BenchmarkA", NanoSecsPerOp: 1, MegaBytesPerSec: math.Inf(1)},
+			{Name: "BenchmarkA", NanoSecsPerOp: 1, Metrics: map[string]float64{"p99-ns": math.Inf(-1)}},
+		} {
+			if err := store.Save(nil, scenario, "commit", "alice", now, []ben.Run{{Name: "BenchmarkB", NanoSecsPerOp: 1}, run}); err == nil || !strings.Contains(err.Error(), "BenchmarkA") {
+				t.Errorf("%+v: got error %v", run, err)
+			}
+		}
+		if got := dumpStore(t, store); got != "" {
+			t.Errorf("got %v, want an empty store", got)
+		}
+		// The store remains usable.
+		if err := store.Save(nil, scenario, "commit", "alice", now, []ben.Run{{Name: "BenchmarkA", NanoSecsPerOp: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := store.Delete(archive.UploadSelector{Uploader: "alice"}); err != nil || n != 1 {
+			t.Errorf("got (%v, %v), want (1, nil)", n, err)
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+		save := func(uploader, label string, hours int, runs ...ben.Run) {
+			scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}, Label: label}
+			if err := store.Save(nil, scenario, ben.SourceCode(fmt.Sprintf("commit%d", hours)), uploader, start.Add(time.Duration(hours)*time.Hour), runs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		save("alice", "", 0, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 1}, ben.Run{Name: "BenchmarkB", NanoSecsPerOp: 10})
+		save("alice", "", 1, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 2, Metrics: map[string]float64{"p99-ns": 3}})
+		save("alice", "ci", 2, ben.Run{Name: "BenchmarkC", NanoSecsPerOp: 100})
+		save("bob", "", 3, ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 4})
+
+		if _, err := store.Delete(archive.UploadSelector{}); err == nil {
+			t.Errorf("deleting all uploads unexpectedly succeeded")
+		}
+		for _, test := range []struct {
+			selector archive.UploadSelector
+			uploads  int
+			want     map[string]float64
+		}{
+			// The upload with the latest run of alice's BenchmarkA.
+			{archive.UploadSelector{Upload: "2"}, 1, map[string]float64{"alice/BenchmarkA": 1, "alice/BenchmarkB": 10, "alice/BenchmarkC": 100, "bob/BenchmarkA": 4}},
+			{archive.UploadSelector{Upload: "2"}, 0, map[string]float64{"alice/BenchmarkA": 1, "alice/BenchmarkB": 10, "alice/BenchmarkC": 100, "bob/BenchmarkA": 4}},
+			{archive.UploadSelector{Uploader: "alice", Label: "ci"}, 1, map[string]float64{"alice/BenchmarkA": 1, "alice/BenchmarkB": 10, "bob/BenchmarkA": 4}},
+			{archive.UploadSelector{Uploader: "Alice"}, 1, map[string]float64{"bob/BenchmarkA": 4}},
+		} {
+			uploads, err := store.Delete(test.selector)
+			if err != nil {
+				t.Fatalf("%+v: %v", test.selector, err)
+			}
+			if uploads != test.uploads {
+				t.Errorf("%+v: got %d uploads deleted, want %d", test.selector, uploads, test.uploads)
+			}
+			if got := latest(t, store); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%+v: got %v, want %v", test.selector, got, test.want)
+			}
+		}
+		// Source code descriptions of deleted uploads are removed too.
+		if _, err := store.DescribeSource(ben.SourceCode("commit0").ID()); err != ErrNotFound {
+			t.Errorf("got %v, want ErrNotFound", err)
+		}
+		if _, err := store.DescribeSource(ben.SourceCode("commit3").ID()); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestDownsample(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+		start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+		// Four runs a day, for three days.
+		for i, ns := range []float64{5, 1, 3, 9, 2, 8, 4, 6, 7, 7, 7, 7} {
+			runs := []ben.Run{{Name: "BenchmarkA", NanoSecsPerOp: ns}}
+			if err := store.Save(nil, scenario, "commit", "alice", start.Add(time.Duration(i)*6*time.Hour), runs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := store.Downsample(start, 0); err == nil {
+			t.Errorf("downsampling with no interval unexpectedly succeeded")
+		}
+		policy := RetentionPolicy{KeepAll: 48 * time.Hour, Interval: 24 * time.Hour}
+		// Only the first day is older than KeepAll.
+		removed, err := policy.Apply(store, start.Add(72*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != 3 {
+			t.Errorf("got %d runs removed, want 3", removed)
+		}
+		_, itr := store.Lookup(scenario, "alice", "BenchmarkA")
+		var got []float64
+		for itr.Advance() {
+			run, _, _ := itr.Value()
+			got = append([]float64{run.NanoSecsPerOp}, got...)
+		}
+		if err := itr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		itr.Close()
+		if want := []float64{3, 2, 8, 4, 6, 7, 7, 7, 7}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		// Downsampling everything keeps a single run per day, and the latest
+		// of those is cached in Benchmark.
+		if _, err := (RetentionPolicy{KeepAll: time.Hour, Interval: 24 * time.Hour}).Apply(store, start.Add(100*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := latest(t, store), map[string]float64{"alice/BenchmarkA": 7}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if removed, err := policy.Apply(store, start.Add(100*time.Hour)); err != nil || removed != 0 {
+			t.Errorf("got (%d, %v), want (0, nil)", removed, err)
+		}
+	})
+}
+
+func TestSamples(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		scenario := ben.Scenario{Cpu: ben.Cpu{Architecture: "amd64"}, Os: ben.Os{Name: "linux"}}
+		start := time.Date(2016, 2, 14, 0, 0, 0, 0, time.UTC)
+		uploads := [][]ben.Run{
+			// As uploaded for "go test -bench . -count 3".
+			{
+				{Name: "BenchmarkA", NanoSecsPerOp: 14, AllocsPerOp: 1, Metrics: map[string]float64{"p99-ns": 30}},
+				{Name: "BenchmarkB", NanoSecsPerOp: 5},
+				{Name: "BenchmarkA", NanoSecsPerOp: 10, AllocsPerOp: 3, Metrics: map[string]float64{"p99-ns": 10}},
+				{Name: "BenchmarkA", NanoSecsPerOp: 12, AllocsPerOp: 2, Metrics: map[string]float64{"p99-ns": 20}},
+			},
+			{
+				{Name: "BenchmarkA", NanoSecsPerOp: 21},
+				{Name: "BenchmarkA", NanoSecsPerOp: 19},
+			},
+		}
+		for i, runs := range uploads {
+			if err := store.Save(nil, scenario, "commit", "alice", start.Add(time.Duration(i)*time.Hour), runs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// The median of the latest samples is cached in Benchmark.
+		if got, want := latest(t, store), map[string]float64{"alice/BenchmarkA": 20, "alice/BenchmarkB": 5}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		_, itr := store.Lookup(scenario, "alice", "BenchmarkA")
+		defer itr.Close()
+		var got []Samples
+		if err := bySamples(itr, func(s Samples) bool {
+			got = append(got, s)
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("got %d uploads, want 2: %+v", len(got), got)
+		}
+		for i, want := range []Stats{
+			{Samples: 2, Min: 19, Median: 20, Mean: 20, StdDev: math.Sqrt2, CILow: 20 - 12.706, CIHigh: 20 + 12.706},
+			{Samples: 3, Min: 10, Median: 12, Mean: 12, StdDev: 2, CILow: 12 - 4.303*2/math.Sqrt(3), CIHigh: 12 + 4.303*2/math.Sqrt(3)},
+		} {
+			if got := got[i].Stats; !approxStats(got, want) {
+				t.Errorf("%d: got %+v, want %+v", i, got, want)
+			}
+		}
+		if got[0].UploadID == got[1].UploadID {
+			t.Errorf("got the same upload ID %q for both uploads", got[0].UploadID)
+		}
+		want := ben.Run{Name: "BenchmarkA", NanoSecsPerOp: 12, AllocsPerOp: 2, Metrics: map[string]float64{"p99-ns": 20}}
+		if got := got[1].Run(); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+		if got, want := got[1].Stats.NoisePercent(), 4.303*2/math.Sqrt(3)/12*100; math.Abs(got-want) > 1e-9 {
+			t.Errorf("got %v%% noise, want %v%%", got, want)
+		}
+	})
+}
+
+func approxStats(a, b Stats) bool {
+	eq := func(x, y float64) bool { return math.Abs(x-y) < 1e-9 }
+	return a.Samples == b.Samples && eq(a.Min, b.Min) && eq(a.Median, b.Median) && eq(a.Mean, b.Mean) &&
+		eq(a.StdDev, b.StdDev) && eq(a.CILow, b.CILow) && eq(a.CIHigh, b.CIHigh)
+}
+
+func TestSQLMigrations(t *testing.T) {
+	db, err := sql.Open(SQLite3Driver, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	// A database created before schema versions were recorded, with the
+	// tables of the first migrations.
+	migrations := SQLMigrations("sqlite3")
+	for _, m := range migrations[:3] {
+		for _, stmt := range m.Statements {
+			if _, err := db.Exec(stmt); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	code := ben.SourceCode("commit")
+	if _, err := db.Exec("INSERT INTO SourceCode (ID, Description) VALUES (?, ?)", code.ID(), string(code)); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewSQLStore("sqlite3", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version, err := dbutil.SchemaVersion(db, SQLStoreSchema); err != nil || version != len(migrations) {
+		t.Errorf("got (%d, %v), want (%d, nil)", version, err, len(migrations))
+	}
+	if got, err := store.DescribeSource(code.ID()); err != nil || got != code {
+		t.Errorf("got (%q, %v), want (%q, nil)", got, err, code)
+	}
+	// Previously archived descriptions that are their own IDs are commits.
+	var hash string
+	if err := db.QueryRow("SELECT Hash FROM SourceCommit WHERE SourceCode = ?", code.ID()).Scan(&hash); err != nil || hash != "commit" {
+		t.Errorf("got (%q, %v), want (%q, nil)", hash, err, "commit")
+	}
+	// Stores can be reopened without further migrations.
+	if _, err := NewSQLStore("sqlite3", db); err != nil {
+		t.Fatal(err)
+	}
+}
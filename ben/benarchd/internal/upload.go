@@ -0,0 +1,108 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/vanadium/services/ben"
+	"github.com/vanadium/services/ben/archive"
+	"github.com/vanadium/services/ben/gobench"
+	v23 "v.io/v23"
+	"v.io/v23/context"
+	"v.io/v23/security"
+	"v.io/v23/security/access"
+	"v.io/v23/vdl"
+	"v.io/v23/verror"
+)
+
+// maxUploadBytes is the maximum size of the output of "go test -bench"
+// accepted by the upload handler.
+const maxUploadBytes = 32 << 20
+
+// NewUploadHandler returns an http.Handler serving archive.UploadPath, which
+// archives the output of "go test -bench" like the archive.BenchmarkArchiver
+// server returned by NewArchiver with the same arguments. Uploads must be
+// signed by archive.SignRequest with blessings that the principal of ctx
+// recognizes, and are subject to the same Authorizer.
+func NewUploadHandler(ctx *context.T, store Store, url string, policy RegressionPolicy, access *Policy, notifier *Notifier) http.Handler {
+	return &uploadHandler{
+		ctx: ctx,
+		server: &server{
+			url:      url,
+			store:    store,
+			policy:   policy,
+			access:   access,
+			notifier: notifier,
+		},
+	}
+}
+
+type uploadHandler struct {
+	ctx    *context.T
+	server *server
+}
+
+func (h *uploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		uploadError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method))
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadBytes))
+	if err != nil {
+		uploadError(w, http.StatusBadRequest, err)
+		return
+	}
+	now := time.Now()
+	blessings, err := archive.VerifyRequest(r, body, now)
+	if err != nil {
+		uploadError(w, http.StatusUnauthorized, err)
+		return
+	}
+	call := security.NewCall(&security.CallParams{
+		Timestamp:       now,
+		Method:          "Archive",
+		MethodTags:      []*vdl.Value{vdl.ValueOf(access.Write)},
+		LocalPrincipal:  v23.GetPrincipal(h.ctx),
+		RemoteBlessings: blessings,
+	})
+	if err := Authorizer(h.server.access).Authorize(h.ctx, call); err != nil {
+		uploadError(w, http.StatusForbidden, err)
+		return
+	}
+	scenario, runs, err := gobench.Parse(bytes.NewReader(body))
+	if err == nil && len(runs) == 0 {
+		err = fmt.Errorf("no benchmark results found")
+	}
+	if err == nil {
+		err = validate(scenario, runs)
+	}
+	if err != nil {
+		uploadError(w, http.StatusBadRequest, err)
+		return
+	}
+	params := r.URL.Query()
+	scenario.Label = params.Get("label")
+	uploaderBlessings, _ := security.RemoteBlessingNames(h.ctx, call)
+	url, regressions, err := h.server.archive(h.ctx, uploaderBlessings, scenario, ben.SourceCode(params.Get("source")), runs)
+	if errors.Is(err, verror.ErrNoAccess) {
+		uploadError(w, http.StatusForbidden, err)
+		return
+	}
+	if err != nil {
+		uploadError(w, http.StatusInternalServerError, err)
+		return
+	}
+	apiJSON(w, http.StatusOK, archive.UploadResult{URL: url, Regressions: regressions})
+}
+
+func uploadError(w http.ResponseWriter, status int, err error) {
+	apiJSON(w, status, archive.UploadResult{Error: err.Error()})
+}
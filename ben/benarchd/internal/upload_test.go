@@ -0,0 +1,124 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vanadium/services/ben/archive"
+	v23 "v.io/v23"
+	"v.io/v23/security"
+	_ "v.io/x/ref/runtime/factories/generic"
+	"v.io/x/ref/test"
+	"v.io/x/ref/test/testutil"
+)
+
+const uploadOutput = `goos: linux
+goarch: amd64
+pkg: crypto/sha256
+cpu: Intel(R) Xeon(R) CPU @ 2.20GHz
+BenchmarkHash8Bytes-4   	 5000000	       254 ns/op	  31.42 MB/s
+BenchmarkHash1K-4       	  500000	      2388 ns/op	 428.74 MB/s
+PASS
+`
+
+func TestUpload(t *testing.T) {
+	ctx, shutdown := test.V23Init()
+	defer shutdown()
+	store, cleanup := newSQLiteStore(t)
+	defer cleanup()
+	h := NewUploadHandler(ctx, store, "http://benarchd/?q=", RegressionPolicy{}, nil, nil)
+
+	idp := testutil.IDProviderFromPrincipal(v23.GetPrincipal(ctx))
+	alice := testutil.NewPrincipal()
+	if err := idp.Bless(alice, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	aliceBlessings, _ := alice.BlessingStore().Default()
+	// Blessings that the server does not recognize.
+	mallory := testutil.NewPrincipal("mallory")
+	malloryBlessings, _ := mallory.BlessingStore().Default()
+
+	upload := func(p security.Principal, b security.Blessings, signedBody, body string, signedAt time.Time, wantStatus int) archive.UploadResult {
+		req := httptest.NewRequest("POST", archive.UploadPath+"?label=ci&source=abcdef", strings.NewReader(body))
+		if p != nil {
+			if err := archive.SignRequest(p, b, req, []byte(signedBody), signedAt); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != wantStatus {
+			t.Errorf("got status %v, want %v: %s", w.Code, wantStatus, w.Body)
+		}
+		var result archive.UploadResult
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("%v: %s", err, w.Body)
+		}
+		return result
+	}
+
+	now := time.Now()
+	upload(nil, security.Blessings{}, "", uploadOutput, now, http.StatusUnauthorized)
+	upload(alice, aliceBlessings, "tampered", uploadOutput, now, http.StatusUnauthorized)
+	upload(alice, aliceBlessings, uploadOutput, uploadOutput, now.Add(-time.Hour), http.StatusUnauthorized)
+	upload(mallory, malloryBlessings, uploadOutput, uploadOutput, now, http.StatusForbidden)
+	// Alice can't sign with the blessings of Mallory.
+	upload(alice, malloryBlessings, uploadOutput, uploadOutput, now, http.StatusUnauthorized)
+	upload(alice, aliceBlessings, "PASS\n", "PASS\n", now, http.StatusBadRequest)
+	result := upload(alice, aliceBlessings, uploadOutput, uploadOutput, now, http.StatusOK)
+	if !strings.HasPrefix(result.URL, "http://benarchd/?q=") || len(result.Error) > 0 {
+		t.Errorf("got %+v", result)
+	}
+
+	itr := store.Benchmarks(&Query{Name: "crypto/sha256.BenchmarkHash"})
+	defer itr.Close()
+	var got []Benchmark
+	for itr.Advance() {
+		got = append(got, itr.Value())
+	}
+	if err := itr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d benchmarks, want 2: %v", len(got), got)
+	}
+	for _, bm := range got {
+		if bm.Uploader != "test-blessing:alice" || bm.Scenario.Label != "ci" || bm.Scenario.Cpu.ClockSpeedMhz != 2200 || bm.Scenario.Os.Name != "linux" {
+			t.Errorf("got %+v", bm)
+		}
+	}
+	code, err := store.DescribeSource("abcdef")
+	if err != nil || code != "abcdef" {
+		t.Errorf("got %q, %v", code, err)
+	}
+
+	// Upload signs requests with the default blessings of the principal.
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	aliceCtx, err := v23.WithPrincipal(ctx, alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := archive.Upload(aliceCtx, srv.URL, "ci", "abcdef", []byte(uploadOutput)); err != nil || len(result.URL) == 0 {
+		t.Errorf("got %+v, %v", result, err)
+	}
+	if _, err := archive.Upload(aliceCtx, srv.URL, "ci", "abcdef", []byte("PASS\n")); err == nil || !strings.Contains(err.Error(), "no benchmark results") {
+		t.Errorf("got error %v", err)
+	}
+	if _, err := archive.Upload(aliceCtx, srv.URL, "ci", "abcdef", []byte("goos: linux\ngoarch: amd64\nBenchmarkBad-4 100 NaN ns/op\n")); err == nil || !strings.Contains(err.Error(), "BenchmarkBad") {
+		t.Errorf("got error %v", err)
+	}
+	if resp, err := http.Get(srv.URL + archive.UploadPath); err != nil || resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("got %v, %v", resp, err)
+	} else {
+		resp.Body.Close()
+	}
+}
@@ -3,7 +3,7 @@
 // license that can be found in the LICENSE file.
 
 // The following enables go generate to generate the doc.go file.
-//go:generate go run v.io/x/lib/cmdline/gendoc . -help
+//go:generate go run v.io/x/lib/cmdline/gendoc .
 
 package main
 
@@ -14,12 +14,15 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/vanadium/services/ben/archive"
 	"github.com/vanadium/services/ben/benarchd/internal"
 	"github.com/vanadium/services/internal/dbutil"
 	v23 "v.io/v23"
 	"v.io/v23/context"
+	"v.io/v23/security"
 	"v.io/x/lib/cmdline"
 	"v.io/x/ref/lib/signals"
 	"v.io/x/ref/lib/v23cmd"
@@ -32,6 +35,14 @@ var (
 	flagPublicHTTPAddr string
 	flagName           string
 	flagAssets         string
+	flagRegressions    internal.RegressionPolicy
+	flagRetention      internal.RetentionPolicy
+	flagAdmins         string
+	flagPolicy         string
+	flagDryRun         bool
+	flagHooks          string
+	flagHookRetry      internal.RetryPolicy
+	flagHookLog        string
 	cmdRoot            = &cmdline.Command{
 		Runner: v23cmd.RunnerFunc(run),
 		Name:   "benarchd",
@@ -41,15 +52,68 @@ Command benarchd implements a benchmark archival daemon.
 
 It provides a Vanadium RPC service to accept results to archive (typically
 provided by the benup tool - https://godoc.org/v.io/x/ref/services/ben/benup)
-and a web-interface to browse the results.
+and a web-interface to browse the results. The results are also available
+in JSON and CSV formats from the /api/v1/benchmarks, /api/v1/runs and
+/api/v1/sources HTTP endpoints.
 
-A SQL database is used for persistent storage, configured via the --store
-flag.
+The output of "go test -bench" can also be POSTed to the /api/v1/upload HTTP
+endpoint, with requests authenticated by Vanadium blessings as described in
+https://godoc.org/github.com/vanadium/services/ben/archive#UploadPath
+Multiple runs of a benchmark in one upload (e.g. from "go test -bench -count")
+are samples of it: their median is used to detect regressions, and their
+variance is reported with the results.
+
+A SQL database or a journal file is used for persistent storage, configured
+via the --store flag. Runs older than --retention-keep-all are periodically
+downsampled to one run per benchmark and --retention-interval, and callers
+with blessings matching --admin may delete uploads via the Delete RPC method.
+
+Reading, uploading and deleting results can be restricted by a policy file,
+configured via the --policy flag, as described in
+https://godoc.org/github.com/vanadium/services/ben/benarchd/internal#ReadPolicy
+If reading results requires authentication, browsers are authenticated by
+opening a URL obtained as described in
+https://godoc.org/github.com/vanadium/services/ben/archive#LoginPath
+
+Archived uploads, and the regressions they exhibit, can be announced to the
+HTTP endpoints and Vanadium RPC subscribers configured via the --hooks flag,
+as described in
+https://godoc.org/github.com/vanadium/services/ben/archive#UploadNotification
+Failed deliveries are retried with exponential backoff, and every attempt is
+recorded in the delivery log configured via the --hook-log flag.
+
+The schema of SQL stores is versioned, and migrated to the latest version
+when benarchd starts. The migrate command applies or previews the migrations.
+`,
+		Children: []*cmdline.Command{cmdMigrate},
+	}
+	cmdMigrate = &cmdline.Command{
+		Runner: v23cmd.RunnerFunc(runMigrate),
+		Name:   "migrate",
+		Short:  "Migrates the schema of a SQL store to the latest version",
+		Long: `
+Command migrate applies the migrations of the schema of the SQL store specified
+by --store that have not been applied yet, in order, each in a transaction, and
+exits. With --dry-run, it only prints them.
 `,
 	}
 )
 
 func run(ctx *context.T, env *cmdline.Env, args []string) error {
+	// Initialize the authorization policy
+	policy := &internal.Policy{}
+	if flagPolicy != "" {
+		var err error
+		if policy, err = internal.ReadPolicy(flagPolicy); err != nil {
+			return err
+		}
+	}
+	for _, p := range strings.Split(flagAdmins, ",") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			policy.AddAdmin(security.BlessingPattern(p))
+		}
+	}
+
 	// Initialize assets to be used by the web interface
 	assets, err := internal.NewAssets(flagAssets)
 	if err != nil {
@@ -57,26 +121,19 @@ func run(ctx *context.T, env *cmdline.Env, args []string) error {
 	}
 
 	// Initialize the store
-	driver, dataSource, err := parseStore(ctx, flagStore)
+	store, closeStore, err := openStore(ctx, flagStore)
 	if err != nil {
 		return err
 	}
-	var db *sql.DB
-	switch driver {
-	case "sqlconfig":
-		// I'll admit, I have no clude of SERIALIZABLE is the right choice.
-		db, err = dbutil.NewSQLDBConnFromFile(dataSource, "SERIALIZABLE")
-	default:
-		db, err = sql.Open(driver, dataSource)
-	}
-	if err != nil {
-		return err
-	}
-	defer db.Close()
-	store, err := internal.NewSQLStore(driver, db)
+	defer closeStore()
+	go flagRetention.Run(ctx, store, time.Hour)
+
+	// Initialize the hooks notified of uploads
+	notifier, closeNotifier, err := openNotifier(ctx)
 	if err != nil {
 		return err
 	}
+	defer closeNotifier()
 
 	// Start the HTTP service
 	ln, err := net.Listen("tcp", flagHTTPAddr)
@@ -84,14 +141,19 @@ func run(ctx *context.T, env *cmdline.Env, args []string) error {
 		return err
 	}
 	ctx.Infof("HTTP server at http://%v", ln.Addr())
-	go http.Serve(ln, internal.NewHTTPHandler(assets, store)) //nolint:errcheck
-
-	// Start the v23 RPC service
 	pubAddr := fmt.Sprintf("http://%v", ln.Addr())
 	if flagPublicHTTPAddr != "" {
 		pubAddr = flagPublicHTTPAddr
 	}
-	_, v23server, err := v23.WithNewServer(ctx, flagName, internal.NewArchiver(store, pubAddr+"/?q="), internal.Authorizer())
+	mux := http.NewServeMux()
+	auth := internal.NewAuthenticator(ctx, pubAddr, policy)
+	mux.Handle(archive.UploadPath, internal.NewUploadHandler(ctx, store, pubAddr+"/?q=", flagRegressions, policy, notifier))
+	mux.Handle(archive.LoginPath, auth)
+	mux.Handle("/", internal.NewHTTPHandler(assets, store, flagRegressions, auth))
+	go http.Serve(ln, mux) //nolint:errcheck
+
+	// Start the v23 RPC service
+	_, v23server, err := v23.WithNewServer(ctx, flagName, internal.NewArchiver(store, pubAddr+"/?q=", flagRegressions, policy, notifier), internal.Authorizer(policy))
 	if err != nil {
 		return err
 	}
@@ -103,11 +165,106 @@ func run(ctx *context.T, env *cmdline.Env, args []string) error {
 	return nil
 }
 
+// openStore returns the store specified by the --store flag and a function to
+// release it.
+func openStore(ctx *context.T, spec string) (internal.Store, func(), error) {
+	driver, dataSource, err := parseStore(ctx, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch driver {
+	case "memory":
+		return internal.NewMemStore(), func() {}, nil
+	case "file":
+		store, err := internal.NewFileStore(dataSource)
+		return store, func() {}, err
+	}
+	db, err := openDB(driver, dataSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := internal.NewSQLStore(driver, db)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return store, func() { db.Close() }, nil
+}
+
+// openNotifier returns the notifier of the hooks specified by the --hooks flag,
+// or nil if there are none, and a function to release it.
+func openNotifier(ctx *context.T) (*internal.Notifier, func(), error) {
+	hooks, err := internal.ParseHooks(flagHooks)
+	if err != nil || len(hooks) == 0 {
+		return nil, func() {}, err
+	}
+	if flagHookLog == "" {
+		return internal.NewNotifier(ctx, hooks, flagHookRetry, nil), func() {}, nil
+	}
+	log, err := os.OpenFile(flagHookLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return internal.NewNotifier(ctx, hooks, flagHookRetry, log), func() { log.Close() }, nil
+}
+
+// openDB opens the SQL database of a --store with the given engine (other than
+// 'memory' and 'file') and parameters.
+func openDB(driver, dataSource string) (*sql.DB, error) {
+	switch driver {
+	case "sqlconfig":
+		// I'll admit, I have no clude of SERIALIZABLE is the right choice.
+		return dbutil.NewSQLDBConnFromFile(dataSource, "SERIALIZABLE")
+	case "sqlite3":
+		return sql.Open(internal.SQLite3Driver, dataSource)
+	}
+	return sql.Open(driver, dataSource)
+}
+
+func runMigrate(ctx *context.T, env *cmdline.Env, args []string) error {
+	driver, dataSource, err := parseStore(ctx, flagStore)
+	if err != nil {
+		return err
+	}
+	switch driver {
+	case "memory", "file":
+		return env.UsageErrorf("--store=%v: only SQL stores have schemas to migrate", driver)
+	}
+	db, err := openDB(driver, dataSource)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	migrations := internal.SQLMigrations(driver)
+	version, pending, err := dbutil.PendingMigrations(db, internal.SQLStoreSchema, migrations)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.Stdout, "Schema %q is at version %d of %d.\n", internal.SQLStoreSchema, version, len(migrations))
+	for i, m := range pending {
+		fmt.Fprintf(env.Stdout, "Migration to version %d: %v\n", version+i+1, m.Description)
+		if flagDryRun {
+			for _, stmt := range m.Statements {
+				fmt.Fprintf(env.Stdout, "\t%s;\n", strings.Join(strings.Fields(stmt), " "))
+			}
+		}
+	}
+	if flagDryRun || len(pending) == 0 {
+		return nil
+	}
+	n, err := dbutil.Migrate(db, internal.SQLStoreSchema, migrations)
+	fmt.Fprintf(env.Stdout, "Applied %d migrations.\n", n)
+	return err
+}
+
 func parseStore(ctx *context.T, spec string) (driver, dataSource string, err error) {
 	if len(spec) == 0 {
 		spec = fmt.Sprintf("sqlite3:%v/benarchd.db", os.TempDir())
 		ctx.Infof("No --store specified, using %q", spec)
 	}
+	if spec == "memory" {
+		return spec, "", nil
+	}
 	pos := strings.Index(spec, ":")
 	if pos < 0 {
 		return "", "", fmt.Errorf("invalid --store, must be in the format <engine>:<parameters>")
@@ -118,9 +275,22 @@ func parseStore(ctx *context.T, spec string) (driver, dataSource string, err err
 func main() {
 	cmdline.HideGlobalFlagsExcept()
 	cmdRoot.Flags.StringVar(&flagName, "name", "", "Vanadium object name to export this service under")
-	cmdRoot.Flags.StringVar(&flagStore, "store", "", "Specification of the persistent store to use. Format: <engine>:<parameters>, where <engine> can be 'sqlite3', 'mysql' or 'sqlconfig'. For 'sqlconfig', <parameters> is a path to a file containing a JSON-serialized SqlConfig (https://godoc.org/v.io/x/lib/dbutil#SqlConfig), for the others it is the data source name (e.g., filename for sqlite3)")
+	cmdRoot.Flags.StringVar(&flagStore, "store", "", "Specification of the persistent store to use. Format: <engine>:<parameters>, where <engine> can be 'sqlite3', 'mysql', 'sqlconfig', 'file' or 'memory'. For 'sqlconfig', <parameters> is a path to a file containing a JSON-serialized SqlConfig (https://godoc.org/v.io/x/lib/dbutil#SqlConfig), for 'file' it is the path to a journal of the archived results (which requires neither cgo nor a database server), for 'memory' it is ignored and results are lost when the process exits (the parameters and ':' can be omitted), and for the others it is the data source name (e.g., filename for sqlite3)")
 	cmdRoot.Flags.StringVar(&flagPublicHTTPAddr, "exthttp", "", "The address of the HTTP server to advertise externally, typically used if the HTTP server is running behind a proxy or on a machine that is unaware of its publicly accessible hostname/IP address. If empty, derived from --http.")
 	cmdRoot.Flags.StringVar(&flagHTTPAddr, "http", "127.0.0.1:0", "Address on which to serve HTTP requests")
 	cmdRoot.Flags.StringVar(&flagAssets, "assets", "", "If set, the directory containing assets (template definitions, css, javascript files etc.) to use in the web interface. If not set, compiled-in assets will be used instead.")
+	cmdRoot.Flags.IntVar(&flagRegressions.History, "regression-history", 10, "Number of previously archived runs of a benchmark that new runs are compared to in order to detect regressions. Zero disables regression detection.")
+	cmdRoot.Flags.Float64Var(&flagRegressions.Threshold, "regression-threshold", 0.1, "Minimum relative increase in ns/op, allocs/op or B/op over the median of the previously archived runs that is flagged as a regression.")
+	cmdRoot.Flags.Float64Var(&flagRegressions.MaxPValue, "regression-max-pvalue", 0.05, "If non-zero, regressions are only flagged if the probability of the new result given the distribution of the previously archived runs is at most this value.")
+	cmdRoot.Flags.DurationVar(&flagRetention.KeepAll, "retention-keep-all", 0, "Age up to which all archived runs are kept. Older runs are downsampled to the run with the median ns/op per benchmark and --retention-interval. Zero disables downsampling.")
+	cmdRoot.Flags.DurationVar(&flagRetention.Interval, "retention-interval", 24*time.Hour, "Length of the intervals that runs older than --retention-keep-all are downsampled to.")
+	cmdRoot.Flags.StringVar(&flagAdmins, "admin", "", "Comma-separated list of blessing patterns of the clients authorized to delete archived uploads, in addition to those authorized by --policy.")
+	cmdRoot.Flags.StringVar(&flagPolicy, "policy", "", "If set, the JSON file with the policy that authorizes reading, uploading and deleting results. If not set, anyone can read results, clients with any recognizable blessing can upload them and only --admin clients can delete them.")
+	cmdRoot.Flags.StringVar(&flagHooks, "hooks", "", "Comma-separated list of hooks notified of archived uploads: HTTP(S) URLs to POST a JSON-encoded UploadNotification to, signed like uploads to /api/v1/upload, and Vanadium object names of UploadSubscriber servers.")
+	cmdRoot.Flags.IntVar(&flagHookRetry.Attempts, "hook-attempts", 5, "Maximum number of attempts to deliver a notification to each of --hooks.")
+	cmdRoot.Flags.DurationVar(&flagHookRetry.Backoff, "hook-backoff", time.Second, "Delay before retrying a failed delivery to one of --hooks, which doubles with each further retry.")
+	cmdRoot.Flags.StringVar(&flagHookLog, "hook-log", "", "If set, the file that a JSON-encoded record of every attempt to deliver a notification to --hooks is appended to.")
+	cmdMigrate.Flags.StringVar(&flagStore, "store", "", "Specification of the SQL store to migrate, in the format of the --store flag of benarchd.")
+	cmdMigrate.Flags.BoolVar(&flagDryRun, "dry-run", false, "If set, only print the migrations that would be applied, and their SQL statements.")
 	cmdline.Main(cmdRoot)
 }
@@ -0,0 +1,233 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gobench parses the output of "go test -bench" into the data
+// structures used to archive microbenchmark results.
+package gobench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vanadium/services/ben"
+)
+
+var clockSpeedRE = regexp.MustCompile(`@ *([0-9]+(?:\.[0-9]+)?) *([GM])Hz`)
+
+// Parse parses the output of "go test -bench", optionally with -benchmem, for
+// one or more packages.
+//
+// The Scenario is described by the goos, goarch and cpu lines printed by go
+// test; the clock speed of the CPU is extracted from its description when
+// present (e.g. "Intel(R) Core(TM) i7-5557U CPU @ 3.10GHz"). The name of each
+// Run is qualified by the package printed in the preceding pkg line, e.g.
+// "crypto/sha256.BenchmarkHash1K", and its Parallelism is the GOMAXPROCS
+// suffix of the benchmark name. Metrics in units other than ns/op, MB/s, B/op
+// and allocs/op, which are reported by b.ReportMetric, are stored in the
+// Metrics of the Run.
+//
+// The Env of the Scenario is described by lines in the same format that are
+// not printed by go test, but may be added to its output by the uploader:
+//
+//	go: go1.22.1
+//	gomaxprocs: 8
+//	cores: 8
+//	memory: 17179869184
+//	kernel: 5.15.0-91-generic
+//	governor: performance
+//	tag: ci=nightly
+//
+// where memory is in bytes and tag lines may be repeated. Absent a gomaxprocs
+// line, GOMAXPROCS is the Parallelism of the runs if it is the same for all.
+//
+// Lines that are not benchmark results or headers, such as test logs and
+// failures, are ignored.
+func Parse(r io.Reader) (ben.Scenario, []ben.Run, error) {
+	var (
+		scenario ben.Scenario
+		runs     []ben.Run
+		pkg      string
+		scanner  = bufio.NewScanner(r)
+		lineno   int
+	)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+		if key, value, ok := header(line); ok {
+			var dst *string
+			switch key {
+			case "goos":
+				dst = &scenario.Os.Name
+			case "goarch":
+				dst = &scenario.Cpu.Architecture
+			case "cpu":
+				dst = &scenario.Cpu.Description
+			case "go":
+				dst = &scenario.Env.GoVersion
+			case "kernel":
+				dst = &scenario.Env.Kernel
+			case "governor":
+				dst = &scenario.Env.CpuGovernor
+			case "gomaxprocs", "cores", "memory", "tag":
+				if err := setEnv(&scenario.Env, key, value); err != nil {
+					return ben.Scenario{}, nil, fmt.Errorf("line %d: %v", lineno, err)
+				}
+				continue
+			case "pkg":
+				pkg = value
+				continue
+			default:
+				continue
+			}
+			if len(*dst) > 0 && *dst != value {
+				return ben.Scenario{}, nil, fmt.Errorf("line %d: %s %q conflicts with %q: results must be from a single machine", lineno, key, value, *dst)
+			}
+			*dst = value
+			continue
+		}
+		run, ok, err := parseRun(line)
+		if err != nil {
+			return ben.Scenario{}, nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		if !ok {
+			continue
+		}
+		if len(pkg) > 0 {
+			run.Name = pkg + "." + run.Name
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return ben.Scenario{}, nil, err
+	}
+	scenario.Cpu.ClockSpeedMhz = clockSpeedMhz(scenario.Cpu.Description)
+	if scenario.Env.GoMaxProcs == 0 && len(runs) > 0 {
+		scenario.Env.GoMaxProcs = runs[0].Parallelism
+		for _, run := range runs {
+			if run.Parallelism != scenario.Env.GoMaxProcs {
+				scenario.Env.GoMaxProcs = 0
+				break
+			}
+		}
+	}
+	return scenario, runs, nil
+}
+
+// setEnv sets the field of env described by a gomaxprocs, cores, memory or tag
+// line.
+func setEnv(env *ben.Environment, key, value string) error {
+	if key == "tag" {
+		idx := strings.Index(value, "=")
+		if idx <= 0 {
+			return fmt.Errorf("tag %q is not of the form <name>=<value>", value)
+		}
+		name, v := value[:idx], value[idx+1:]
+		if old, ok := env.Tags[name]; ok && old != v {
+			return fmt.Errorf("tag %s=%q conflicts with %q", name, v, old)
+		}
+		if env.Tags == nil {
+			env.Tags = make(map[string]string)
+		}
+		env.Tags[name] = v
+		return nil
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", key, value)
+	}
+	var old uint64
+	switch key {
+	case "gomaxprocs":
+		old, env.GoMaxProcs = uint64(env.GoMaxProcs), uint32(n)
+	case "cores":
+		old, env.Cores = uint64(env.Cores), uint32(n)
+	case "memory":
+		old, env.MemoryBytes = env.MemoryBytes, n
+	}
+	if old != 0 && old != n {
+		return fmt.Errorf("%s %d conflicts with %d: results must be from a single machine", key, n, old)
+	}
+	return nil
+}
+
+// header returns the key and value of lines like "goos: linux".
+func header(line string) (key, value string, ok bool) {
+	idx := strings.Index(line, ": ")
+	if idx < 0 {
+		return "", "", false
+	}
+	switch key = line[:idx]; key {
+	case "goos", "goarch", "cpu", "pkg", "go", "gomaxprocs", "cores", "memory", "kernel", "governor", "tag":
+		return key, strings.TrimSpace(line[idx+2:]), true
+	}
+	return "", "", false
+}
+
+// parseRun parses lines like:
+//
+//	BenchmarkHash1K-8   	  500000	      2388 ns/op	 428.74 MB/s	       0 B/op	       0 allocs/op
+//
+// It returns false if line is not a benchmark result.
+func parseRun(line string) (ben.Run, bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return ben.Run{}, false, nil
+	}
+	iterations, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		// e.g. "BenchmarkBad-4 --- FAIL: BenchmarkBad-4"
+		return ben.Run{}, false, nil
+	}
+	run := ben.Run{Iterations: iterations, Parallelism: 1}
+	run.Name = fields[0]
+	if idx := strings.LastIndex(run.Name, "-"); idx > 0 {
+		if procs, err := strconv.ParseUint(run.Name[idx+1:], 10, 32); err == nil {
+			run.Name, run.Parallelism = run.Name[:idx], uint32(procs)
+		}
+	}
+	for i := 2; i+1 < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err == nil && (math.IsNaN(value) || math.IsInf(value, 0)) {
+			err = fmt.Errorf("not a finite number")
+		}
+		if err != nil {
+			return ben.Run{}, false, fmt.Errorf("invalid value %q for %v of %v", fields[i], fields[i+1], fields[0])
+		}
+		switch fields[i+1] {
+		case "ns/op":
+			run.NanoSecsPerOp = value
+		case "MB/s":
+			run.MegaBytesPerSec = value
+		case "B/op":
+			run.AllocedBytesPerOp = uint64(value)
+		case "allocs/op":
+			run.AllocsPerOp = uint64(value)
+		default:
+			// Reported by b.ReportMetric.
+			if run.Metrics == nil {
+				run.Metrics = map[string]float64{}
+			}
+			run.Metrics[fields[i+1]] = value
+		}
+	}
+	return run, true, nil
+}
+
+func clockSpeedMhz(cpu string) uint32 {
+	m := clockSpeedRE.FindStringSubmatch(cpu)
+	if m == nil {
+		return 0
+	}
+	speed, _ := strconv.ParseFloat(m[1], 64)
+	if m[2] == "G" {
+		speed *= 1000
+	}
+	return uint32(speed + 0.5)
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gobench
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vanadium/services/ben"
+)
+
+func TestParse(t *testing.T) {
+	output := `
+goos: linux
+goarch: amd64
+pkg: crypto/sha256
+cpu: Intel(R) Core(TM) i7-5557U CPU @ 3.10GHz
+BenchmarkHash8Bytes-4   	 5000000	       254 ns/op	  31.42 MB/s
+BenchmarkHash1K-4       	  500000	      2388 ns/op	 428.74 MB/s	       0 B/op	       0 allocs/op
+BenchmarkBad-4          	--- FAIL: BenchmarkBad-4
+	sha256_test.go:54: BenchmarkBad intentionally fails
+PASS
+ok  	crypto/sha256	4.728s
+goos: linux
+goarch: amd64
+pkg: v.io/v23/security
+cpu: Intel(R) Core(TM) i7-5557U CPU @ 3.10GHz
+BenchmarkSign
+BenchmarkSign/ecdsa-4   	   20000	     61234.5 ns/op	    4321 B/op	      56 allocs/op
+BenchmarkVerify         	   10000	    123456 ns/op	    150000 p99-ns	      8100 verifies/s
+PASS
+ok  	v.io/v23/security	3.210s
+`
+	scenario, runs, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantScenario := ben.Scenario{
+		Cpu: ben.Cpu{Architecture: "amd64", Description: "Intel(R) Core(TM) i7-5557U CPU @ 3.10GHz", ClockSpeedMhz: 3100},
+		Os:  ben.Os{Name: "linux"},
+	}
+	if !reflect.DeepEqual(scenario, wantScenario) {
+		t.Errorf("got %#v, want %#v", scenario, wantScenario)
+	}
+	wantRuns := []ben.Run{
+		{Name: "crypto/sha256.BenchmarkHash8Bytes", Iterations: 5000000, NanoSecsPerOp: 254, MegaBytesPerSec: 31.42, Parallelism: 4},
+		{Name: "crypto/sha256.BenchmarkHash1K", Iterations: 500000, NanoSecsPerOp: 2388, MegaBytesPerSec: 428.74, Parallelism: 4},
+		{Name: "v.io/v23/security.BenchmarkSign/ecdsa", Iterations: 20000, NanoSecsPerOp: 61234.5, AllocedBytesPerOp: 4321, AllocsPerOp: 56, Parallelism: 4},
+		{Name: "v.io/v23/security.BenchmarkVerify", Iterations: 10000, NanoSecsPerOp: 123456, Parallelism: 1, Metrics: map[string]float64{"p99-ns": 150000, "verifies/s": 8100}},
+	}
+	if !reflect.DeepEqual(runs, wantRuns) {
+		t.Errorf("got %#v, want %#v", runs, wantRuns)
+	}
+}
+
+func TestParseEnvironment(t *testing.T) {
+	output := `
+go: go1.22.1
+cores: 8
+memory: 17179869184
+kernel: 5.15.0-91-generic
+governor: performance
+tag: ci=nightly
+tag: runner=bench-1
+goos: linux
+goarch: amd64
+BenchmarkA-8   	    1000	      1234 ns/op
+BenchmarkB-8   	    1000	      5678 ns/op
+`
+	scenario, _, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ben.Environment{
+		GoVersion:   "go1.22.1",
+		GoMaxProcs:  8,
+		Cores:       8,
+		MemoryBytes: 17179869184,
+		Kernel:      "5.15.0-91-generic",
+		CpuGovernor: "performance",
+		Tags:        map[string]string{"ci": "nightly", "runner": "bench-1"},
+	}
+	if !reflect.DeepEqual(scenario.Env, want) {
+		t.Errorf("got %#v, want %#v", scenario.Env, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, output := range []string{
+		"goos: linux\ngoos: darwin\n",
+		"goarch: amd64\nBenchmarkFoo-4 100 10 ns/op\ngoarch: arm64\n",
+		"BenchmarkFoo-4 100 fast ns/op\n",
+		"BenchmarkFoo-4 100 NaN ns/op\n",
+		"BenchmarkFoo-4 100 10 ns/op +Inf MB/s\n",
+		"BenchmarkFoo-4 100 10 ns/op -Inf p99-ns\n",
+		"cores: 8\ncores: 4\n",
+		"memory: 16GB\n",
+		"tag: nightly\n",
+		"tag: ci=nightly\ntag: ci=presubmit\n",
+	} {
+		if _, _, err := Parse(strings.NewReader(output)); err == nil {
+			t.Errorf("Parse(%q) did not fail", output)
+		}
+	}
+}
@@ -0,0 +1,1621 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file was auto-generated by the vanadium vdl tool.
+// Package: groups
+
+// Package groups defines the interfaces implemented by groupsd in addition
+// to the standard v.io/v23/services/groups interfaces.
+//
+//nolint:revive
+package groups
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	v23 "v.io/v23"
+	"v.io/v23/context"
+	"v.io/v23/rpc"
+	"v.io/v23/security/access"
+	"v.io/v23/services/groups"
+	"v.io/v23/services/permissions"
+	"v.io/v23/vdl"
+	vdltime "v.io/v23/vdlroot/time"
+	"v.io/v23/verror"
+)
+
+var initializeVDLCalled = false
+var _ = initializeVDL() // Must be first; see initializeVDL comments for details.
+
+// Hold type definitions in package-level variables, for better performance.
+// Declare and initialize with default values here so that the initializeVDL
+// method will be considered ready to initialize before any of the type
+// definitions that appear below.
+//
+//nolint:unused
+var (
+	vdlTypeStruct1  *vdl.Type = nil
+	vdlTypeList2    *vdl.Type = nil
+	vdlTypeMap3     *vdl.Type = nil
+	vdlTypeString4  *vdl.Type = nil
+	vdlTypeEnum5    *vdl.Type = nil
+	vdlTypeStruct6  *vdl.Type = nil
+	vdlTypeStruct7  *vdl.Type = nil
+	vdlTypeList8    *vdl.Type = nil
+	vdlTypeStruct9  *vdl.Type = nil
+	vdlTypeStruct10 *vdl.Type = nil
+	vdlTypeStruct11 *vdl.Type = nil
+	vdlTypeList12   *vdl.Type = nil
+)
+
+// Type definitions
+// ================
+// BatchRequest describes a set of changes to be applied to a group
+// atomically. Entries in Remove are removed before entries in Add are added,
+// so an entry that appears in both is present afterwards.
+type BatchRequest struct {
+	// Add lists the entries to add to the group.
+	Add []groups.BlessingPatternChunk
+	// Remove lists the entries to remove from the group.
+	Remove []groups.BlessingPatternChunk
+	// SetPerms indicates that the group's permissions are to be replaced
+	// by Perms.
+	SetPerms bool
+	Perms    access.Permissions
+}
+
+func (BatchRequest) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/groups.BatchRequest"`
+}) {
+}
+
+func (x BatchRequest) VDLIsZero() bool { //nolint:gocyclo
+	if len(x.Add) != 0 {
+		return false
+	}
+	if len(x.Remove) != 0 {
+		return false
+	}
+	if x.SetPerms {
+		return false
+	}
+	if len(x.Perms) != 0 {
+		return false
+	}
+	return true
+}
+
+func (x BatchRequest) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct1); err != nil {
+		return err
+	}
+	if len(x.Add) != 0 {
+		if err := enc.NextField(0); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList1(enc, x.Add); err != nil {
+			return err
+		}
+	}
+	if len(x.Remove) != 0 {
+		if err := enc.NextField(1); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList1(enc, x.Remove); err != nil {
+			return err
+		}
+	}
+	if x.SetPerms {
+		if err := enc.NextFieldValueBool(2, vdl.BoolType, x.SetPerms); err != nil {
+			return err
+		}
+	}
+	if len(x.Perms) != 0 {
+		if err := enc.NextField(3); err != nil {
+			return err
+		}
+		if err := x.Perms.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonList1(enc vdl.Encoder, x []groups.BlessingPatternChunk) error {
+	if err := enc.StartValue(vdlTypeList2); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for _, elem := range x {
+		if err := enc.NextEntryValueString(vdlTypeString4, string(elem)); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *BatchRequest) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = BatchRequest{}
+	if err := dec.StartValue(vdlTypeStruct1); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct1 {
+			index = vdlTypeStruct1.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			if err := vdlReadAnonList1(dec, &x.Add); err != nil {
+				return err
+			}
+		case 1:
+			if err := vdlReadAnonList1(dec, &x.Remove); err != nil {
+				return err
+			}
+		case 2:
+			switch value, err := dec.ReadValueBool(); {
+			case err != nil:
+				return err
+			default:
+				x.SetPerms = value
+			}
+		case 3:
+			if err := x.Perms.VDLRead(dec); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonList1(dec vdl.Decoder, x *[]groups.BlessingPatternChunk) error {
+	if err := dec.StartValue(vdlTypeList2); err != nil {
+		return err
+	}
+	if len := dec.LenHint(); len > 0 {
+		*x = make([]groups.BlessingPatternChunk, 0, len)
+	} else {
+		*x = nil
+	}
+	for {
+		switch done, elem, err := dec.NextEntryValueString(); {
+		case err != nil:
+			return err
+		case done:
+			return dec.FinishValue()
+		default:
+			*x = append(*x, groups.BlessingPatternChunk(elem))
+		}
+	}
+}
+
+// ChangeType identifies the kind of change made to a group.
+type ChangeType int
+
+const (
+	ChangeTypeCreate ChangeType = iota
+	ChangeTypeDelete
+	ChangeTypeAdd
+	ChangeTypeRemove
+	ChangeTypeSetPermissions
+)
+
+// ChangeTypeAll holds all labels for ChangeType.
+var ChangeTypeAll = [...]ChangeType{ChangeTypeCreate, ChangeTypeDelete, ChangeTypeAdd, ChangeTypeRemove, ChangeTypeSetPermissions}
+
+// ChangeTypeFromString creates a ChangeType from a string label.
+//
+//nolint:unused
+func ChangeTypeFromString(label string) (x ChangeType, err error) {
+	err = x.Set(label)
+	return
+}
+
+// Set assigns label to x.
+func (x *ChangeType) Set(label string) error {
+	switch label {
+	case "Create", "create":
+		*x = ChangeTypeCreate
+		return nil
+	case "Delete", "delete":
+		*x = ChangeTypeDelete
+		return nil
+	case "Add", "add":
+		*x = ChangeTypeAdd
+		return nil
+	case "Remove", "remove":
+		*x = ChangeTypeRemove
+		return nil
+	case "SetPermissions", "setpermissions":
+		*x = ChangeTypeSetPermissions
+		return nil
+	}
+	*x = -1
+	return fmt.Errorf("unknown label %q in groups.ChangeType", label)
+}
+
+// String returns the string label of x.
+func (x ChangeType) String() string {
+	switch x {
+	case ChangeTypeCreate:
+		return "Create"
+	case ChangeTypeDelete:
+		return "Delete"
+	case ChangeTypeAdd:
+		return "Add"
+	case ChangeTypeRemove:
+		return "Remove"
+	case ChangeTypeSetPermissions:
+		return "SetPermissions"
+	}
+	return ""
+}
+
+func (ChangeType) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/groups.ChangeType"`
+	Enum struct{ Create, Delete, Add, Remove, SetPermissions string }
+}) {
+}
+
+func (x ChangeType) VDLIsZero() bool { //nolint:gocyclo
+	return x == ChangeTypeCreate
+}
+
+func (x ChangeType) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.WriteValueString(vdlTypeEnum5, x.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *ChangeType) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	switch value, err := dec.ReadValueString(); {
+	case err != nil:
+		return err
+	default:
+		if err := x.Set(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Change describes a change made to a group.
+type Change struct {
+	// Name is the name of the group, relative to the groups server.
+	Name string
+	Type ChangeType
+	// Entries lists the entries that were added or removed by Add and Remove
+	// changes, and the initial entries of the group for Create changes.
+	Entries []groups.BlessingPatternChunk
+	// Version is the version of the group after the change. It is empty for
+	// Delete changes.
+	Version string
+}
+
+func (Change) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/groups.Change"`
+}) {
+}
+
+func (x Change) VDLIsZero() bool { //nolint:gocyclo
+	if x.Name != "" {
+		return false
+	}
+	if x.Type != ChangeTypeCreate {
+		return false
+	}
+	if len(x.Entries) != 0 {
+		return false
+	}
+	if x.Version != "" {
+		return false
+	}
+	return true
+}
+
+func (x Change) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct6); err != nil {
+		return err
+	}
+	if x.Name != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Name); err != nil {
+			return err
+		}
+	}
+	if x.Type != ChangeTypeCreate {
+		if err := enc.NextFieldValueString(1, vdlTypeEnum5, x.Type.String()); err != nil {
+			return err
+		}
+	}
+	if len(x.Entries) != 0 {
+		if err := enc.NextField(2); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList1(enc, x.Entries); err != nil {
+			return err
+		}
+	}
+	if x.Version != "" {
+		if err := enc.NextFieldValueString(3, vdl.StringType, x.Version); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *Change) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = Change{}
+	if err := dec.StartValue(vdlTypeStruct6); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct6 {
+			index = vdlTypeStruct6.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Name = value
+			}
+		case 1:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				if err := x.Type.Set(value); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if err := vdlReadAnonList1(dec, &x.Entries); err != nil {
+				return err
+			}
+		case 3:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Version = value
+			}
+		}
+	}
+}
+
+// AuditRecord records a change made to a group by a caller.
+type AuditRecord struct {
+	// Blessings lists the blessing names of the caller that made the change.
+	Blessings []string
+	Timestamp time.Time
+	// OldVersion is the version of the group before the change. It is empty
+	// for Create changes. The new version is recorded in Change.
+	OldVersion string
+	Change     Change
+	// Perms is the permissions of the group after Create and SetPermissions
+	// changes.
+	Perms access.Permissions
+}
+
+func (AuditRecord) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/groups.AuditRecord"`
+}) {
+}
+
+func (x AuditRecord) VDLIsZero() bool { //nolint:gocyclo
+	if len(x.Blessings) != 0 {
+		return false
+	}
+	if !x.Timestamp.IsZero() {
+		return false
+	}
+	if x.OldVersion != "" {
+		return false
+	}
+	if !x.Change.VDLIsZero() {
+		return false
+	}
+	if len(x.Perms) != 0 {
+		return false
+	}
+	return true
+}
+
+func (x AuditRecord) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct7); err != nil {
+		return err
+	}
+	if len(x.Blessings) != 0 {
+		if err := enc.NextField(0); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList2(enc, x.Blessings); err != nil {
+			return err
+		}
+	}
+	if !x.Timestamp.IsZero() {
+		if err := enc.NextField(1); err != nil {
+			return err
+		}
+		var wire vdltime.Time
+		if err := vdltime.TimeFromNative(&wire, x.Timestamp); err != nil {
+			return err
+		}
+		if err := wire.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if x.OldVersion != "" {
+		if err := enc.NextFieldValueString(2, vdl.StringType, x.OldVersion); err != nil {
+			return err
+		}
+	}
+	if !x.Change.VDLIsZero() {
+		if err := enc.NextField(3); err != nil {
+			return err
+		}
+		if err := x.Change.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if len(x.Perms) != 0 {
+		if err := enc.NextField(4); err != nil {
+			return err
+		}
+		if err := x.Perms.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonList2(enc vdl.Encoder, x []string) error {
+	if err := enc.StartValue(vdlTypeList8); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for _, elem := range x {
+		if err := enc.NextEntryValueString(vdl.StringType, elem); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *AuditRecord) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = AuditRecord{}
+	if err := dec.StartValue(vdlTypeStruct7); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct7 {
+			index = vdlTypeStruct7.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			if err := vdlReadAnonList2(dec, &x.Blessings); err != nil {
+				return err
+			}
+		case 1:
+			var wire vdltime.Time
+			if err := wire.VDLRead(dec); err != nil {
+				return err
+			}
+			if err := vdltime.TimeToNative(wire, &x.Timestamp); err != nil {
+				return err
+			}
+		case 2:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.OldVersion = value
+			}
+		case 3:
+			if err := x.Change.VDLRead(dec); err != nil {
+				return err
+			}
+		case 4:
+			if err := x.Perms.VDLRead(dec); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonList2(dec vdl.Decoder, x *[]string) error {
+	if err := dec.StartValue(vdlTypeList8); err != nil {
+		return err
+	}
+	if len := dec.LenHint(); len > 0 {
+		*x = make([]string, 0, len)
+	} else {
+		*x = nil
+	}
+	for {
+		switch done, elem, err := dec.NextEntryValueString(); {
+		case err != nil:
+			return err
+		case done:
+			return dec.FinishValue()
+		default:
+			*x = append(*x, elem)
+		}
+	}
+}
+
+// GroupSnapshot is the state of a single group in a Snapshot.
+type GroupSnapshot struct {
+	// Name is the name of the group, relative to the groups server.
+	Name    string
+	Perms   access.Permissions
+	Entries []groups.BlessingPatternChunk
+	// Creators lists the user ids of the caller that created the group,
+	// against whose quotas the group is counted.
+	Creators []string
+	Version  string
+}
+
+func (GroupSnapshot) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/groups.GroupSnapshot"`
+}) {
+}
+
+func (x GroupSnapshot) VDLIsZero() bool { //nolint:gocyclo
+	if x.Name != "" {
+		return false
+	}
+	if len(x.Perms) != 0 {
+		return false
+	}
+	if len(x.Entries) != 0 {
+		return false
+	}
+	if len(x.Creators) != 0 {
+		return false
+	}
+	if x.Version != "" {
+		return false
+	}
+	return true
+}
+
+func (x GroupSnapshot) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct10); err != nil {
+		return err
+	}
+	if x.Name != "" {
+		if err := enc.NextFieldValueString(0, vdl.StringType, x.Name); err != nil {
+			return err
+		}
+	}
+	if len(x.Perms) != 0 {
+		if err := enc.NextField(1); err != nil {
+			return err
+		}
+		if err := x.Perms.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if len(x.Entries) != 0 {
+		if err := enc.NextField(2); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList1(enc, x.Entries); err != nil {
+			return err
+		}
+	}
+	if len(x.Creators) != 0 {
+		if err := enc.NextField(3); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList2(enc, x.Creators); err != nil {
+			return err
+		}
+	}
+	if x.Version != "" {
+		if err := enc.NextFieldValueString(4, vdl.StringType, x.Version); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *GroupSnapshot) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = GroupSnapshot{}
+	if err := dec.StartValue(vdlTypeStruct10); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct10 {
+			index = vdlTypeStruct10.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		switch index {
+		case 0:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Name = value
+			}
+		case 1:
+			if err := x.Perms.VDLRead(dec); err != nil {
+				return err
+			}
+		case 2:
+			if err := vdlReadAnonList1(dec, &x.Entries); err != nil {
+				return err
+			}
+		case 3:
+			if err := vdlReadAnonList2(dec, &x.Creators); err != nil {
+				return err
+			}
+		case 4:
+			switch value, err := dec.ReadValueString(); {
+			case err != nil:
+				return err
+			default:
+				x.Version = value
+			}
+		}
+	}
+}
+
+// Snapshot is the state of all of the groups managed by a groups server, in
+// a form that may be saved and imported into another groups server.
+type Snapshot struct {
+	Groups []GroupSnapshot
+}
+
+func (Snapshot) VDLReflect(struct {
+	Name string `vdl:"v.io/x/ref/services/groups.Snapshot"`
+}) {
+}
+
+func (x Snapshot) VDLIsZero() bool { //nolint:gocyclo
+	return len(x.Groups) == 0
+}
+
+func (x Snapshot) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
+	if err := enc.StartValue(vdlTypeStruct11); err != nil {
+		return err
+	}
+	if len(x.Groups) != 0 {
+		if err := enc.NextField(0); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonList3(enc, x.Groups); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextField(-1); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonList3(enc vdl.Encoder, x []GroupSnapshot) error {
+	if err := enc.StartValue(vdlTypeList12); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for _, elem := range x {
+		if err := enc.NextEntry(false); err != nil {
+			return err
+		}
+		if err := elem.VDLWrite(enc); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func (x *Snapshot) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
+	*x = Snapshot{}
+	if err := dec.StartValue(vdlTypeStruct11); err != nil {
+		return err
+	}
+	decType := dec.Type()
+	for {
+		index, err := dec.NextField()
+		switch {
+		case err != nil:
+			return err
+		case index == -1:
+			return dec.FinishValue()
+		}
+		if decType != vdlTypeStruct11 {
+			index = vdlTypeStruct11.FieldIndexByName(decType.Field(index).Name)
+			if index == -1 {
+				if err := dec.SkipValue(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if index == 0 {
+
+			if err := vdlReadAnonList3(dec, &x.Groups); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func vdlReadAnonList3(dec vdl.Decoder, x *[]GroupSnapshot) error {
+	if err := dec.StartValue(vdlTypeList12); err != nil {
+		return err
+	}
+	if len := dec.LenHint(); len > 0 {
+		*x = make([]GroupSnapshot, 0, len)
+	} else {
+		*x = nil
+	}
+	for {
+		switch done, err := dec.NextEntry(); {
+		case err != nil:
+			return err
+		case done:
+			return dec.FinishValue()
+		default:
+			var elem GroupSnapshot
+			if err := elem.VDLRead(dec); err != nil {
+				return err
+			}
+			*x = append(*x, elem)
+		}
+	}
+}
+
+// Error definitions
+// =================
+
+var (
+	ErrWatchLagging = verror.NewIDAction("v.io/x/ref/services/groups.WatchLagging", verror.RetryRefetch)
+	// ErrQuotaExceeded indicates that a change would exceed one of the limits
+	// configured for the groups server.
+	ErrQuotaExceeded = verror.NewIDAction("v.io/x/ref/services/groups.QuotaExceeded", verror.NoRetry)
+)
+
+// ErrorfWatchLagging calls ErrWatchLagging.Errorf with the supplied arguments.
+func ErrorfWatchLagging(ctx *context.T, format string) error {
+	return ErrWatchLagging.Errorf(ctx, format)
+}
+
+// MessageWatchLagging calls ErrWatchLagging.Message with the supplied arguments.
+func MessageWatchLagging(ctx *context.T, message string) error {
+	return ErrWatchLagging.Message(ctx, message)
+}
+
+// ParamsErrWatchLagging extracts the expected parameters from the error's ParameterList.
+func ParamsErrWatchLagging(argumentError error) (verrorComponent string, verrorOperation string, returnErr error) {
+	params := verror.Params(argumentError)
+	if params == nil {
+		returnErr = fmt.Errorf("no parameters found in: %T: %v", argumentError, argumentError)
+		return
+	}
+	iter := &paramListIterator{params: params, max: len(params)}
+
+	if verrorComponent, verrorOperation, returnErr = iter.preamble(); returnErr != nil {
+		return
+	}
+
+	return
+}
+
+// ErrorfQuotaExceeded calls ErrQuotaExceeded.Errorf with the supplied arguments.
+func ErrorfQuotaExceeded(ctx *context.T, format string) error {
+	return ErrQuotaExceeded.Errorf(ctx, format)
+}
+
+// MessageQuotaExceeded calls ErrQuotaExceeded.Message with the supplied arguments.
+func MessageQuotaExceeded(ctx *context.T, message string) error {
+	return ErrQuotaExceeded.Message(ctx, message)
+}
+
+// ParamsErrQuotaExceeded extracts the expected parameters from the error's ParameterList.
+func ParamsErrQuotaExceeded(argumentError error) (verrorComponent string, verrorOperation string, returnErr error) {
+	params := verror.Params(argumentError)
+	if params == nil {
+		returnErr = fmt.Errorf("no parameters found in: %T: %v", argumentError, argumentError)
+		return
+	}
+	iter := &paramListIterator{params: params, max: len(params)}
+
+	if verrorComponent, verrorOperation, returnErr = iter.preamble(); returnErr != nil {
+		return
+	}
+
+	return
+}
+
+type paramListIterator struct {
+	err      error
+	idx, max int
+	params   []interface{}
+}
+
+func (pl *paramListIterator) next() (interface{}, error) {
+	if pl.err != nil {
+		return nil, pl.err
+	}
+	if pl.idx+1 > pl.max {
+		pl.err = fmt.Errorf("too few parameters: have %v", pl.max)
+		return nil, pl.err
+	}
+	pl.idx++
+	return pl.params[pl.idx-1], nil
+}
+
+func (pl *paramListIterator) preamble() (component, operation string, err error) {
+	var tmp interface{}
+	if tmp, err = pl.next(); err != nil {
+		return
+	}
+	var ok bool
+	if component, ok = tmp.(string); !ok {
+		return "", "", fmt.Errorf("ParamList[0]: component name is not a string: %T", tmp)
+	}
+	if tmp, err = pl.next(); err != nil {
+		return
+	}
+	if operation, ok = tmp.(string); !ok {
+		return "", "", fmt.Errorf("ParamList[1]: operation name is not a string: %T", tmp)
+	}
+	return
+}
+
+// Interface definitions
+// =====================
+
+// GroupClientMethods is the client interface
+// containing Group methods.
+//
+// Group extends groups.Group with the additional methods implemented by
+// groupsd.
+type GroupClientMethods interface {
+	// A group's version covers its Permissions as well as any other data stored in
+	// the group. Clients should treat versions as opaque identifiers. For both Get
+	// and Relate, if version is set and matches the Group's current version, the
+	// response will indicate that fact but will otherwise be empty.
+	groups.GroupClientMethods
+	// Apply applies all of the changes in req to the group in a single
+	// update, so that either all of them or none of them take effect and the
+	// group's version changes exactly once. If version is set, Apply fails
+	// unless it matches the group's current version. Apply returns the new
+	// version of the group.
+	//
+	// Replacing the permissions additionally requires Admin access to the
+	// group.
+	Apply(_ *context.T, req BatchRequest, version string, _ ...rpc.CallOpt) (string, error)
+	// Expand returns the membership of the group as a flat set of blessing
+	// patterns, in which every reference to another group, including groups
+	// on other servers, has been replaced by that group's expanded members.
+	//
+	// visitedGroups is the set of groups already visited in a chain of Expand
+	// calls, and is used to detect cycles, as in Relate. An entry that cannot
+	// be expanded, because of a cycle or because the referenced group cannot
+	// be read, is approximated as specified by hint: Under omits the entry,
+	// while Over replaces it by the prefix of the entry that precedes the
+	// group reference. Expand returns an Approximation for every entry that
+	// was approximated, along with the version of the group.
+	//
+	// readers is the set of blessing names of the client on whose behalf a
+	// referenced group is expanded. If it is not empty, Expand fails unless
+	// readers are also granted Read access to the group. A server expanding
+	// the groups referenced by a group passes the blessing names of its
+	// caller (or the readers it was passed), so that a client cannot learn
+	// the members of a group it may not read through a group it may read.
+	Expand(_ *context.T, hint groups.ApproximationType, visitedGroups map[string]struct{}, readers map[string]struct{}, _ ...rpc.CallOpt) (patterns []groups.BlessingPatternChunk, approximations []groups.Approximation, version string, _ error)
+	// History returns the audit records of the changes made to the group,
+	// oldest first. The history of a group survives its deletion, and that of
+	// a group which has been deleted and recreated spans both groups.
+	//
+	// History requires Admin access to the group, unless the caller is an
+	// administrator of the server.
+	History(*context.T, ...rpc.CallOpt) ([]AuditRecord, error)
+	// Watch streams the changes made to the group from the time of the call
+	// onwards. If prefix is true, the changes made to every group whose name
+	// begins with the name of this group followed by "/" are streamed as
+	// well, and this group need not exist. Only changes to groups on which
+	// the caller has Resolve or Read access are sent, and their Entries are
+	// only sent to callers with Read access. A batch of changes made by Apply
+	// is sent as one Change per type of change, all with the same version.
+	//
+	// Watch fails with ErrWatchLagging if the caller does not keep up with
+	// the stream of changes; the caller should then re-read any state that
+	// it depends on and call Watch again.
+	Watch(_ *context.T, prefix bool, _ ...rpc.CallOpt) (GroupWatchClientCall, error)
+}
+
+// GroupClientStub embeds GroupClientMethods and is a
+// placeholder for additional management operations.
+type GroupClientStub interface {
+	GroupClientMethods
+}
+
+// GroupClient returns a client stub for Group.
+func GroupClient(name string) GroupClientStub {
+	return implGroupClientStub{name, groups.GroupClient(name)}
+}
+
+type implGroupClientStub struct {
+	name string
+
+	groups.GroupClientStub
+}
+
+func (c implGroupClientStub) Apply(ctx *context.T, i0 BatchRequest, i1 string, opts ...rpc.CallOpt) (o0 string, err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "Apply", []interface{}{i0, i1}, []interface{}{&o0}, opts...)
+	return
+}
+
+func (c implGroupClientStub) Expand(ctx *context.T, i0 groups.ApproximationType, i1 map[string]struct{}, i2 map[string]struct{}, opts ...rpc.CallOpt) (o0 []groups.BlessingPatternChunk, o1 []groups.Approximation, o2 string, err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "Expand", []interface{}{i0, i1, i2}, []interface{}{&o0, &o1, &o2}, opts...)
+	return
+}
+
+func (c implGroupClientStub) History(ctx *context.T, opts ...rpc.CallOpt) (o0 []AuditRecord, err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "History", nil, []interface{}{&o0}, opts...)
+	return
+}
+
+func (c implGroupClientStub) Watch(ctx *context.T, i0 bool, opts ...rpc.CallOpt) (ocall GroupWatchClientCall, err error) {
+	var call rpc.ClientCall
+	if call, err = v23.GetClient(ctx).StartCall(ctx, c.name, "Watch", []interface{}{i0}, opts...); err != nil {
+		return
+	}
+	ocall = &implGroupWatchClientCall{ClientCall: call}
+	return
+}
+
+// GroupWatchClientStream is the client stream for Group.Watch.
+type GroupWatchClientStream interface {
+	// RecvStream returns the receiver side of the Group.Watch client stream.
+	RecvStream() interface {
+		// Advance stages an item so that it may be retrieved via Value.  Returns
+		// true iff there is an item to retrieve.  Advance must be called before
+		// Value is called.  May block if an item is not available.
+		Advance() bool
+		// Value returns the item that was staged by Advance.  May panic if Advance
+		// returned false or was not called.  Never blocks.
+		Value() Change
+		// Err returns any error encountered by Advance.  Never blocks.
+		Err() error
+	}
+}
+
+// GroupWatchClientCall represents the call returned from Group.Watch.
+type GroupWatchClientCall interface {
+	GroupWatchClientStream
+	// Finish blocks until the server is done, and returns the positional return
+	// values for call.
+	//
+	// Finish returns immediately if the call has been canceled; depending on the
+	// timing the output could either be an error signaling cancelation, or the
+	// valid positional return values from the server.
+	//
+	// Calling Finish is mandatory for releasing stream resources, unless the call
+	// has been canceled or any of the other methods return an error.  Finish should
+	// be called at most once.
+	Finish() error
+}
+
+type implGroupWatchClientCall struct {
+	rpc.ClientCall
+	valRecv Change
+	errRecv error
+}
+
+func (c *implGroupWatchClientCall) RecvStream() interface {
+	Advance() bool
+	Value() Change
+	Err() error
+} {
+	return implGroupWatchClientCallRecv{c}
+}
+
+type implGroupWatchClientCallRecv struct {
+	c *implGroupWatchClientCall
+}
+
+func (c implGroupWatchClientCallRecv) Advance() bool {
+	c.c.valRecv = Change{}
+	c.c.errRecv = c.c.Recv(&c.c.valRecv)
+	return c.c.errRecv == nil
+}
+func (c implGroupWatchClientCallRecv) Value() Change {
+	return c.c.valRecv
+}
+func (c implGroupWatchClientCallRecv) Err() error {
+	if c.c.errRecv == io.EOF {
+		return nil
+	}
+	return c.c.errRecv
+}
+func (c *implGroupWatchClientCall) Finish() (err error) {
+	err = c.ClientCall.Finish()
+	return
+}
+
+// GroupServerMethods is the interface a server writer
+// implements for Group.
+//
+// Group extends groups.Group with the additional methods implemented by
+// groupsd.
+type GroupServerMethods interface {
+	// A group's version covers its Permissions as well as any other data stored in
+	// the group. Clients should treat versions as opaque identifiers. For both Get
+	// and Relate, if version is set and matches the Group's current version, the
+	// response will indicate that fact but will otherwise be empty.
+	groups.GroupServerMethods
+	// Apply applies all of the changes in req to the group in a single
+	// update, so that either all of them or none of them take effect and the
+	// group's version changes exactly once. If version is set, Apply fails
+	// unless it matches the group's current version. Apply returns the new
+	// version of the group.
+	//
+	// Replacing the permissions additionally requires Admin access to the
+	// group.
+	Apply(_ *context.T, _ rpc.ServerCall, req BatchRequest, version string) (string, error)
+	// Expand returns the membership of the group as a flat set of blessing
+	// patterns, in which every reference to another group, including groups
+	// on other servers, has been replaced by that group's expanded members.
+	//
+	// visitedGroups is the set of groups already visited in a chain of Expand
+	// calls, and is used to detect cycles, as in Relate. An entry that cannot
+	// be expanded, because of a cycle or because the referenced group cannot
+	// be read, is approximated as specified by hint: Under omits the entry,
+	// while Over replaces it by the prefix of the entry that precedes the
+	// group reference. Expand returns an Approximation for every entry that
+	// was approximated, along with the version of the group.
+	//
+	// readers is the set of blessing names of the client on whose behalf a
+	// referenced group is expanded. If it is not empty, Expand fails unless
+	// readers are also granted Read access to the group. A server expanding
+	// the groups referenced by a group passes the blessing names of its
+	// caller (or the readers it was passed), so that a client cannot learn
+	// the members of a group it may not read through a group it may read.
+	Expand(_ *context.T, _ rpc.ServerCall, hint groups.ApproximationType, visitedGroups map[string]struct{}, readers map[string]struct{}) (patterns []groups.BlessingPatternChunk, approximations []groups.Approximation, version string, _ error)
+	// History returns the audit records of the changes made to the group,
+	// oldest first. The history of a group survives its deletion, and that of
+	// a group which has been deleted and recreated spans both groups.
+	//
+	// History requires Admin access to the group, unless the caller is an
+	// administrator of the server.
+	History(*context.T, rpc.ServerCall) ([]AuditRecord, error)
+	// Watch streams the changes made to the group from the time of the call
+	// onwards. If prefix is true, the changes made to every group whose name
+	// begins with the name of this group followed by "/" are streamed as
+	// well, and this group need not exist. Only changes to groups on which
+	// the caller has Resolve or Read access are sent, and their Entries are
+	// only sent to callers with Read access. A batch of changes made by Apply
+	// is sent as one Change per type of change, all with the same version.
+	//
+	// Watch fails with ErrWatchLagging if the caller does not keep up with
+	// the stream of changes; the caller should then re-read any state that
+	// it depends on and call Watch again.
+	Watch(_ *context.T, _ GroupWatchServerCall, prefix bool) error
+}
+
+// GroupServerStubMethods is the server interface containing
+// Group methods, as expected by rpc.Server.
+// The only difference between this interface and GroupServerMethods
+// is the streaming methods.
+type GroupServerStubMethods interface {
+	// A group's version covers its Permissions as well as any other data stored in
+	// the group. Clients should treat versions as opaque identifiers. For both Get
+	// and Relate, if version is set and matches the Group's current version, the
+	// response will indicate that fact but will otherwise be empty.
+	groups.GroupServerStubMethods
+	// Apply applies all of the changes in req to the group in a single
+	// update, so that either all of them or none of them take effect and the
+	// group's version changes exactly once. If version is set, Apply fails
+	// unless it matches the group's current version. Apply returns the new
+	// version of the group.
+	//
+	// Replacing the permissions additionally requires Admin access to the
+	// group.
+	Apply(_ *context.T, _ rpc.ServerCall, req BatchRequest, version string) (string, error)
+	// Expand returns the membership of the group as a flat set of blessing
+	// patterns, in which every reference to another group, including groups
+	// on other servers, has been replaced by that group's expanded members.
+	//
+	// visitedGroups is the set of groups already visited in a chain of Expand
+	// calls, and is used to detect cycles, as in Relate. An entry that cannot
+	// be expanded, because of a cycle or because the referenced group cannot
+	// be read, is approximated as specified by hint: Under omits the entry,
+	// while Over replaces it by the prefix of the entry that precedes the
+	// group reference. Expand returns an Approximation for every entry that
+	// was approximated, along with the version of the group.
+	//
+	// readers is the set of blessing names of the client on whose behalf a
+	// referenced group is expanded. If it is not empty, Expand fails unless
+	// readers are also granted Read access to the group. A server expanding
+	// the groups referenced by a group passes the blessing names of its
+	// caller (or the readers it was passed), so that a client cannot learn
+	// the members of a group it may not read through a group it may read.
+	Expand(_ *context.T, _ rpc.ServerCall, hint groups.ApproximationType, visitedGroups map[string]struct{}, readers map[string]struct{}) (patterns []groups.BlessingPatternChunk, approximations []groups.Approximation, version string, _ error)
+	// History returns the audit records of the changes made to the group,
+	// oldest first. The history of a group survives its deletion, and that of
+	// a group which has been deleted and recreated spans both groups.
+	//
+	// History requires Admin access to the group, unless the caller is an
+	// administrator of the server.
+	History(*context.T, rpc.ServerCall) ([]AuditRecord, error)
+	// Watch streams the changes made to the group from the time of the call
+	// onwards. If prefix is true, the changes made to every group whose name
+	// begins with the name of this group followed by "/" are streamed as
+	// well, and this group need not exist. Only changes to groups on which
+	// the caller has Resolve or Read access are sent, and their Entries are
+	// only sent to callers with Read access. A batch of changes made by Apply
+	// is sent as one Change per type of change, all with the same version.
+	//
+	// Watch fails with ErrWatchLagging if the caller does not keep up with
+	// the stream of changes; the caller should then re-read any state that
+	// it depends on and call Watch again.
+	Watch(_ *context.T, _ *GroupWatchServerCallStub, prefix bool) error
+}
+
+// GroupServerStub adds universal methods to GroupServerStubMethods.
+type GroupServerStub interface {
+	GroupServerStubMethods
+	// DescribeInterfaces the Group interfaces.
+	Describe__() []rpc.InterfaceDesc
+}
+
+// GroupServer returns a server stub for Group.
+// It converts an implementation of GroupServerMethods into
+// an object that may be used by rpc.Server.
+func GroupServer(impl GroupServerMethods) GroupServerStub {
+	stub := implGroupServerStub{
+		impl:            impl,
+		GroupServerStub: groups.GroupServer(impl),
+	}
+	// Initialize GlobState; always check the stub itself first, to handle the
+	// case where the user has the Glob method defined in their VDL source.
+	if gs := rpc.NewGlobState(stub); gs != nil {
+		stub.gs = gs
+	} else if gs := rpc.NewGlobState(impl); gs != nil {
+		stub.gs = gs
+	}
+	return stub
+}
+
+type implGroupServerStub struct {
+	impl GroupServerMethods
+	groups.GroupServerStub
+	gs *rpc.GlobState
+}
+
+func (s implGroupServerStub) Apply(ctx *context.T, call rpc.ServerCall, i0 BatchRequest, i1 string) (string, error) {
+	return s.impl.Apply(ctx, call, i0, i1)
+}
+
+func (s implGroupServerStub) Expand(ctx *context.T, call rpc.ServerCall, i0 groups.ApproximationType, i1 map[string]struct{}, i2 map[string]struct{}) ([]groups.BlessingPatternChunk, []groups.Approximation, string, error) {
+	return s.impl.Expand(ctx, call, i0, i1, i2)
+}
+
+func (s implGroupServerStub) History(ctx *context.T, call rpc.ServerCall) ([]AuditRecord, error) {
+	return s.impl.History(ctx, call)
+}
+
+func (s implGroupServerStub) Watch(ctx *context.T, call *GroupWatchServerCallStub, i0 bool) error {
+	return s.impl.Watch(ctx, call, i0)
+}
+
+func (s implGroupServerStub) Globber() *rpc.GlobState {
+	return s.gs
+}
+
+func (s implGroupServerStub) Describe__() []rpc.InterfaceDesc {
+	return []rpc.InterfaceDesc{GroupDesc, groups.GroupDesc, groups.GroupReaderDesc, permissions.ObjectDesc}
+}
+
+// GroupDesc describes the Group interface.
+var GroupDesc rpc.InterfaceDesc = descGroup
+
+// descGroup hides the desc to keep godoc clean.
+var descGroup = rpc.InterfaceDesc{
+	Name:    "Group",
+	PkgPath: "v.io/x/ref/services/groups",
+	Doc:     "// Group extends groups.Group with the additional methods implemented by\n// groupsd.",
+	Embeds: []rpc.EmbedDesc{
+		{Name: "Group", PkgPath: "v.io/v23/services/groups", Doc: "// A group's version covers its Permissions as well as any other data stored in\n// the group. Clients should treat versions as opaque identifiers. For both Get\n// and Relate, if version is set and matches the Group's current version, the\n// response will indicate that fact but will otherwise be empty."},
+	},
+	Methods: []rpc.MethodDesc{
+		{
+			Name: "Apply",
+			Doc:  "// Apply applies all of the changes in req to the group in a single\n// update, so that either all of them or none of them take effect and the\n// group's version changes exactly once. If version is set, Apply fails\n// unless it matches the group's current version. Apply returns the new\n// version of the group.\n//\n// Replacing the permissions additionally requires Admin access to the\n// group.",
+			InArgs: []rpc.ArgDesc{
+				{Name: "req", Doc: ``},     // BatchRequest
+				{Name: "version", Doc: ``}, // string
+			},
+			OutArgs: []rpc.ArgDesc{
+				{Name: "", Doc: ``}, // string
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Write"))},
+		},
+		{
+			Name: "Expand",
+			Doc:  "// Expand returns the membership of the group as a flat set of blessing\n// patterns, in which every reference to another group, including groups\n// on other servers, has been replaced by that group's expanded members.\n//\n// visitedGroups is the set of groups already visited in a chain of Expand\n// calls, and is used to detect cycles, as in Relate. An entry that cannot\n// be expanded, because of a cycle or because the referenced group cannot\n// be read, is approximated as specified by hint: Under omits the entry,\n// while Over replaces it by the prefix of the entry that precedes the\n// group reference. Expand returns an Approximation for every entry that\n// was approximated, along with the version of the group.\n//\n// readers is the set of blessing names of the client on whose behalf a\n// referenced group is expanded. If it is not empty, Expand fails unless\n// readers are also granted Read access to the group. A server expanding\n// the groups referenced by a group passes the blessing names of its\n// caller (or the readers it was passed), so that a client cannot learn\n// the members of a group it may not read through a group it may read.",
+			InArgs: []rpc.ArgDesc{
+				{Name: "hint", Doc: ``},          // groups.ApproximationType
+				{Name: "visitedGroups", Doc: ``}, // map[string]struct{}
+				{Name: "readers", Doc: ``},       // map[string]struct{}
+			},
+			OutArgs: []rpc.ArgDesc{
+				{Name: "patterns", Doc: ``},       // []groups.BlessingPatternChunk
+				{Name: "approximations", Doc: ``}, // []groups.Approximation
+				{Name: "version", Doc: ``},        // string
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Read"))},
+		},
+		{
+			Name: "History",
+			Doc:  "// History returns the audit records of the changes made to the group,\n// oldest first. The history of a group survives its deletion, and that of\n// a group which has been deleted and recreated spans both groups.\n//\n// History requires Admin access to the group, unless the caller is an\n// administrator of the server.",
+			OutArgs: []rpc.ArgDesc{
+				{Name: "", Doc: ``}, // []AuditRecord
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Admin"))},
+		},
+		{
+			Name: "Watch",
+			Doc:  "// Watch streams the changes made to the group from the time of the call\n// onwards. If prefix is true, the changes made to every group whose name\n// begins with the name of this group followed by \"/\" are streamed as\n// well, and this group need not exist. Only changes to groups on which\n// the caller has Resolve or Read access are sent, and their Entries are\n// only sent to callers with Read access. A batch of changes made by Apply\n// is sent as one Change per type of change, all with the same version.\n//\n// Watch fails with ErrWatchLagging if the caller does not keep up with\n// the stream of changes; the caller should then re-read any state that\n// it depends on and call Watch again.",
+			InArgs: []rpc.ArgDesc{
+				{Name: "prefix", Doc: ``}, // bool
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Resolve"))},
+		},
+	},
+}
+
+// GroupWatchServerStream is the server stream for Group.Watch.
+type GroupWatchServerStream interface {
+	// SendStream returns the send side of the Group.Watch server stream.
+	SendStream() interface {
+		// Send places the item onto the output stream.  Returns errors encountered
+		// while sending.  Blocks if there is no buffer space; will unblock when
+		// buffer space is available.
+		Send(item Change) error
+	}
+}
+
+// GroupWatchServerCall represents the context passed to Group.Watch.
+type GroupWatchServerCall interface {
+	rpc.ServerCall
+	GroupWatchServerStream
+}
+
+// GroupWatchServerCallStub is a wrapper that converts rpc.StreamServerCall into
+// a typesafe stub that implements GroupWatchServerCall.
+type GroupWatchServerCallStub struct {
+	rpc.StreamServerCall
+}
+
+// Init initializes GroupWatchServerCallStub from rpc.StreamServerCall.
+func (s *GroupWatchServerCallStub) Init(call rpc.StreamServerCall) {
+	s.StreamServerCall = call
+}
+
+// SendStream returns the send side of the Group.Watch server stream.
+func (s *GroupWatchServerCallStub) SendStream() interface {
+	Send(item Change) error
+} {
+	return implGroupWatchServerCallSend{s}
+}
+
+type implGroupWatchServerCallSend struct {
+	s *GroupWatchServerCallStub
+}
+
+func (s implGroupWatchServerCallSend) Send(item Change) error {
+	return s.s.Send(item)
+}
+
+// AdminClientMethods is the client interface
+// containing Admin methods.
+//
+// Admin is implemented by the root of the namespace of a groups server, in
+// addition to Group. Its methods are restricted to the administrators of the
+// server rather than being controlled by the permissions of any group.
+type AdminClientMethods interface {
+	// Group extends groups.Group with the additional methods implemented by
+	// groupsd.
+	GroupClientMethods
+	// ExportSnapshot returns a snapshot of every group managed by the
+	// server. No changes are made to the groups while the snapshot is taken.
+	ExportSnapshot(*context.T, ...rpc.CallOpt) (Snapshot, error)
+	// ImportSnapshot writes every group in snapshot, replacing any existing
+	// group of the same name; other groups are left untouched. Quotas are not
+	// applied to imported groups. If preserveVersions is true, each group is
+	// given its version from the snapshot, which is intended for restoring a
+	// snapshot into an empty server, and importing a group that already
+	// exists fails with ErrExist; otherwise versions are assigned as if the
+	// groups were newly created or updated. ImportSnapshot is not atomic:
+	// if it fails, some of the groups may have been imported.
+	ImportSnapshot(_ *context.T, snapshot Snapshot, preserveVersions bool, _ ...rpc.CallOpt) error
+}
+
+// AdminClientStub embeds AdminClientMethods and is a
+// placeholder for additional management operations.
+type AdminClientStub interface {
+	AdminClientMethods
+}
+
+// AdminClient returns a client stub for Admin.
+func AdminClient(name string) AdminClientStub {
+	return implAdminClientStub{name, GroupClient(name)}
+}
+
+type implAdminClientStub struct {
+	name string
+
+	GroupClientStub
+}
+
+func (c implAdminClientStub) ExportSnapshot(ctx *context.T, opts ...rpc.CallOpt) (o0 Snapshot, err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "ExportSnapshot", nil, []interface{}{&o0}, opts...)
+	return
+}
+
+func (c implAdminClientStub) ImportSnapshot(ctx *context.T, i0 Snapshot, i1 bool, opts ...rpc.CallOpt) (err error) {
+	err = v23.GetClient(ctx).Call(ctx, c.name, "ImportSnapshot", []interface{}{i0, i1}, nil, opts...)
+	return
+}
+
+// AdminServerMethods is the interface a server writer
+// implements for Admin.
+//
+// Admin is implemented by the root of the namespace of a groups server, in
+// addition to Group. Its methods are restricted to the administrators of the
+// server rather than being controlled by the permissions of any group.
+type AdminServerMethods interface {
+	// Group extends groups.Group with the additional methods implemented by
+	// groupsd.
+	GroupServerMethods
+	// ExportSnapshot returns a snapshot of every group managed by the
+	// server. No changes are made to the groups while the snapshot is taken.
+	ExportSnapshot(*context.T, rpc.ServerCall) (Snapshot, error)
+	// ImportSnapshot writes every group in snapshot, replacing any existing
+	// group of the same name; other groups are left untouched. Quotas are not
+	// applied to imported groups. If preserveVersions is true, each group is
+	// given its version from the snapshot, which is intended for restoring a
+	// snapshot into an empty server, and importing a group that already
+	// exists fails with ErrExist; otherwise versions are assigned as if the
+	// groups were newly created or updated. ImportSnapshot is not atomic:
+	// if it fails, some of the groups may have been imported.
+	ImportSnapshot(_ *context.T, _ rpc.ServerCall, snapshot Snapshot, preserveVersions bool) error
+}
+
+// AdminServerStubMethods is the server interface containing
+// Admin methods, as expected by rpc.Server.
+// The only difference between this interface and AdminServerMethods
+// is the streaming methods.
+type AdminServerStubMethods interface {
+	// Group extends groups.Group with the additional methods implemented by
+	// groupsd.
+	GroupServerStubMethods
+	// ExportSnapshot returns a snapshot of every group managed by the
+	// server. No changes are made to the groups while the snapshot is taken.
+	ExportSnapshot(*context.T, rpc.ServerCall) (Snapshot, error)
+	// ImportSnapshot writes every group in snapshot, replacing any existing
+	// group of the same name; other groups are left untouched. Quotas are not
+	// applied to imported groups. If preserveVersions is true, each group is
+	// given its version from the snapshot, which is intended for restoring a
+	// snapshot into an empty server, and importing a group that already
+	// exists fails with ErrExist; otherwise versions are assigned as if the
+	// groups were newly created or updated. ImportSnapshot is not atomic:
+	// if it fails, some of the groups may have been imported.
+	ImportSnapshot(_ *context.T, _ rpc.ServerCall, snapshot Snapshot, preserveVersions bool) error
+}
+
+// AdminServerStub adds universal methods to AdminServerStubMethods.
+type AdminServerStub interface {
+	AdminServerStubMethods
+	// DescribeInterfaces the Admin interfaces.
+	Describe__() []rpc.InterfaceDesc
+}
+
+// AdminServer returns a server stub for Admin.
+// It converts an implementation of AdminServerMethods into
+// an object that may be used by rpc.Server.
+func AdminServer(impl AdminServerMethods) AdminServerStub {
+	stub := implAdminServerStub{
+		impl:            impl,
+		GroupServerStub: GroupServer(impl),
+	}
+	// Initialize GlobState; always check the stub itself first, to handle the
+	// case where the user has the Glob method defined in their VDL source.
+	if gs := rpc.NewGlobState(stub); gs != nil {
+		stub.gs = gs
+	} else if gs := rpc.NewGlobState(impl); gs != nil {
+		stub.gs = gs
+	}
+	return stub
+}
+
+type implAdminServerStub struct {
+	impl AdminServerMethods
+	GroupServerStub
+	gs *rpc.GlobState
+}
+
+func (s implAdminServerStub) ExportSnapshot(ctx *context.T, call rpc.ServerCall) (Snapshot, error) {
+	return s.impl.ExportSnapshot(ctx, call)
+}
+
+func (s implAdminServerStub) ImportSnapshot(ctx *context.T, call rpc.ServerCall, i0 Snapshot, i1 bool) error {
+	return s.impl.ImportSnapshot(ctx, call, i0, i1)
+}
+
+func (s implAdminServerStub) Globber() *rpc.GlobState {
+	return s.gs
+}
+
+func (s implAdminServerStub) Describe__() []rpc.InterfaceDesc {
+	return []rpc.InterfaceDesc{AdminDesc, GroupDesc, groups.GroupDesc, groups.GroupReaderDesc, permissions.ObjectDesc}
+}
+
+// AdminDesc describes the Admin interface.
+var AdminDesc rpc.InterfaceDesc = descAdmin
+
+// descAdmin hides the desc to keep godoc clean.
+var descAdmin = rpc.InterfaceDesc{
+	Name:    "Admin",
+	PkgPath: "v.io/x/ref/services/groups",
+	Doc:     "// Admin is implemented by the root of the namespace of a groups server, in\n// addition to Group. Its methods are restricted to the administrators of the\n// server rather than being controlled by the permissions of any group.",
+	Embeds: []rpc.EmbedDesc{
+		{Name: "Group", PkgPath: "v.io/x/ref/services/groups", Doc: "// Group extends groups.Group with the additional methods implemented by\n// groupsd."},
+	},
+	Methods: []rpc.MethodDesc{
+		{
+			Name: "ExportSnapshot",
+			Doc:  "// ExportSnapshot returns a snapshot of every group managed by the\n// server. No changes are made to the groups while the snapshot is taken.",
+			OutArgs: []rpc.ArgDesc{
+				{Name: "", Doc: ``}, // Snapshot
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Admin"))},
+		},
+		{
+			Name: "ImportSnapshot",
+			Doc:  "// ImportSnapshot writes every group in snapshot, replacing any existing\n// group of the same name; other groups are left untouched. Quotas are not\n// applied to imported groups. If preserveVersions is true, each group is\n// given its version from the snapshot, which is intended for restoring a\n// snapshot into an empty server, and importing a group that already\n// exists fails with ErrExist; otherwise versions are assigned as if the\n// groups were newly created or updated. ImportSnapshot is not atomic:\n// if it fails, some of the groups may have been imported.",
+			InArgs: []rpc.ArgDesc{
+				{Name: "snapshot", Doc: ``},         // Snapshot
+				{Name: "preserveVersions", Doc: ``}, // bool
+			},
+			Tags: []*vdl.Value{vdl.ValueOf(access.Tag("Admin"))},
+		},
+	},
+}
+
+// initializeVDL performs vdl initialization.  It is safe to call multiple times.
+// If you have an init ordering issue, just insert the following line verbatim
+// into your source files in this package, right after the "package foo" clause:
+//
+//	var _ = initializeVDL()
+//
+// The purpose of this function is to ensure that vdl initialization occurs in
+// the right order, and very early in the init sequence.  In particular, vdl
+// registration and package variable initialization needs to occur before
+// functions like vdl.TypeOf will work properly.
+//
+// This function returns a dummy value, so that it can be used to initialize the
+// first var in the file, to take advantage of Go's defined init order.
+func initializeVDL() struct{} {
+	if initializeVDLCalled {
+		return struct{}{}
+	}
+	initializeVDLCalled = true
+
+	// Register types.
+	vdl.Register((*BatchRequest)(nil))
+	vdl.Register((*ChangeType)(nil))
+	vdl.Register((*Change)(nil))
+	vdl.Register((*AuditRecord)(nil))
+	vdl.Register((*GroupSnapshot)(nil))
+	vdl.Register((*Snapshot)(nil))
+
+	// Initialize type definitions.
+	vdlTypeStruct1 = vdl.TypeOf((*BatchRequest)(nil)).Elem()
+	vdlTypeList2 = vdl.TypeOf((*[]groups.BlessingPatternChunk)(nil))
+	vdlTypeMap3 = vdl.TypeOf((*access.Permissions)(nil))
+	vdlTypeString4 = vdl.TypeOf((*groups.BlessingPatternChunk)(nil))
+	vdlTypeEnum5 = vdl.TypeOf((*ChangeType)(nil))
+	vdlTypeStruct6 = vdl.TypeOf((*Change)(nil)).Elem()
+	vdlTypeStruct7 = vdl.TypeOf((*AuditRecord)(nil)).Elem()
+	vdlTypeList8 = vdl.TypeOf((*[]string)(nil))
+	vdlTypeStruct9 = vdl.TypeOf((*vdltime.Time)(nil)).Elem()
+	vdlTypeStruct10 = vdl.TypeOf((*GroupSnapshot)(nil)).Elem()
+	vdlTypeStruct11 = vdl.TypeOf((*Snapshot)(nil)).Elem()
+	vdlTypeList12 = vdl.TypeOf((*[]GroupSnapshot)(nil))
+
+	return struct{}{}
+}
@@ -19,7 +19,15 @@ The groups commands are:
 	add         Adds a blessing pattern to a group
 	remove      Removes a blessing pattern from a group
 	relate      Relate a set of blessing to a group
+	expand      Expands the membership of a group
 	get         Returns entries of a group
+	list        Lists the groups under a name
+	getperms    Returns the permissions of a group
+	setperms    Sets the permissions of a group
+	apply       Applies a batch of changes to a group
+	history     Returns the audit log of a group
+	export      Exports a snapshot of all groups
+	import      Imports a snapshot of groups
 	help        Display help for commands or topics
 
 The global flags are:
@@ -177,6 +185,26 @@ The groups relate flags are:
 	-version=
 	  Identifies group version
 
+# Groups expand - Expands the membership of a group
+
+Expands the membership of a group into a flat set of blessing patterns, by
+replacing every reference to another group, including groups on other servers,
+with the expanded members of that group. Entries that cannot be expanded, for
+example because they are part of a cycle of group references or refer to groups
+that cannot be read, are approximated, and the reasons are reported. The result
+is returned as a JSON-encoded output.
+
+Usage:
+
+	groups expand [flags] <von>
+
+<von> is the vanadium object name of the group
+
+The groups expand flags are:
+
+	-approximation=under
+	  Identifies the type of approximation to use; supported values = (under, over)
+
 # Groups get - Returns entries of a group
 
 Returns entries of a group.
@@ -187,6 +215,140 @@ Usage:
 
 <von> is the vanadium object name of the group
 
+# Groups list - Lists the groups under a name
+
+Lists the names of the groups, and of the intermediate names leading to groups,
+under a name. Only groups that the caller has Resolve or Read access to are
+listed.
+
+Usage:
+
+	groups list [flags] <von> [<pattern>]
+
+<von> is the vanadium object name under which to list groups
+
+<pattern> is the glob pattern to match relative to <von>; defaults to "*"
+
+# Groups getperms - Returns the permissions of a group
+
+Returns the permissions of a group, along with the version of the group, as
+JSON-encoded output.
+
+Usage:
+
+	groups getperms [flags] <von>
+
+<von> is the vanadium object name of the group
+
+# Groups setperms - Sets the permissions of a group
+
+Sets the permissions of a group. The permissions are read as JSON, in the same
+format as the file passed to "create -permissions". If --version is set, the
+update fails unless it matches the current version of the group, as returned by
+getperms.
+
+Usage:
+
+	groups setperms [flags] <von> <file>
+
+<von> is the vanadium object name of the group
+
+<file> is the path to a permissions file, or "-" to read it from stdin
+
+The groups setperms flags are:
+
+	-version=
+	  Identifies group version
+
+# Groups apply - Applies a batch of changes to a group
+
+Applies a batch of changes to a group atomically, so that either all of them or
+none of them take effect. The changes are read as a JSON object of the form:
+
+	{
+	  "Add": ["pattern", ...],
+	  "Remove": ["pattern", ...],
+	  "Permissions": {...}
+	}
+
+Entries in "Remove" are removed before entries in "Add" are added. If
+"Permissions" is present, it replaces the permissions of the group, which
+requires Admin access. All fields are optional. The new version of the group is
+printed on success.
+
+Usage:
+
+	groups apply [flags] <von> <file>
+
+<von> is the vanadium object name of the group
+
+<file> is the path to the file describing the changes, or "-" to read it from
+stdin
+
+The groups apply flags are:
+
+	-version=
+	  Identifies group version
+
+# Groups history - Returns the audit log of a group
+
+Returns the audit log of the changes made to a group, oldest first, one change
+per line. Each line shows the time of the change, the change itself, the
+blessing names of the caller that made it, and the versions of the group before
+and after it. The history of a group is kept after the group is deleted.
+
+Requires Admin access to the group, or administrative access to the server.
+
+Usage:
+
+	groups history [flags] <von>
+
+<von> is the vanadium object name of the group
+
+# Groups export - Exports a snapshot of all groups
+
+Exports a snapshot of the name, permissions, entries and version of every group
+managed by a groups server. The snapshot may be restored with "import". Requires
+administrative access to the server.
+
+Usage:
+
+	groups export [flags] <von> <file>
+
+<von> is the vanadium object name of the groups server
+
+<file> is the path to write the snapshot to, or "-" to write it to stdout
+
+The groups export flags are:
+
+	-format=json
+	  Identifies the snapshot encoding; supported values = (json, vom)
+
+# Groups import - Imports a snapshot of groups
+
+Imports a snapshot written by "export" into a groups server. Every group in the
+snapshot replaces any existing group of the same name; other groups are left
+untouched, and the groups are given new versions. With --preserve-versions, the
+groups are instead given the versions in the snapshot, which is only possible
+for groups that do not exist yet, e.g. when restoring a snapshot into an empty
+server. Requires administrative access to the server.
+
+Usage:
+
+	groups import [flags] <von> <file>
+
+<von> is the vanadium object name of the groups server
+
+<file> is the path to the snapshot, or "-" to read it from stdin
+
+The groups import flags are:
+
+	-format=json
+	  Identifies the snapshot encoding; supported values = (json, vom)
+	-preserve-versions=false
+	  Whether to give groups their versions from the snapshot, which fails for
+	  groups that already exist
+
 # Groups help - Display help for commands or topics
 
 Help with no args displays the usage of the parent command.
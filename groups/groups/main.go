@@ -10,11 +10,18 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"time"
 
+	wire "github.com/vanadium/services/groups"
+	v23 "v.io/v23"
 	"v.io/v23/context"
+	"v.io/v23/naming"
 	"v.io/v23/security/access"
 	"v.io/v23/services/groups"
+	"v.io/v23/vom"
 	"v.io/x/lib/cmdline"
 	"v.io/x/lib/set"
 	"v.io/x/ref/lib/v23cmd"
@@ -27,10 +34,31 @@ type relateResult struct {
 	Version        string
 }
 
+type expandResult struct {
+	Patterns       []groups.BlessingPatternChunk
+	Approximations []groups.Approximation
+	Version        string
+}
+
+type permissionsResult struct {
+	Permissions access.Permissions
+	Version     string
+}
+
+// applyRequest is the JSON-encoded contents of the file read by the "apply"
+// command. If Permissions is set, it replaces the group's permissions.
+type applyRequest struct {
+	Add         []groups.BlessingPatternChunk
+	Remove      []groups.BlessingPatternChunk
+	Permissions access.Permissions
+}
+
 var (
 	flagPermFile      string
 	flagVersion       string
 	flagApproximation string
+	flagFormat        string
+	flagPreserve      bool
 
 	cmdCreate = &cmdline.Command{
 		Name:     "create",
@@ -184,6 +212,54 @@ issued by the authorization logic.
 		}),
 	}
 
+	cmdExpand = &cmdline.Command{
+		Name:  "expand",
+		Short: "Expands the membership of a group",
+		Long: `
+Expands the membership of a group into a flat set of blessing patterns, by
+replacing every reference to another group, including groups on other
+servers, with the expanded members of that group. Entries that cannot be
+expanded, for example because they are part of a cycle of group references or
+refer to groups that cannot be read, are approximated, and the reasons are
+reported. The result is returned as a JSON-encoded output.
+`,
+		ArgsName: "<von>",
+		ArgsLong: "<von> is the vanadium object name of the group",
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 1, len(args); want != got {
+				return env.UsageErrorf("expand: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von := args[0]
+			// Process command-line flags.
+			var hint groups.ApproximationType
+			switch flagApproximation {
+			case "under":
+				hint = groups.ApproximationTypeUnder
+			case "over":
+				hint = groups.ApproximationTypeOver
+			}
+			// Invoke the "expand" RPC. The group itself is visited, so that
+			// references back to it are detected as cycles.
+			client := wire.GroupClient(von)
+			patterns, approximations, version, err := client.Expand(ctx, hint, set.String.FromSlice([]string{von}), nil)
+			if err != nil {
+				return err
+			}
+			result := expandResult{
+				Patterns:       patterns,
+				Approximations: approximations,
+				Version:        version,
+			}
+			bytes, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("MarshalIndent(%v) failed: %v", result, err)
+			}
+			fmt.Fprintf(env.Stdout, "%v\n", string(bytes))
+			return nil
+		}),
+	}
+
 	cmdGet = &cmdline.Command{
 		Name:     "get",
 		Short:    "Returns entries of a group",
@@ -197,11 +273,313 @@ issued by the authorization logic.
 		}),
 	}
 
+	cmdList = &cmdline.Command{
+		Name:  "list",
+		Short: "Lists the groups under a name",
+		Long: `
+Lists the names of the groups, and of the intermediate names leading to
+groups, under a name. Only groups that the caller has Resolve or Read access
+to are listed.
+`,
+		ArgsName: "<von> [<pattern>]",
+		ArgsLong: `
+<von> is the vanadium object name under which to list groups
+
+<pattern> is the glob pattern to match relative to <von>; defaults to "*"
+`,
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if got := len(args); got != 1 && got != 2 {
+				return env.UsageErrorf("list: unexpected number of arguments, want 1 or 2, got %d", got)
+			}
+			von, pattern := args[0], "*"
+			if len(args) == 2 {
+				pattern = args[1]
+			}
+			// Glob the namespace under von.
+			ch, err := v23.GetNamespace(ctx).Glob(ctx, naming.Join(von, pattern))
+			if err != nil {
+				return err
+			}
+			var names []string
+			var globErr error
+			for res := range ch {
+				switch v := res.(type) {
+				case *naming.GlobReplyEntry:
+					names = append(names, v.Value.Name)
+				case *naming.GlobReplyError:
+					globErr = fmt.Errorf("glob of %v failed: %v", v.Value.Name, v.Value.Error)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Fprintln(env.Stdout, name)
+			}
+			return globErr
+		}),
+	}
+
+	cmdGetPerms = &cmdline.Command{
+		Name:  "getperms",
+		Short: "Returns the permissions of a group",
+		Long: `
+Returns the permissions of a group, along with the version of the group, as
+JSON-encoded output.
+`,
+		ArgsName: "<von>",
+		ArgsLong: "<von> is the vanadium object name of the group",
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 1, len(args); want != got {
+				return env.UsageErrorf("getperms: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von := args[0]
+			// Invoke the "getpermissions" RPC.
+			client := groups.GroupClient(von)
+			perms, version, err := client.GetPermissions(ctx)
+			if err != nil {
+				return err
+			}
+			result := permissionsResult{
+				Permissions: perms.Normalize(),
+				Version:     version,
+			}
+			bytes, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("MarshalIndent(%v) failed: %v", result, err)
+			}
+			fmt.Fprintf(env.Stdout, "%v\n", string(bytes))
+			return nil
+		}),
+	}
+
+	cmdSetPerms = &cmdline.Command{
+		Name:  "setperms",
+		Short: "Sets the permissions of a group",
+		Long: `
+Sets the permissions of a group. The permissions are read as JSON, in the same
+format as the file passed to "create -permissions". If --version is set, the
+update fails unless it matches the current version of the group, as returned
+by getperms.
+`,
+		ArgsName: "<von> <file>",
+		ArgsLong: `
+<von> is the vanadium object name of the group
+
+<file> is the path to a permissions file, or "-" to read it from stdin
+`,
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 2, len(args); want != got {
+				return env.UsageErrorf("setperms: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von, path := args[0], args[1]
+			r, err := openInput(env, path)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			permissions, err := access.ReadPermissions(r)
+			if err != nil {
+				return err
+			}
+			// Invoke the "setpermissions" RPC.
+			client := groups.GroupClient(von)
+			return client.SetPermissions(ctx, permissions, flagVersion)
+		}),
+	}
+
+	cmdApply = &cmdline.Command{
+		Name:  "apply",
+		Short: "Applies a batch of changes to a group",
+		Long: `
+Applies a batch of changes to a group atomically, so that either all of them
+or none of them take effect. The changes are read as a JSON object of the form:
+
+  {
+    "Add": ["pattern", ...],
+    "Remove": ["pattern", ...],
+    "Permissions": {...}
+  }
+
+Entries in "Remove" are removed before entries in "Add" are added. If
+"Permissions" is present, it replaces the permissions of the group, which
+requires Admin access. All fields are optional. The new version of the group
+is printed on success.
+`,
+		ArgsName: "<von> <file>",
+		ArgsLong: `
+<von> is the vanadium object name of the group
+
+<file> is the path to the file describing the changes, or "-" to read it from
+stdin
+`,
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 2, len(args); want != got {
+				return env.UsageErrorf("apply: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von, path := args[0], args[1]
+			r, err := openInput(env, path)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			var changes applyRequest
+			if err := json.NewDecoder(r).Decode(&changes); err != nil {
+				return fmt.Errorf("failed to decode %v: %v", path, err)
+			}
+			req := wire.BatchRequest{
+				Add:      changes.Add,
+				Remove:   changes.Remove,
+				SetPerms: changes.Permissions != nil,
+				Perms:    changes.Permissions,
+			}
+			// Invoke the "apply" RPC.
+			client := wire.GroupClient(von)
+			version, err := client.Apply(ctx, req, flagVersion)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(env.Stdout, version)
+			return nil
+		}),
+	}
+
+	cmdHistory = &cmdline.Command{
+		Name:  "history",
+		Short: "Returns the audit log of a group",
+		Long: `
+Returns the audit log of the changes made to a group, oldest first, one change
+per line. Each line shows the time of the change, the change itself, the
+blessing names of the caller that made it, and the versions of the group
+before and after it. The history of a group is kept after the group is
+deleted.
+
+Requires Admin access to the group, or administrative access to the server.
+`,
+		ArgsName: "<von>",
+		ArgsLong: "<von> is the vanadium object name of the group",
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 1, len(args); want != got {
+				return env.UsageErrorf("history: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von := args[0]
+			// Invoke the "history" RPC.
+			client := wire.GroupClient(von)
+			records, err := client.History(ctx)
+			if err != nil {
+				return err
+			}
+			for _, r := range records {
+				line := fmt.Sprintf("%v %v", r.Timestamp.UTC().Format(time.RFC3339), r.Change.Type)
+				if len(r.Change.Entries) > 0 {
+					line += fmt.Sprintf(" %v", r.Change.Entries)
+				}
+				if r.Perms != nil {
+					bytes, err := json.Marshal(r.Perms.Normalize())
+					if err != nil {
+						return fmt.Errorf("Marshal(%v) failed: %v", r.Perms, err)
+					}
+					line += " " + string(bytes)
+				}
+				fmt.Fprintf(env.Stdout, "%v by %v (version %q -> %q)\n", line, r.Blessings, r.OldVersion, r.Change.Version)
+			}
+			return nil
+		}),
+	}
+
+	cmdExport = &cmdline.Command{
+		Name:  "export",
+		Short: "Exports a snapshot of all groups",
+		Long: `
+Exports a snapshot of the name, permissions, entries and version of every
+group managed by a groups server. The snapshot may be restored with "import".
+Requires administrative access to the server.
+`,
+		ArgsName: "<von> <file>",
+		ArgsLong: `
+<von> is the vanadium object name of the groups server
+
+<file> is the path to write the snapshot to, or "-" to write it to stdout
+`,
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 2, len(args); want != got {
+				return env.UsageErrorf("export: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von, path := args[0], args[1]
+			// Process command-line flags.
+			if err := checkFormat(env); err != nil {
+				return err
+			}
+			// Invoke the "exportsnapshot" RPC.
+			client := wire.AdminClient(von)
+			snapshot, err := client.ExportSnapshot(ctx)
+			if err != nil {
+				return err
+			}
+			w, err := openOutput(env, path)
+			if err != nil {
+				return err
+			}
+			if err := writeSnapshot(w, snapshot); err != nil {
+				w.Close()
+				return fmt.Errorf("failed to write %v: %v", path, err)
+			}
+			return w.Close()
+		}),
+	}
+
+	cmdImport = &cmdline.Command{
+		Name:  "import",
+		Short: "Imports a snapshot of groups",
+		Long: `
+Imports a snapshot written by "export" into a groups server. Every group in
+the snapshot replaces any existing group of the same name; other groups are
+left untouched, and the groups are given new versions. With
+--preserve-versions, the groups are instead given the versions in the
+snapshot, which is only possible for groups that do not exist yet, e.g. when
+restoring a snapshot into an empty server. Requires administrative access to
+the server.
+`,
+		ArgsName: "<von> <file>",
+		ArgsLong: `
+<von> is the vanadium object name of the groups server
+
+<file> is the path to the snapshot, or "-" to read it from stdin
+`,
+		Runner: v23cmd.RunnerFunc(func(ctx *context.T, env *cmdline.Env, args []string) error {
+			// Process command-line arguments.
+			if want, got := 2, len(args); want != got {
+				return env.UsageErrorf("import: unexpected number of arguments, want %d, got %d", want, got)
+			}
+			von, path := args[0], args[1]
+			// Process command-line flags.
+			if err := checkFormat(env); err != nil {
+				return err
+			}
+			r, err := openInput(env, path)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			snapshot, err := readSnapshot(r)
+			if err != nil {
+				return fmt.Errorf("failed to decode %v: %v", path, err)
+			}
+			// Invoke the "importsnapshot" RPC.
+			client := wire.AdminClient(von)
+			return client.ImportSnapshot(ctx, snapshot, flagPreserve)
+		}),
+	}
+
 	cmdRoot = &cmdline.Command{
 		Name:     "groups",
 		Short:    "creates and manages Vanadium groups of blessing patterns",
 		Long:     "Command groups creates and manages Vanadium groups of blessing patterns.",
-		Children: []*cmdline.Command{cmdCreate, cmdDelete, cmdAdd, cmdRemove, cmdRelate, cmdGet},
+		Children: []*cmdline.Command{cmdCreate, cmdDelete, cmdAdd, cmdRemove, cmdRelate, cmdExpand, cmdGet, cmdList, cmdGetPerms, cmdSetPerms, cmdApply, cmdHistory, cmdExport, cmdImport},
 	}
 )
 
@@ -211,9 +589,79 @@ func init() {
 	cmdAdd.Flags.StringVar(&flagVersion, "version", "", "Identifies group version")
 	cmdRemove.Flags.StringVar(&flagVersion, "version", "", "Identifies group version")
 	cmdRelate.Flags.StringVar(&flagVersion, "version", "", "Identifies group version")
-	cmdRelate.Flags.StringVar(&flagApproximation, "approximation", "under",
-		"Identifies the type of approximation to use; supported values = (under, over)",
-	)
+	cmdSetPerms.Flags.StringVar(&flagVersion, "version", "", "Identifies group version")
+	cmdApply.Flags.StringVar(&flagVersion, "version", "", "Identifies group version")
+	for _, cmd := range []*cmdline.Command{cmdRelate, cmdExpand} {
+		cmd.Flags.StringVar(&flagApproximation, "approximation", "under",
+			"Identifies the type of approximation to use; supported values = (under, over)",
+		)
+	}
+	for _, cmd := range []*cmdline.Command{cmdExport, cmdImport} {
+		cmd.Flags.StringVar(&flagFormat, "format", "json", "Identifies the snapshot encoding; supported values = (json, vom)")
+	}
+	cmdImport.Flags.BoolVar(&flagPreserve, "preserve-versions", false, "Whether to give groups their versions from the snapshot, which fails for groups that already exist")
+}
+
+func checkFormat(env *cmdline.Env) error {
+	switch flagFormat {
+	case "json", "vom":
+		return nil
+	}
+	return env.UsageErrorf("unsupported format %q", flagFormat)
+}
+
+// writeSnapshot writes snapshot to w, encoded as specified by --format.
+func writeSnapshot(w io.Writer, snapshot wire.Snapshot) error {
+	if flagFormat == "vom" {
+		return vom.NewEncoder(w).Encode(snapshot)
+	}
+	bytes, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%v\n", string(bytes))
+	return err
+}
+
+// readSnapshot reads a snapshot from r, encoded as specified by --format.
+func readSnapshot(r io.Reader) (wire.Snapshot, error) {
+	var snapshot wire.Snapshot
+	if flagFormat == "vom" {
+		return snapshot, vom.NewDecoder(r).Decode(&snapshot)
+	}
+	return snapshot, json.NewDecoder(r).Decode(&snapshot)
+}
+
+// openInput opens the named file, or stdin if path is "-".
+func openInput(env *cmdline.Env, path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(env.Stdin), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Open(%v) failed: %v", path, err)
+	}
+	return file, nil
+}
+
+// openOutput creates the named file, or returns stdout if path is "-".
+func openOutput(env *cmdline.Env, path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{env.Stdout}, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("Create(%v) failed: %v", path, err)
+	}
+	return file, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
 }
 
 func main() {
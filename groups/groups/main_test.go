@@ -11,13 +11,17 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
+	wire "github.com/vanadium/services/groups"
 	v23 "v.io/v23"
 	"v.io/v23/context"
+	"v.io/v23/glob"
 	"v.io/v23/naming"
 	"v.io/v23/rpc"
+	"v.io/v23/security"
 	"v.io/v23/security/access"
 	"v.io/v23/services/groups"
 	"v.io/x/lib/cmdline"
@@ -28,6 +32,14 @@ import (
 var group map[string]struct{}
 var buffer bytes.Buffer
 
+var testSnapshot = wire.Snapshot{Groups: []wire.GroupSnapshot{{
+	Name:     "alice/friends",
+	Perms:    access.Permissions{}.Add("alice", string(access.Admin)),
+	Entries:  []groups.BlessingPatternChunk{"bob", "carol"},
+	Creators: []string{"alice"},
+	Version:  "123",
+}}}
+
 type mock struct{}
 
 func (mock) Create(ctx *context.T, call rpc.ServerCall, perms access.Permissions, entries []groups.BlessingPatternChunk) error {
@@ -67,11 +79,64 @@ func (mock) Get(ctx *context.T, call rpc.ServerCall, req groups.GetRequest, vers
 }
 
 func (mock) SetPermissions(ctx *context.T, call rpc.ServerCall, perms access.Permissions, version string) error {
+	fmt.Fprintf(&buffer, "SetPermissions(%v, %v) was called", perms, version)
 	return nil
 }
 
 func (mock) GetPermissions(ctx *context.T, call rpc.ServerCall) (access.Permissions, string, error) {
-	return nil, "", nil
+	return access.Permissions{}.Add("alice", string(access.Admin)), "123", nil
+}
+
+func (mock) Apply(ctx *context.T, call rpc.ServerCall, req wire.BatchRequest, version string) (string, error) {
+	fmt.Fprintf(&buffer, "Apply(%v, %v) was called", req, version)
+	return "124", nil
+}
+
+func (mock) Expand(ctx *context.T, call rpc.ServerCall, hint groups.ApproximationType, visitedGroups, readers map[string]struct{}) ([]groups.BlessingPatternChunk, []groups.Approximation, string, error) {
+	fmt.Fprintf(&buffer, "Expand(%v, %v) was called", hint, len(visitedGroups))
+	return []groups.BlessingPatternChunk{"alice", "bob:phone"}, []groups.Approximation{{Reason: "r", Details: "d"}}, "123", nil
+}
+
+func (mock) History(ctx *context.T, call rpc.ServerCall) ([]wire.AuditRecord, error) {
+	return []wire.AuditRecord{
+		{
+			Blessings: []string{"alice"},
+			Timestamp: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Change:    wire.Change{Type: wire.ChangeTypeCreate, Entries: []groups.BlessingPatternChunk{"bob"}, Version: "0"},
+			Perms:     access.Permissions{}.Add("alice", string(access.Admin)),
+		},
+		{
+			Blessings:  []string{"alice", "carol"},
+			Timestamp:  time.Date(2020, 1, 2, 3, 5, 0, 0, time.UTC),
+			OldVersion: "0",
+			Change:     wire.Change{Type: wire.ChangeTypeRemove, Entries: []groups.BlessingPatternChunk{"bob"}, Version: "1"},
+		},
+	}, nil
+}
+
+func (mock) ExportSnapshot(ctx *context.T, call rpc.ServerCall) (wire.Snapshot, error) {
+	fmt.Fprintf(&buffer, "ExportSnapshot() was called")
+	return testSnapshot, nil
+}
+
+func (mock) ImportSnapshot(ctx *context.T, call rpc.ServerCall, snapshot wire.Snapshot, preserveVersions bool) error {
+	fmt.Fprintf(&buffer, "ImportSnapshot(%v, %v) was called", snapshot, preserveVersions)
+	return nil
+}
+
+func (mock) Watch(ctx *context.T, call wire.GroupWatchServerCall, prefix bool) error {
+	fmt.Fprintf(&buffer, "Watch(%v) was called", prefix)
+	return nil
+}
+
+func (mock) GlobChildren__(ctx *context.T, call rpc.GlobChildrenServerCall, matcher *glob.Element) error {
+	for _, child := range []string{"alice", "bob"} {
+		if matcher.Match(child) {
+			//nolint:errcheck
+			call.SendStream().Send(naming.GlobChildrenReplyName{Value: child})
+		}
+	}
+	return nil
 }
 
 func capitalize(s string) string {
@@ -82,11 +147,19 @@ func capitalize(s string) string {
 	return string(unicode.ToUpper(r)) + s[size:]
 }
 
+// dispatcher serves the mock for every suffix, like groupsd does for groups
+// and the root of its namespace.
+type dispatcher struct{}
+
+func (dispatcher) Lookup(_ *context.T, suffix string) (interface{}, security.Authorizer, error) {
+	return wire.AdminServer(&mock{}), nil, nil
+}
+
 func startServer(ctx *context.T, t *testing.T) (rpc.Server, naming.Endpoint) {
 	unpublished := ""
-	_, s, err := v23.WithNewServer(ctx, unpublished, groups.GroupServer(&mock{}), nil)
+	_, s, err := v23.WithNewDispatchingServer(ctx, unpublished, dispatcher{})
 	if err != nil {
-		t.Fatalf("NewServer(%v) failed: %v", unpublished, err)
+		t.Fatalf("NewDispatchingServer(%v) failed: %v", unpublished, err)
 	}
 	return s, s.Status().Endpoints[0]
 }
@@ -213,4 +286,147 @@ func TestGroupClient(t *testing.T) { //nolint:gocyclo
 		}
 		buffer.Reset()
 	}
+	// Test the "expand" command.
+	{
+		var stdout, stderr bytes.Buffer
+		env := &cmdline.Env{Stdout: &stdout, Stderr: &stderr}
+		args := []string{"expand", "-approximation=over", naming.JoinAddressName(endpoint.String(), "")}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		if got, want := buffer.String(), "Expand(Over, 1) was called"; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		var got expandResult
+		if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+			t.Fatalf("Unmarshal(%v) failed: %v", stdout.Bytes(), err)
+		}
+		want := expandResult{
+			Patterns:       []groups.BlessingPatternChunk{"alice", "bob:phone"},
+			Approximations: []groups.Approximation{{Reason: "r", Details: "d"}},
+			Version:        "123",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+		buffer.Reset()
+	}
+
+	// Test the "getperms" command.
+	{
+		var stdout, stderr bytes.Buffer
+		env := &cmdline.Env{Stdout: &stdout, Stderr: &stderr}
+		args := []string{"getperms", naming.JoinAddressName(endpoint.String(), "")}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		var got permissionsResult
+		if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+			t.Fatalf("Unmarshal(%v) failed: %v", stdout.Bytes(), err)
+		}
+		want := permissionsResult{
+			Permissions: access.Permissions{}.Add("alice", string(access.Admin)),
+			Version:     "123",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	}
+
+	// Test the "setperms" command.
+	{
+		var stdout, stderr bytes.Buffer
+		stdin := strings.NewReader(`{"Admin":{"In":["bob"]}}`)
+		env := &cmdline.Env{Stdin: stdin, Stdout: &stdout, Stderr: &stderr}
+		version := "123"
+		args := []string{"setperms", "-version=" + version, naming.JoinAddressName(endpoint.String(), ""), "-"}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		perms := access.Permissions{}.Add("bob", string(access.Admin))
+		if got, want := buffer.String(), fmt.Sprintf("SetPermissions(%v, %v) was called", perms, version); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if got, want := strings.TrimSpace(stdout.String()), ""; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		buffer.Reset()
+	}
+
+	// Test the "list" command.
+	{
+		var stdout, stderr bytes.Buffer
+		env := &cmdline.Env{Stdout: &stdout, Stderr: &stderr}
+		args := []string{"list", naming.JoinAddressName(endpoint.String(), ""), "b*"}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		if got, want := strings.TrimSpace(stdout.String()), naming.JoinAddressName(endpoint.String(), "bob"); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+	// Test the "apply" command.
+	{
+		var stdout, stderr bytes.Buffer
+		stdin := strings.NewReader(`{"Add":["alice","bob"],"Remove":["carol"],"Permissions":{"Admin":{"In":["bob"]}}}`)
+		env := &cmdline.Env{Stdin: stdin, Stdout: &stdout, Stderr: &stderr}
+		version := "123"
+		args := []string{"apply", "-version=" + version, naming.JoinAddressName(endpoint.String(), ""), "-"}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		req := wire.BatchRequest{
+			Add:      []groups.BlessingPatternChunk{"alice", "bob"},
+			Remove:   []groups.BlessingPatternChunk{"carol"},
+			SetPerms: true,
+			Perms:    access.Permissions{}.Add("bob", string(access.Admin)),
+		}
+		if got, want := buffer.String(), fmt.Sprintf("Apply(%v, %v) was called", req, version); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if got, want := strings.TrimSpace(stdout.String()), "124"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		buffer.Reset()
+	}
+
+	// Test the "history" command.
+	{
+		var stdout, stderr bytes.Buffer
+		env := &cmdline.Env{Stdout: &stdout, Stderr: &stderr}
+		args := []string{"history", naming.JoinAddressName(endpoint.String(), "")}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		want := `2020-01-02T03:04:05Z Create [bob] {"Admin":{"In":["alice"],"NotIn":null}} by [alice] (version "" -> "0")
+2020-01-02T03:05:00Z Remove [bob] by [alice carol] (version "0" -> "1")
+`
+		if got := stdout.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+
+	// Test the "export" and "import" commands, in each format.
+	for _, format := range []string{"json", "vom"} {
+		var stdout, stderr bytes.Buffer
+		env := &cmdline.Env{Stdout: &stdout, Stderr: &stderr}
+		args := []string{"export", "-format=" + format, naming.JoinAddressName(endpoint.String(), ""), "-"}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		if got, want := buffer.String(), "ExportSnapshot() was called"; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		buffer.Reset()
+
+		env = &cmdline.Env{Stdin: &stdout, Stdout: &stdout, Stderr: &stderr}
+		args = []string{"import", "-format=" + format, naming.JoinAddressName(endpoint.String(), ""), "-"}
+		if err := v23cmd.ParseAndRunForTest(cmdRoot, ctx, env, args); err != nil {
+			t.Fatalf("run failed: %v\n%v", err, stderr.String())
+		}
+		if got, want := buffer.String(), fmt.Sprintf("ImportSnapshot(%v, %v) was called", testSnapshot, false); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		buffer.Reset()
+	}
 }
@@ -25,12 +25,15 @@ var (
 	flagName    string
 	flagEngine  string
 	flagRootDir string
+	flagQuotas  lib.Quotas
 )
 
 func main() {
 	cmdGroupsD.Flags.StringVar(&flagName, "name", "", "Name to mount the groups server as.")
-	cmdGroupsD.Flags.StringVar(&flagEngine, "engine", "memstore", "Storage engine to use. Currently supported: leveldb, and memstore.")
+	cmdGroupsD.Flags.StringVar(&flagEngine, "engine", "memstore", "Storage engine to use. Currently supported: sqlite3, and memstore.")
 	cmdGroupsD.Flags.StringVar(&flagRootDir, "root-dir", "/var/lib/groupsd", "Root dir for storage engines and other data.")
+	cmdGroupsD.Flags.IntVar(&flagQuotas.MaxGroupsPerUser, "max-groups-per-user", 0, "Maximum number of groups that each user id may create. Zero means no limit.")
+	cmdGroupsD.Flags.IntVar(&flagQuotas.MaxEntriesPerGroup, "max-entries-per-group", 0, "Maximum number of entries in each group. Zero means no limit.")
 
 	cmdline.HideGlobalFlagsExcept()
 	cmdline.Main(cmdGroupsD)
@@ -49,7 +52,7 @@ v.io/v23/services/groups.Group interface.
 func runGroupsD(ctx *context.T, env *cmdline.Env, args []string) error {
 	ctx, handler := signals.ShutdownOnSignalsWithCancel(ctx)
 	defer handler.WaitForSignal()
-	dispatcher, err := lib.NewGroupsDispatcher(flagRootDir, flagEngine)
+	dispatcher, err := lib.NewGroupsDispatcher(flagRootDir, flagEngine, flagQuotas)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,53 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package audit provides logs of the changes made to groups, for use by the
+// groups server.
+package audit
+
+import (
+	"database/sql"
+	"sync"
+
+	wire "github.com/vanadium/services/groups"
+)
+
+// Log is an append-only log of audit records.
+type Log interface {
+	// Append appends r to the log of the group named by r.Change.Name. If
+	// tx is not nil, it is a transaction of the database of a store (see
+	// store.Store.Transact), and a Log that is persisted in the same
+	// database appends r in tx, so that r is only committed along with
+	// the change that it records.
+	Append(tx *sql.Tx, r wire.AuditRecord) error
+
+	// Query returns the records for the named group, in the order in which
+	// they were appended.
+	Query(name string) ([]wire.AuditRecord, error)
+}
+
+type memLog struct {
+	mu      sync.Mutex
+	records map[string][]wire.AuditRecord
+}
+
+// NewMem returns a Log that keeps its records in memory. Since records are
+// appended immediately, it should only be used with a store that is not backed
+// by a SQL database, whose transactions cannot fail to commit.
+func NewMem() Log {
+	return &memLog{records: map[string][]wire.AuditRecord{}}
+}
+
+func (l *memLog) Append(_ *sql.Tx, r wire.AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records[r.Change.Name] = append(l.records[r.Change.Name], r)
+	return nil
+}
+
+func (l *memLog) Query(name string) ([]wire.AuditRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]wire.AuditRecord(nil), l.records[name]...), nil
+}
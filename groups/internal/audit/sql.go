@@ -0,0 +1,120 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audit
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	wire "github.com/vanadium/services/groups"
+	"v.io/v23/verror"
+	"v.io/v23/vom"
+)
+
+type sqlLog struct {
+	db                    *sql.DB
+	maxSeq, insert, query *sql.Stmt
+}
+
+// NewSQL returns a Log that persists its records in the provided database,
+// creating the required table if it does not already exist. The database may
+// be shared with a sqlstore.
+//
+// Table with 3 columns:
+// (1) Name = the name of the group.
+// (2) Seq = the position of the record in the log of the group.
+// (3) Record = the VOM-encoded wire.AuditRecord.
+func NewSQL(db *sql.DB) (Log, error) {
+	if _, err := db.Exec(`
+CREATE TABLE IF NOT EXISTS GroupAudit (
+	Name VARBINARY(767) NOT NULL,
+	Seq BIGINT NOT NULL,
+	Record MEDIUMBLOB NOT NULL,
+	PRIMARY KEY (Name, Seq)
+)`); err != nil {
+		return nil, err
+	}
+	l := &sqlLog{db: db}
+	stmts := []struct {
+		stmt **sql.Stmt
+		sql  string
+	}{
+		{&l.maxSeq, "SELECT COALESCE(MAX(Seq), -1) FROM GroupAudit WHERE Name = ?"},
+		{&l.insert, "INSERT INTO GroupAudit (Name, Seq, Record) VALUES (?, ?, ?)"},
+		{&l.query, "SELECT Record FROM GroupAudit WHERE Name = ? ORDER BY Seq"},
+	}
+	for _, stmt := range stmts {
+		var err error
+		if *stmt.stmt, err = db.Prepare(stmt.sql); err != nil {
+			return nil, fmt.Errorf("failed to prepare [%s]: %v", strings.Join(strings.Fields(stmt.sql), " "), err)
+		}
+	}
+	return l, nil
+}
+
+// Append appends r in tx, which must be a transaction of the database of l, if
+// it is not nil, and in a transaction of its own otherwise.
+func (l *sqlLog) Append(tx *sql.Tx, r wire.AuditRecord) error {
+	record, err := vom.Encode(r)
+	if err != nil {
+		return convertError(err)
+	}
+	if tx != nil {
+		return l.append(tx, r.Change.Name, record)
+	}
+	if tx, err = l.db.Begin(); err != nil {
+		return convertError(err)
+	}
+	// If tx.Commit is called, then this tx.Rollback is a no-op
+	defer tx.Rollback() //nolint:errcheck
+	if err := l.append(tx, r.Change.Name, record); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+func (l *sqlLog) append(tx *sql.Tx, name string, record []byte) error {
+	// Names are bound as []byte for the same reasons as in sqlstore.
+	var seq int64
+	if err := tx.Stmt(l.maxSeq).QueryRow([]byte(name)).Scan(&seq); err != nil {
+		return convertError(err)
+	}
+	if _, err := tx.Stmt(l.insert).Exec([]byte(name), seq+1, record); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+func (l *sqlLog) Query(name string) ([]wire.AuditRecord, error) {
+	rows, err := l.query.Query([]byte(name))
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+	var records []wire.AuditRecord
+	for rows.Next() {
+		var record []byte
+		if err := rows.Scan(&record); err != nil {
+			return nil, convertError(err)
+		}
+		var r wire.AuditRecord
+		if err := vom.Decode(record, &r); err != nil {
+			return nil, convertError(err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, convertError(err)
+	}
+	return records, nil
+}
+
+func convertError(err error) error {
+	return verror.ErrUnknown.Errorf(nil, "audit: %v", err)
+}
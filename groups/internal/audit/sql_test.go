@@ -0,0 +1,95 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package audit_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	wire "github.com/vanadium/services/groups"
+	"github.com/vanadium/services/groups/internal/audit"
+)
+
+func openOrDie(t *testing.T, path string) (audit.Log, *sql.DB) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := audit.NewSQL(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, db
+}
+
+func TestSQL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.db")
+	l, db := openOrDie(t, path)
+
+	var want []wire.AuditRecord
+	for i, name := range []string{"a", "b", "a", "a"} {
+		r := wire.AuditRecord{
+			Blessings: []string{"idp:alice"},
+			Timestamp: time.Unix(int64(i), 0).UTC(),
+			Change:    wire.Change{Name: name, Type: wire.ChangeTypeAdd},
+		}
+		if err := l.Append(nil, r); err != nil {
+			t.Fatal(err)
+		}
+		if name == "a" {
+			want = append(want, r)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The records must survive reopening the database, and be returned in
+	// the order in which they were appended.
+	l, db = openOrDie(t, path)
+	defer db.Close()
+	got, err := l.Query("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, err := l.Query("c"); err != nil || len(got) != 0 {
+		t.Errorf("got %v, %v, want no records", got, err)
+	}
+}
+
+func TestSQLTx(t *testing.T) {
+	l, db := openOrDie(t, filepath.Join(t.TempDir(), "groups.db"))
+	defer db.Close()
+
+	// Records appended in a transaction are only committed with it.
+	for _, commit := range []bool{false, true} {
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := wire.AuditRecord{Change: wire.Change{Name: "a", Type: wire.ChangeTypeAdd}, Timestamp: time.Unix(0, 0).UTC()}
+		if err := l.Append(tx, r); err != nil {
+			t.Fatal(err)
+		}
+		if commit {
+			err = tx.Commit()
+		} else {
+			err = tx.Rollback()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := l.Query("a"); err != nil || len(got) != 1 {
+		t.Errorf("got %v, %v, want a single record", got, err)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+
+	wire "github.com/vanadium/services/groups"
+	"github.com/vanadium/services/groups/internal/store"
+	"v.io/v23/context"
+	"v.io/v23/rpc"
+	"v.io/v23/security"
+	"v.io/v23/services/groups"
+	"v.io/v23/verror"
+)
+
+// root is the root of the namespace of groups. It implements the
+// administrative methods in addition to those of group, which fail since the
+// root is not a group.
+type root struct {
+	group
+}
+
+var _ wire.AdminServerMethods = (*root)(nil)
+
+func (r *root) ExportSnapshot(ctx *context.T, call rpc.ServerCall) (wire.Snapshot, error) {
+	if err := r.authorizeAdmin(ctx, call.Security()); err != nil {
+		return wire.Snapshot{}, err
+	}
+	var snapshot wire.Snapshot
+	// The groups are read in a transaction, so that the snapshot is
+	// consistent.
+	if err := r.m.st.Transact(func(st store.Store, _ *sql.Tx) error {
+		names, err := st.List("")
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			var gd groupData
+			version, err := st.Get(name, &gd)
+			if err != nil {
+				return err
+			}
+			gs := wire.GroupSnapshot{
+				Name:    name,
+				Perms:   gd.Perms,
+				Entries: sortedEntries(gd.Entries),
+				Version: version,
+			}
+			for uid := range gd.Creators {
+				gs.Creators = append(gs.Creators, uid)
+			}
+			sort.Strings(gs.Creators)
+			snapshot.Groups = append(snapshot.Groups, gs)
+		}
+		return nil
+	}); err != nil {
+		return wire.Snapshot{}, verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
+	}
+	return snapshot, nil
+}
+
+func (r *root) ImportSnapshot(ctx *context.T, call rpc.ServerCall, snapshot wire.Snapshot, preserveVersions bool) error {
+	if err := r.authorizeAdmin(ctx, call.Security()); err != nil {
+		return err
+	}
+	for _, gs := range snapshot.Groups {
+		if err := validateName(ctx, gs.Name); err != nil {
+			return err
+		}
+	}
+	// The imported groups may have been created by anyone, so the group
+	// counts are reloaded from the store when next needed.
+	defer r.m.resetGroupCounts()
+	for _, gs := range snapshot.Groups {
+		if err := r.importGroup(ctx, call.Security(), gs, preserveVersions); err != nil {
+			if errors.Is(err, verror.ErrBadVersion) {
+				return verror.ErrBadArg.Errorf(ctx, "invalid version for group %v: %v", gs.Name, err)
+			}
+			if errors.Is(err, verror.ErrExist) || errors.Is(err, verror.ErrInternal) {
+				return err
+			}
+			return verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
+		}
+	}
+	return nil
+}
+
+// Internal helpers
+
+// Returns a VDL-compatible error.
+func (r *root) authorizeAdmin(ctx *context.T, call security.Call) error {
+	if err := r.m.adminAuthorizer.Authorize(ctx, call); err != nil {
+		return verror.ErrNoAccess.Errorf(ctx, "access denied: %v", err)
+	}
+	return nil
+}
+
+// importGroup writes the group described by gs to the store. It returns a
+// Store error, or a VDL-compatible error if the version of an existing group
+// would be preserved.
+func (r *root) importGroup(ctx *context.T, call security.Call, gs wire.GroupSnapshot, preserveVersion bool) error {
+	gd := groupData{
+		Perms:    gs.Perms,
+		Entries:  map[groups.BlessingPatternChunk]struct{}{},
+		Creators: map[string]struct{}{},
+	}
+	for _, entry := range gs.Entries {
+		gd.Entries[entry] = struct{}{}
+	}
+	for _, uid := range gs.Creators {
+		gd.Creators[uid] = struct{}{}
+	}
+	return r.m.write(ctx, call, func(st store.Store) ([]logEntry, error) {
+		// The existing group is read in the transaction of the write
+		// below, so that it cannot change in between.
+		var old groupData
+		oldVersion, err := st.Get(gs.Name, &old)
+		exists := err == nil
+		if err != nil && !errors.Is(err, store.ErrUnknownKey) {
+			return nil, err
+		}
+		version := gs.Version
+		switch {
+		case preserveVersion && exists:
+			// Replacing the group with an older or reused version would
+			// make clients that cache it by version miss the change.
+			return nil, verror.ErrExist.Errorf(ctx, "cannot preserve the version of existing group %v", gs.Name)
+		case preserveVersion:
+			err = st.Put(gs.Name, gd, version)
+		case exists:
+			version, err = st.Update(gs.Name, gd, oldVersion)
+		default:
+			version, err = st.Insert(gs.Name, gd)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entries []logEntry
+		if exists {
+			change := wire.Change{Name: gs.Name, Type: wire.ChangeTypeDelete}
+			entries = append(entries, logEntry{change: change, perms: old.Perms, oldVersion: oldVersion})
+		}
+		change := wire.Change{Name: gs.Name, Type: wire.ChangeTypeCreate, Entries: sortedEntries(gd.Entries), Version: version}
+		return append(entries, logEntry{change: change, perms: gd.Perms}), nil
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"strings"
+	"sync"
+
+	wire "github.com/vanadium/services/groups"
+	"v.io/v23/security/access"
+)
+
+// watchBufferSize is the number of changes that may be queued for a watcher
+// before it is considered to be lagging and is dropped.
+const watchBufferSize = 1024
+
+// logEntry is a change along with the permissions of the changed group at
+// the time of the change, which are used to filter the changes sent to each
+// watcher, and the version of the group before the change, which is recorded
+// in the audit log.
+type logEntry struct {
+	change     wire.Change
+	perms      access.Permissions
+	oldVersion string
+}
+
+// changeLog fans out the changes made to groups to the active watchers.
+type changeLog struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	// next is the sequence number of the next write to be reserved, and
+	// sent that of the next write whose changes are to be sent. pending
+	// holds the changes of the writes that have completed out of order.
+	next, sent uint64
+	pending    map[uint64][]logEntry
+}
+
+type watcher struct {
+	name   string
+	prefix bool
+	// ch is closed if the watcher falls behind and is dropped.
+	ch chan logEntry
+}
+
+func newChangeLog() *changeLog {
+	return &changeLog{watchers: map[*watcher]struct{}{}, pending: map[uint64][]logEntry{}}
+}
+
+// reserve returns the sequence number of a write that is about to be
+// committed. Writes must reserve their sequence numbers in the order in which
+// they are committed, e.g. before committing a transaction that conflicts
+// with the transactions of all other writes to the same groups, and must then
+// call send with it whether they are committed or not.
+func (l *changeLog) reserve() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	seq := l.next
+	l.next++
+	return seq
+}
+
+// send sends the changes made by the write with sequence number seq, which
+// are nil if it was not committed, to the interested watchers, once the
+// changes of all the writes that precede it have been sent, so that watchers
+// receive changes in the order in which they were committed.
+func (l *changeLog) send(seq uint64, entries []logEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending[seq] = entries
+	for {
+		entries, ok := l.pending[l.sent]
+		if !ok {
+			return
+		}
+		delete(l.pending, l.sent)
+		l.sent++
+		l.fanOut(entries)
+	}
+}
+
+// fanOut sends entries to the interested watchers. It must be called with
+// l.mu held.
+func (l *changeLog) fanOut(entries []logEntry) {
+	for w := range l.watchers {
+	sendLoop:
+		for _, e := range entries {
+			if !w.matches(e.change.Name) {
+				continue
+			}
+			select {
+			case w.ch <- e:
+			default:
+				// The watcher is lagging: drop it.
+				close(w.ch)
+				delete(l.watchers, w)
+				break sendLoop
+			}
+		}
+	}
+}
+
+// watch registers a watcher for the group with the given name, and for the
+// groups under it if prefix is true.
+func (l *changeLog) watch(name string, prefix bool) *watcher {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w := &watcher{name: name, prefix: prefix, ch: make(chan logEntry, watchBufferSize)}
+	l.watchers[w] = struct{}{}
+	return w
+}
+
+// unwatch unregisters w. It is a no-op if w has already been dropped.
+func (l *changeLog) unwatch(w *watcher) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.watchers[w]; ok {
+		close(w.ch)
+		delete(l.watchers, w)
+	}
+}
+
+func (w *watcher) matches(name string) bool {
+	if name == w.name {
+		return true
+	}
+	if !w.prefix {
+		return false
+	}
+	return w.name == "" || strings.HasPrefix(name, w.name+"/")
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	wire "github.com/vanadium/services/groups"
+	"v.io/v23/context"
+	"v.io/v23/security"
+	"v.io/v23/services/groups"
+	"v.io/v23/verror"
+)
+
+var grpRegexp = regexp.MustCompile(groups.GroupStart + "[^" + groups.GroupEnd + "]*" + groups.GroupEnd)
+
+// expander expands the entries of a group, replacing each reference to
+// another group by the members of that group.
+type expander struct {
+	hint    groups.ApproximationType
+	visited map[string]struct{}
+	// readers are the blessing names of the client on whose behalf
+	// referenced groups are expanded.
+	readers map[string]struct{}
+	// members caches the expanded members of each referenced group, or the
+	// error encountered expanding it, so that each group is expanded at
+	// most once.
+	members  map[string][]groups.BlessingPatternChunk
+	errs     map[string]error
+	patterns map[groups.BlessingPatternChunk]struct{}
+	apprxs   []groups.Approximation
+}
+
+func newExpander(hint groups.ApproximationType, visited, readers map[string]struct{}) *expander {
+	return &expander{
+		hint:     hint,
+		visited:  visited,
+		readers:  readers,
+		members:  map[string][]groups.BlessingPatternChunk{},
+		errs:     map[string]error{},
+		patterns: map[groups.BlessingPatternChunk]struct{}{},
+	}
+}
+
+// expand adds the expansion of entry to e.patterns. ctx is used to make the
+// outgoing Expand RPCs to referenced groups, which are only expanded if
+// e.readers may read them.
+func (e *expander) expand(ctx *context.T, entry groups.BlessingPatternChunk) {
+	e.expandRest(ctx, entry, "", string(entry))
+}
+
+// expandRest expands the group references in rest, which is the part of entry
+// that follows expanded, and adds the resulting patterns to e.patterns. The
+// members of referenced groups are never themselves expanded, so that a
+// misbehaving server cannot cause unbounded expansion.
+func (e *expander) expandRest(ctx *context.T, entry groups.BlessingPatternChunk, expanded, rest string) {
+	loc := grpRegexp.FindStringIndex(rest)
+	if loc == nil {
+		e.patterns[groups.BlessingPatternChunk(expanded+rest)] = struct{}{}
+		return
+	}
+	prefix := expanded + rest[:loc[0]]
+	name := rest[loc[0]+len(groups.GroupStart) : loc[1]-len(groups.GroupEnd)]
+	suffix := rest[loc[1]:]
+	if (prefix != "" && !strings.HasSuffix(prefix, security.ChainSeparator)) || (suffix != "" && !strings.HasPrefix(suffix, security.ChainSeparator)) || name == "" {
+		e.approximate(entry, prefix, verror.ErrBadArg.Errorf(ctx, "bad argument: malformed pattern: %s", entry))
+		return
+	}
+	members, err := e.expandGroup(ctx, name)
+	if err != nil {
+		e.approximate(entry, prefix, err)
+		return
+	}
+	for _, member := range members {
+		if member == groups.BlessingPatternChunk(security.AllPrincipals) {
+			// Every blessing name that begins with the prefix matches.
+			e.patterns[overApproximation(prefix)] = struct{}{}
+			continue
+		}
+		e.expandRest(ctx, entry, prefix+string(member), suffix)
+	}
+}
+
+// expandGroup returns the expanded members of the named group.
+func (e *expander) expandGroup(ctx *context.T, name string) ([]groups.BlessingPatternChunk, error) {
+	if members, ok := e.members[name]; ok {
+		return members, nil
+	}
+	if err, ok := e.errs[name]; ok {
+		return nil, err
+	}
+	members, err := e.expandRemote(ctx, name)
+	if err != nil {
+		e.errs[name] = err
+		return nil, err
+	}
+	e.members[name] = members
+	return members, nil
+}
+
+func (e *expander) expandRemote(ctx *context.T, name string) ([]groups.BlessingPatternChunk, error) {
+	if _, ok := e.visited[name]; ok {
+		return nil, groups.ErrorfCycleFound(ctx, "found cycle in group definitions %v visited %v", name, cycle(e.visited))
+	}
+	if len(e.readers) == 0 {
+		// An empty set of readers would not restrict the expansion.
+		return nil, verror.ErrNoAccess.Errorf(ctx, "access denied: no blessing names to expand %v on behalf of", name)
+	}
+	visited := make(map[string]struct{}, len(e.visited)+1)
+	for k := range e.visited {
+		visited[k] = struct{}{}
+	}
+	visited[name] = struct{}{}
+	members, apprxs, _, err := wire.GroupClient(name).Expand(ctx, e.hint, visited, e.readers)
+	if err != nil {
+		return nil, err
+	}
+	e.apprxs = append(e.apprxs, apprxs...)
+	return members, nil
+}
+
+// approximate records that entry could not be expanded because of err, and
+// approximates its expansion as specified by e.hint. prefix is the part of
+// entry that precedes the group reference that could not be expanded.
+func (e *expander) approximate(entry groups.BlessingPatternChunk, prefix string, err error) {
+	e.apprxs = append(e.apprxs, groups.Approximation{
+		Reason:  string(verror.ErrorID(err)),
+		Details: fmt.Sprintf("entry %v: %v", entry, err),
+	})
+	if e.hint == groups.ApproximationTypeOver {
+		e.patterns[overApproximation(prefix)] = struct{}{}
+	}
+}
+
+// result returns the expanded patterns in lexicographic order.
+func (e *expander) result() []groups.BlessingPatternChunk {
+	return sortedEntries(e.patterns)
+}
+
+// overApproximation returns the pattern matched by every blessing name that
+// begins with prefix, which is empty or ends with a chain separator.
+func overApproximation(prefix string) groups.BlessingPatternChunk {
+	if prefix == "" {
+		return groups.BlessingPatternChunk(security.AllPrincipals)
+	}
+	return groups.BlessingPatternChunk(strings.TrimSuffix(prefix, security.ChainSeparator))
+}
+
+func cycle(visited map[string]struct{}) string {
+	var grps []string
+	for k := range visited {
+		grps = append(grps, k)
+	}
+	sort.Strings(grps)
+	return strings.Join(grps, " -> ")
+}
@@ -4,15 +4,15 @@
 
 package server
 
-// TODO(sadovsky): Check Resolve access on parent where applicable. Relatedly,
-// convert ErrNoExist and ErrNoAccess to ErrNoExistOrNoAccess where needed to
-// preserve privacy.
-
 import (
 	"errors"
+	"sort"
 
+	wire "github.com/vanadium/services/groups"
 	"github.com/vanadium/services/groups/internal/store"
 	"v.io/v23/context"
+	"v.io/v23/conventions"
+	"v.io/v23/glob"
 	"v.io/v23/rpc"
 	"v.io/v23/security"
 	"v.io/v23/security/access"
@@ -26,11 +26,12 @@ type group struct {
 	m    *manager
 }
 
-var _ groups.GroupServerMethods = (*group)(nil)
+var _ wire.GroupServerMethods = (*group)(nil)
 
-// TODO(sadovsky): Limit the number of groups that a particular user
-// (v23/conventsions.GetClientUserId) can create?
 func (g *group) Create(ctx *context.T, call rpc.ServerCall, perms access.Permissions, entries []groups.BlessingPatternChunk) error {
+	if err := validateName(ctx, g.name); err != nil {
+		return err
+	}
 	if err := g.m.createAuthorizer.Authorize(ctx, call.Security()); err != nil {
 		return err
 	}
@@ -53,13 +54,29 @@ func (g *group) Create(ctx *context.T, call rpc.ServerCall, perms access.Permiss
 	for _, v := range entries {
 		entrySet[v] = struct{}{}
 	}
-	gd := groupData{Perms: perms, Entries: entrySet}
-	if err := g.m.st.Insert(g.name, gd); err != nil {
-		// TODO(sadovsky): We are leaking the fact that this group exists. If the
-		// client doesn't have access to this group, we should probably return an
-		// opaque error. (Reserving buckets for users will help.)
+	if err := g.m.quotas.checkEntries(ctx, len(entrySet)); err != nil {
+		return err
+	}
+	userIds := conventions.GetClientUserIds(ctx, call.Security())
+	gd := groupData{Perms: perms, Entries: entrySet, Creators: map[string]struct{}{}}
+	for _, uid := range userIds {
+		gd.Creators[uid] = struct{}{}
+	}
+	if err := g.m.createGroup(ctx, userIds, func() error {
+		return g.m.write(ctx, call.Security(), func(st store.Store) ([]logEntry, error) {
+			version, err := st.Insert(g.name, gd)
+			if err != nil {
+				return nil, err
+			}
+			change := wire.Change{Name: g.name, Type: wire.ChangeTypeCreate, Entries: sortedEntries(entrySet), Version: version}
+			return []logEntry{{change: change, perms: perms}}, nil
+		})
+	}); err != nil {
+		if errors.Is(err, wire.ErrQuotaExceeded) || errors.Is(err, verror.ErrInternal) {
+			return err
+		}
 		if errors.Is(err, store.ErrKeyExists) {
-			return verror.ErrExist.Errorf(ctx, "already exists: %s", g.name)
+			return g.hideExistence(ctx, call.Security(), verror.ErrExist.Errorf(ctx, "already exists: %s", g.name))
 		}
 		return verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
 	}
@@ -68,7 +85,15 @@ func (g *group) Create(ctx *context.T, call rpc.ServerCall, perms access.Permiss
 
 func (g *group) Delete(ctx *context.T, call rpc.ServerCall, version string) error {
 	if err := g.readModifyWrite(ctx, call.Security(), version, func(gd *groupData, versionSt string) error {
-		return g.m.st.Delete(g.name, versionSt)
+		return g.m.deleteGroup(gd, func() error {
+			return g.m.write(ctx, call.Security(), func(st store.Store) ([]logEntry, error) {
+				if err := st.Delete(g.name, versionSt); err != nil {
+					return nil, err
+				}
+				change := wire.Change{Name: g.name, Type: wire.ChangeTypeDelete}
+				return []logEntry{{change: change, perms: gd.Perms, oldVersion: versionSt}}, nil
+			})
+		})
 	}); err != nil && !errors.Is(err, verror.ErrNoExist) {
 		return err
 	}
@@ -76,17 +101,58 @@ func (g *group) Delete(ctx *context.T, call rpc.ServerCall, version string) erro
 }
 
 func (g *group) Add(ctx *context.T, call rpc.ServerCall, entry groups.BlessingPatternChunk, version string) error {
-	return g.update(ctx, call.Security(), version, func(gd *groupData) {
+	_, err := g.update(ctx, call.Security(), version, func(gd *groupData) ([]wire.Change, error) {
 		if gd.Entries == nil {
 			gd.Entries = map[groups.BlessingPatternChunk]struct{}{}
 		}
 		gd.Entries[entry] = struct{}{}
+		if err := g.m.quotas.checkEntries(ctx, len(gd.Entries)); err != nil {
+			return nil, err
+		}
+		return []wire.Change{{Type: wire.ChangeTypeAdd, Entries: []groups.BlessingPatternChunk{entry}}}, nil
 	})
+	return err
 }
 
 func (g *group) Remove(ctx *context.T, call rpc.ServerCall, entry groups.BlessingPatternChunk, version string) error {
-	return g.update(ctx, call.Security(), version, func(gd *groupData) {
+	_, err := g.update(ctx, call.Security(), version, func(gd *groupData) ([]wire.Change, error) {
 		delete(gd.Entries, entry)
+		return []wire.Change{{Type: wire.ChangeTypeRemove, Entries: []groups.BlessingPatternChunk{entry}}}, nil
+	})
+	return err
+}
+
+func (g *group) Apply(ctx *context.T, call rpc.ServerCall, req wire.BatchRequest, version string) (string, error) {
+	return g.update(ctx, call.Security(), version, func(gd *groupData) ([]wire.Change, error) {
+		var changes []wire.Change
+		if req.SetPerms {
+			if err := g.authorizeTag(ctx, call.Security(), gd.Perms, access.Admin); err != nil {
+				return nil, err
+			}
+			gd.Perms = req.Perms
+		}
+		if len(req.Remove) > 0 {
+			for _, entry := range req.Remove {
+				delete(gd.Entries, entry)
+			}
+			changes = append(changes, wire.Change{Type: wire.ChangeTypeRemove, Entries: req.Remove})
+		}
+		if len(req.Add) > 0 {
+			if gd.Entries == nil {
+				gd.Entries = map[groups.BlessingPatternChunk]struct{}{}
+			}
+			for _, entry := range req.Add {
+				gd.Entries[entry] = struct{}{}
+			}
+			changes = append(changes, wire.Change{Type: wire.ChangeTypeAdd, Entries: req.Add})
+		}
+		if err := g.m.quotas.checkEntries(ctx, len(gd.Entries)); err != nil {
+			return nil, err
+		}
+		if req.SetPerms {
+			changes = append(changes, wire.Change{Type: wire.ChangeTypeSetPermissions})
+		}
+		return changes, nil
 	})
 }
 
@@ -128,10 +194,34 @@ func (g *group) Relate(ctx *context.T, call rpc.ServerCall, blessings map[string
 	return remainder, approximations, resVersion, nil
 }
 
+func (g *group) Expand(ctx *context.T, call rpc.ServerCall, hint groups.ApproximationType, visitedGroups, readers map[string]struct{}) ([]groups.BlessingPatternChunk, []groups.Approximation, string, error) {
+	gd, version, err := g.getInternal(ctx, call.Security())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(readers) > 0 {
+		names := set.String.ToSlice(readers)
+		if !gd.Perms[string(access.Read)].Includes(names...) {
+			return nil, nil, "", verror.ErrNoAccess.Errorf(ctx, "access denied: %v does not have %v access", names, access.Read)
+		}
+	} else {
+		// Referenced groups are expanded on behalf of the caller.
+		blessings, _ := security.RemoteBlessingNames(ctx, call.Security())
+		readers = set.String.FromSlice(blessings)
+	}
+	e := newExpander(hint, visitedGroups, readers)
+	for entry := range gd.Entries {
+		e.expand(ctx, entry)
+	}
+	return e.result(), e.apprxs, version, nil
+}
+
 func (g *group) SetPermissions(ctx *context.T, call rpc.ServerCall, perms access.Permissions, version string) error {
-	return g.update(ctx, call.Security(), version, func(gd *groupData) {
+	_, err := g.update(ctx, call.Security(), version, func(gd *groupData) ([]wire.Change, error) {
 		gd.Perms = perms
+		return []wire.Change{{Type: wire.ChangeTypeSetPermissions}}, nil
 	})
+	return err
 }
 
 func (g *group) GetPermissions(ctx *context.T, call rpc.ServerCall) (perms access.Permissions, version string, err error) {
@@ -142,6 +232,59 @@ func (g *group) GetPermissions(ctx *context.T, call rpc.ServerCall) (perms acces
 	return gd.Perms, version, nil
 }
 
+func (g *group) History(ctx *context.T, call rpc.ServerCall) ([]wire.AuditRecord, error) {
+	// Administrators of the server may read the history of any group,
+	// including one that has been deleted.
+	if err := g.m.adminAuthorizer.Authorize(ctx, call.Security()); err != nil {
+		if _, _, err := g.getInternal(ctx, call.Security()); err != nil {
+			return nil, err
+		}
+	}
+	records, err := g.m.audit.Query(g.name)
+	if err != nil {
+		return nil, verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
+	}
+	return records, nil
+}
+
+func (g *group) Watch(ctx *context.T, call wire.GroupWatchServerCall, prefix bool) error {
+	// Watching a single group requires access to it. Watching a prefix does
+	// not, since the changes sent are filtered by access below.
+	if !prefix {
+		if _, _, err := g.getInternal(ctx, call.Security()); err != nil {
+			return err
+		}
+	}
+	w := g.m.log.watch(g.name, prefix)
+	defer g.m.log.unwatch(w)
+	blessings, _ := security.RemoteBlessingNames(ctx, call.Security())
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-w.ch:
+			if !ok {
+				return wire.ErrWatchLagging.Errorf(ctx, "watcher fell behind the stream of changes")
+			}
+			if !canResolve(e.perms, blessings) {
+				continue
+			}
+			change := e.change
+			// Like Get, the entries of the group require Read access.
+			if !e.perms[string(access.Read)].Includes(blessings...) {
+				change.Entries = nil
+			}
+			if err := call.SendStream().Send(change); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (g *group) GlobChildren__(ctx *context.T, call rpc.GlobChildrenServerCall, matcher *glob.Element) error {
+	return g.m.globChildren(ctx, call, g.name, matcher)
+}
+
 // Internal helpers
 
 // Returns a VDL-compatible error.
@@ -153,32 +296,76 @@ func (g *group) authorize(ctx *context.T, call security.Call, perms access.Permi
 	return nil
 }
 
+// Returns a VDL-compatible error. Checks that the caller is granted access
+// by perms for tag, regardless of the tags of the method being invoked.
+func (g *group) authorizeTag(ctx *context.T, call security.Call, perms access.Permissions, tag access.Tag) error {
+	blessings, _ := security.RemoteBlessingNames(ctx, call)
+	if !perms[string(tag)].Includes(blessings...) {
+		return verror.ErrNoAccess.Errorf(ctx, "access denied: %v does not have %v access", blessings, tag)
+	}
+	return nil
+}
+
 // Returns a VDL-compatible error. Performs access check.
 func (g *group) getInternal(ctx *context.T, call security.Call) (gd groupData, version string, err error) {
 	version, err = g.m.st.Get(g.name, &gd)
 	if err != nil {
 		if errors.Is(err, store.ErrUnknownKey) {
-			return groupData{}, "", verror.ErrNoExist.Errorf(ctx, "does not exist: %v", g.name)
+			return groupData{}, "", g.hideExistence(ctx, call, verror.ErrNoExist.Errorf(ctx, "does not exist: %v", g.name))
 		}
 		return groupData{}, "", verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
 	}
 	if err := g.authorize(ctx, call, gd.Perms); err != nil {
-		return groupData{}, "", err
+		return groupData{}, "", g.hideExistence(ctx, call, err)
 	}
 	return gd, version, nil
 }
 
+// Returns a VDL-compatible error. err should reveal whether the group exists;
+// it is replaced by ErrNoExistOrNoAccess unless the caller may resolve the
+// group's parent, so that callers cannot probe for groups that they have no
+// way of discovering.
+func (g *group) hideExistence(ctx *context.T, call security.Call, err error) error {
+	blessings, _ := security.RemoteBlessingNames(ctx, call)
+	if g.m.canResolveParent(g.name, blessings) {
+		return err
+	}
+	return verror.ErrNoExistOrNoAccess.Errorf(ctx, "does not exist or access denied: %v", g.name)
+}
+
 // Returns a VDL-compatible error. Performs access check.
-func (g *group) update(ctx *context.T, call security.Call, version string, fn func(gd *groupData)) error {
-	return g.readModifyWrite(ctx, call, version, func(gd *groupData, versionSt string) error {
-		fn(gd)
-		return g.m.st.Update(g.name, *gd, versionSt)
-	})
+// fn should modify gd and return the changes it made, without their name and
+// version, which are filled in by update. update returns the new version of
+// the group.
+func (g *group) update(ctx *context.T, call security.Call, version string, fn func(gd *groupData) ([]wire.Change, error)) (string, error) {
+	var newVersion string
+	if err := g.readModifyWrite(ctx, call, version, func(gd *groupData, versionSt string) error {
+		changes, err := fn(gd)
+		if err != nil {
+			return err
+		}
+		return g.m.write(ctx, call, func(st store.Store) ([]logEntry, error) {
+			var err error
+			if newVersion, err = st.Update(g.name, *gd, versionSt); err != nil {
+				return nil, err
+			}
+			entries := make([]logEntry, len(changes))
+			for i, change := range changes {
+				change.Name, change.Version = g.name, newVersion
+				entries[i] = logEntry{change: change, perms: gd.Perms, oldVersion: versionSt}
+			}
+			return entries, nil
+		})
+	}); err != nil {
+		return "", err
+	}
+	return newVersion, nil
 }
 
 // Returns a VDL-compatible error. Performs access check.
 // fn should perform the "modify, write" part of "read, modify, write", and
-// should return a Store error.
+// should return a Store error, or an access or quota error if it performs
+// additional checks.
 func (g *group) readModifyWrite(ctx *context.T, call security.Call, version string, fn func(gd *groupData, versionSt string) error) error {
 	// Transaction retry loop.
 	for i := 0; i < 3; i++ {
@@ -196,6 +383,10 @@ func (g *group) readModifyWrite(ctx *context.T, call security.Call, version stri
 				if version != "" {
 					return err
 				}
+			} else if errors.Is(err, verror.ErrNoAccess) || errors.Is(err, wire.ErrQuotaExceeded) || errors.Is(err, verror.ErrInternal) {
+				// Abort on access, quota or already reported internal
+				// error.
+				return err
 			} else {
 				// Abort on non-version error.
 				return verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
@@ -206,3 +397,13 @@ func (g *group) readModifyWrite(ctx *context.T, call security.Call, version stri
 	}
 	return groups.ErrExcessiveContention.Errorf(ctx, "gave up after encountering excessive contention; try again later")
 }
+
+// sortedEntries returns the entries of a group in lexicographic order.
+func sortedEntries(entries map[groups.BlessingPatternChunk]struct{}) []groups.BlessingPatternChunk {
+	var res []groups.BlessingPatternChunk
+	for entry := range entries {
+		res = append(res, entry)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
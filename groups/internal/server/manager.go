@@ -5,33 +5,193 @@
 package server
 
 import (
+	"database/sql"
+	"errors"
 	"strings"
+	"time"
 
+	wire "github.com/vanadium/services/groups"
+	"github.com/vanadium/services/groups/internal/audit"
 	"github.com/vanadium/services/groups/internal/store"
 	"v.io/v23/context"
+	"v.io/v23/glob"
+	"v.io/v23/naming"
 	"v.io/v23/rpc"
 	"v.io/v23/security"
-	"v.io/v23/services/groups"
+	"v.io/v23/security/access"
+	"v.io/v23/verror"
 )
 
+// maxNameLen is the maximum length in bytes of a group name. It matches the
+// size of the key column used by the SQL store.
+const maxNameLen = 767
+
 type manager struct {
 	st               store.Store
+	audit            audit.Log
 	createAuthorizer security.Authorizer
+	adminAuthorizer  security.Authorizer
+	log              *changeLog
+	quotas           Quotas
+	groupCounts      groupCounter
 }
 
 // NewManager returns an rpc.Dispatcher implementation for a namespace of groups.
 //
+// The changes made to the groups will be recorded in the provided audit log,
+// which must be kept in the database of st if st is backed by one (see
+// audit.Log.Append), so that no change is committed without its records.
 // The authorization policy for the creation of new groups will be controlled
-// by the provided Authorizer.
-func NewManager(st store.Store, auth security.Authorizer) rpc.Dispatcher {
-	return &manager{st: st, createAuthorizer: auth}
+// by the provided createAuth, and the policy for the administrative methods
+// implemented by the root of the namespace by adminAuth. The resources that
+// the groups may consume are limited by the provided Quotas.
+func NewManager(st store.Store, auditLog audit.Log, createAuth, adminAuth security.Authorizer, quotas Quotas) rpc.Dispatcher {
+	return &manager{st: st, audit: auditLog, createAuthorizer: createAuth, adminAuthorizer: adminAuth, log: newChangeLog(), quotas: quotas}
 }
 
-func (m *manager) Lookup(_ *context.T, suffix string) (interface{}, security.Authorizer, error) {
+func (m *manager) Lookup(ctx *context.T, suffix string) (interface{}, security.Authorizer, error) {
 	suffix = strings.TrimPrefix(suffix, "/")
-	// TODO(sadovsky): Check that suffix is a valid group name.
+	// The empty suffix names the root of the namespace, which may be globbed
+	// and administered but is not itself a group; see group.Create.
+	if suffix == "" {
+		return wire.AdminServer(&root{group{m: m}}), security.AllowEveryone(), nil
+	}
+	if err := validateName(ctx, suffix); err != nil {
+		return nil, nil, err
+	}
 	// A permissive authorizer (AllowEveryone) is used here since access
 	// control happens in the implementation of individual RPC methods. See
 	// the implementation of the group operations on the 'group' type.
-	return groups.GroupServer(&group{name: suffix, m: m}), security.AllowEveryone(), nil
+	return wire.GroupServer(&group{name: suffix, m: m}), security.AllowEveryone(), nil
+}
+
+// write calls fn with a store.Store, in which it should perform a store write
+// on behalf of the caller and return the resulting changes, and appends the
+// changes to the audit log in the same transaction as the write, so that
+// either both or neither of them are committed. If the changes are committed,
+// write sends them to the interested watchers. Neither the store write nor the
+// audit log append are serialized by write, but writes to the same group
+// conflict, so the audit records of each group are in the order in which its
+// changes were committed, which is also the order in which they are sent.
+func (m *manager) write(ctx *context.T, call security.Call, fn func(st store.Store) ([]logEntry, error)) error {
+	var (
+		entries  []logEntry
+		seq      uint64
+		reserved bool
+	)
+	err := m.st.Transact(func(st store.Store, tx *sql.Tx) error {
+		var err error
+		if entries, err = fn(st); err != nil {
+			return err
+		}
+		blessings, _ := security.RemoteBlessingNames(ctx, call)
+		now := time.Now()
+		for _, e := range entries {
+			r := wire.AuditRecord{Blessings: blessings, Timestamp: now, OldVersion: e.oldVersion, Change: e.change}
+			if e.change.Type == wire.ChangeTypeCreate || e.change.Type == wire.ChangeTypeSetPermissions {
+				r.Perms = e.perms
+			}
+			if err := m.audit.Append(tx, r); err != nil {
+				ctx.Errorf("failed to append audit record %v: %v", r, err)
+				return verror.ErrInternal.Errorf(ctx, "internal error: the change could not be audited: %v", err)
+			}
+		}
+		// The transaction holds the writes to the changed groups until
+		// it is committed, so conflicting writes reserve their sequence
+		// numbers in commit order.
+		seq, reserved = m.log.reserve(), true
+		return nil
+	})
+	if reserved {
+		if err != nil {
+			entries = nil
+		}
+		m.log.send(seq, entries)
+	}
+	return err
+}
+
+// globChildren sends the names of the immediate children of suffix that
+// match matcher. A child is only sent if it is, or is a prefix of, a group on
+// which the caller has Resolve or Read access, so that groups which the caller
+// cannot access are not revealed.
+func (m *manager) globChildren(ctx *context.T, call rpc.GlobChildrenServerCall, suffix string, matcher *glob.Element) error {
+	prefix := ""
+	if suffix != "" {
+		prefix = suffix + "/"
+	}
+	names, err := m.st.List(prefix)
+	if err != nil {
+		return verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
+	}
+	blessings, _ := security.RemoteBlessingNames(ctx, call.Security())
+	sent := map[string]bool{}
+	for _, name := range names {
+		child := strings.SplitN(strings.TrimPrefix(name, prefix), "/", 2)[0]
+		if child == "" || sent[child] || !matcher.Match(child) {
+			continue
+		}
+		var gd groupData
+		if _, err := m.st.Get(name, &gd); err != nil {
+			// The group may have been deleted since it was listed.
+			continue
+		}
+		if !canResolve(gd.Perms, blessings) {
+			continue
+		}
+		sent[child] = true
+		//nolint:errcheck
+		call.SendStream().Send(naming.GlobChildrenReplyName{Value: child})
+	}
+	return nil
+}
+
+// canResolve returns true iff blessings are granted Resolve or Read access
+// by perms.
+func canResolve(perms access.Permissions, blessings []string) bool {
+	for _, tag := range []access.Tag{access.Resolve, access.Read} {
+		if perms[string(tag)].Includes(blessings...) {
+			return true
+		}
+	}
+	return false
+}
+
+// canResolveParent returns true iff blessings are granted Resolve or Read
+// access on the parent of the named group, which is the nearest of its
+// ancestors that is itself a group. Groups without such an ancestor are
+// children of the root of the namespace, which everyone may resolve.
+func (m *manager) canResolveParent(name string, blessings []string) bool {
+	for i := strings.LastIndex(name, "/"); i > 0; i = strings.LastIndex(name[:i], "/") {
+		var gd groupData
+		if _, err := m.st.Get(name[:i], &gd); err != nil {
+			if errors.Is(err, store.ErrUnknownKey) {
+				continue
+			}
+			return false
+		}
+		return canResolve(gd.Perms, blessings)
+	}
+	return true
+}
+
+// validateName returns a VDL-compatible error if name is not a valid group
+// name. A valid name is a non-empty sequence of "/"-separated components, none
+// of which is empty, "." or "..", and is at most maxNameLen bytes long.
+func validateName(ctx *context.T, name string) error {
+	if name == "" {
+		return verror.ErrBadArg.Errorf(ctx, "invalid group name: name must not be empty")
+	}
+	if len(name) > maxNameLen {
+		return verror.ErrBadArg.Errorf(ctx, "invalid group name: name is %v bytes long, the maximum is %v", len(name), maxNameLen)
+	}
+	for _, component := range strings.Split(name, "/") {
+		switch component {
+		case "":
+			return verror.ErrBadArg.Errorf(ctx, "invalid group name %q: name must not contain empty components", name)
+		case ".", "..":
+			return verror.ErrBadArg.Errorf(ctx, "invalid group name %q: name must not contain %q components", name, component)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,137 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"errors"
+	"sync"
+
+	wire "github.com/vanadium/services/groups"
+	"github.com/vanadium/services/groups/internal/store"
+	"v.io/v23/context"
+	"v.io/v23/verror"
+)
+
+// Quotas limits the resources that may be consumed by the groups managed by a
+// groups server. A zero limit means that the resource is unlimited.
+type Quotas struct {
+	// MaxGroupsPerUser is the maximum number of groups that may be created by
+	// each user id, as determined by conventions.GetClientUserIds. A group
+	// counts against the quota of every user id of its creator.
+	MaxGroupsPerUser int
+	// MaxEntriesPerGroup is the maximum number of entries in a group.
+	MaxEntriesPerGroup int
+}
+
+// groupCounter tracks the number of groups created by each user id, so that
+// Quotas.MaxGroupsPerUser can be enforced without scanning the store on every
+// Create. The counts are loaded from the store on first use.
+type groupCounter struct {
+	mu     sync.Mutex
+	counts map[string]int // nil until loaded
+}
+
+// checkEntries returns a VDL-compatible error if a group with n entries
+// exceeds the entries quota.
+func (q Quotas) checkEntries(ctx *context.T, n int) error {
+	if q.MaxEntriesPerGroup > 0 && n > q.MaxEntriesPerGroup {
+		return wire.ErrQuotaExceeded.Errorf(ctx, "quota exceeded: groups may have at most %v entries", q.MaxEntriesPerGroup)
+	}
+	return nil
+}
+
+// createGroup calls fn, which should insert a group created by the given user
+// ids, if doing so does not exceed the groups quota of any of them. The count
+// lock is held across fn so that concurrent creates cannot exceed the quota.
+func (m *manager) createGroup(ctx *context.T, userIds []string, fn func() error) error {
+	if m.quotas.MaxGroupsPerUser <= 0 {
+		return fn()
+	}
+	c := &m.groupCounts
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := m.loadGroupCountsLocked(); err != nil {
+		return verror.ErrInternal.Errorf(ctx, "internal error: %v", err)
+	}
+	for _, uid := range userIds {
+		if c.counts[uid] >= m.quotas.MaxGroupsPerUser {
+			return wire.ErrQuotaExceeded.Errorf(ctx, "quota exceeded: %v may create at most %v groups", uid, m.quotas.MaxGroupsPerUser)
+		}
+	}
+	if err := fn(); err != nil {
+		if errors.Is(err, verror.ErrInternal) {
+			// The group may have been created regardless.
+			c.counts = nil
+		}
+		return err
+	}
+	for _, uid := range userIds {
+		c.counts[uid]++
+	}
+	return nil
+}
+
+// deleteGroup calls fn, which should delete the group gd, and releases the
+// quota used by the group if fn succeeds.
+func (m *manager) deleteGroup(gd *groupData, fn func() error) error {
+	if m.quotas.MaxGroupsPerUser <= 0 {
+		return fn()
+	}
+	c := &m.groupCounts
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := fn(); err != nil {
+		if errors.Is(err, verror.ErrInternal) {
+			// The group may have been deleted regardless.
+			c.counts = nil
+		}
+		return err
+	}
+	if c.counts == nil {
+		return nil
+	}
+	for uid := range gd.Creators {
+		if c.counts[uid]--; c.counts[uid] <= 0 {
+			delete(c.counts, uid)
+		}
+	}
+	return nil
+}
+
+func (m *manager) loadGroupCountsLocked() error {
+	c := &m.groupCounts
+	if c.counts != nil {
+		return nil
+	}
+	names, err := m.st.List("")
+	if err != nil {
+		return err
+	}
+	counts := map[string]int{}
+	for _, name := range names {
+		var gd groupData
+		if _, err := m.st.Get(name, &gd); err != nil {
+			if errors.Is(err, store.ErrUnknownKey) {
+				// The group was deleted since it was listed.
+				continue
+			}
+			return err
+		}
+		for uid := range gd.Creators {
+			counts[uid]++
+		}
+	}
+	c.counts = counts
+	return nil
+}
+
+// resetGroupCounts discards the group counts, so that they are reloaded from
+// the store when next needed.
+func (m *manager) resetGroupCounts() {
+	c := &m.groupCounts
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = nil
+}
@@ -27,7 +27,8 @@ var (
 	vdlTypeStruct1 *vdl.Type = nil
 	vdlTypeMap2    *vdl.Type = nil
 	vdlTypeSet3    *vdl.Type = nil
-	vdlTypeString4 *vdl.Type = nil
+	vdlTypeSet4    *vdl.Type = nil
+	vdlTypeString5 *vdl.Type = nil
 )
 
 // Type definitions
@@ -37,6 +38,9 @@ var (
 type groupData struct {
 	Perms   access.Permissions
 	Entries map[groups.BlessingPatternChunk]struct{}
+	// Creators is the set of user ids of the caller that created the group,
+	// against each of whose group quotas the group is counted.
+	Creators map[string]struct{}
 }
 
 func (groupData) VDLReflect(struct {
@@ -51,6 +55,9 @@ func (x groupData) VDLIsZero() bool { //nolint:gocyclo
 	if len(x.Entries) != 0 {
 		return false
 	}
+	if len(x.Creators) != 0 {
+		return false
+	}
 	return true
 }
 
@@ -74,6 +81,14 @@ func (x groupData) VDLWrite(enc vdl.Encoder) error { //nolint:gocyclo
 			return err
 		}
 	}
+	if len(x.Creators) != 0 {
+		if err := enc.NextField(2); err != nil {
+			return err
+		}
+		if err := vdlWriteAnonSet2(enc, x.Creators); err != nil {
+			return err
+		}
+	}
 	if err := enc.NextField(-1); err != nil {
 		return err
 	}
@@ -88,7 +103,25 @@ func vdlWriteAnonSet1(enc vdl.Encoder, x map[groups.BlessingPatternChunk]struct{
 		return err
 	}
 	for key := range x {
-		if err := enc.NextEntryValueString(vdlTypeString4, string(key)); err != nil {
+		if err := enc.NextEntryValueString(vdlTypeString5, string(key)); err != nil {
+			return err
+		}
+	}
+	if err := enc.NextEntry(true); err != nil {
+		return err
+	}
+	return enc.FinishValue()
+}
+
+func vdlWriteAnonSet2(enc vdl.Encoder, x map[string]struct{}) error {
+	if err := enc.StartValue(vdlTypeSet4); err != nil {
+		return err
+	}
+	if err := enc.SetLenHint(len(x)); err != nil {
+		return err
+	}
+	for key := range x {
+		if err := enc.NextEntryValueString(vdl.StringType, key); err != nil {
 			return err
 		}
 	}
@@ -130,6 +163,10 @@ func (x *groupData) VDLRead(dec vdl.Decoder) error { //nolint:gocyclo
 			if err := vdlReadAnonSet1(dec, &x.Entries); err != nil {
 				return err
 			}
+		case 2:
+			if err := vdlReadAnonSet2(dec, &x.Creators); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -158,6 +195,30 @@ func vdlReadAnonSet1(dec vdl.Decoder, x *map[groups.BlessingPatternChunk]struct{
 	}
 }
 
+func vdlReadAnonSet2(dec vdl.Decoder, x *map[string]struct{}) error {
+	if err := dec.StartValue(vdlTypeSet4); err != nil {
+		return err
+	}
+	var tmpMap map[string]struct{}
+	if len := dec.LenHint(); len > 0 {
+		tmpMap = make(map[string]struct{}, len)
+	}
+	for {
+		switch done, key, err := dec.NextEntryValueString(); {
+		case err != nil:
+			return err
+		case done:
+			*x = tmpMap
+			return dec.FinishValue()
+		default:
+			if tmpMap == nil {
+				tmpMap = make(map[string]struct{})
+			}
+			tmpMap[key] = struct{}{}
+		}
+	}
+}
+
 // initializeVDL performs vdl initialization.  It is safe to call multiple times.
 // If you have an init ordering issue, just insert the following line verbatim
 // into your source files in this package, right after the "package foo" clause:
@@ -184,7 +245,8 @@ func initializeVDL() struct{} {
 	vdlTypeStruct1 = vdl.TypeOf((*groupData)(nil)).Elem()
 	vdlTypeMap2 = vdl.TypeOf((*access.Permissions)(nil))
 	vdlTypeSet3 = vdl.TypeOf((*map[groups.BlessingPatternChunk]struct{})(nil))
-	vdlTypeString4 = vdl.TypeOf((*groups.BlessingPatternChunk)(nil))
+	vdlTypeSet4 = vdl.TypeOf((*map[string]struct{})(nil))
+	vdlTypeString5 = vdl.TypeOf((*groups.BlessingPatternChunk)(nil))
 
 	return struct{}{}
 }
@@ -5,20 +5,31 @@
 package server_test
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	_ "github.com/mattn/go-sqlite3"
+	wire "github.com/vanadium/services/groups"
+	"github.com/vanadium/services/groups/internal/audit"
 	"github.com/vanadium/services/groups/internal/server"
 	"github.com/vanadium/services/groups/internal/store"
 	"github.com/vanadium/services/groups/internal/store/mem"
+	"github.com/vanadium/services/groups/internal/store/sqlstore"
 	v23 "v.io/v23"
 	"v.io/v23/context"
 	"v.io/v23/naming"
+	"v.io/v23/options"
 	"v.io/v23/security"
 	"v.io/v23/security/access"
 	"v.io/v23/services/groups"
@@ -32,6 +43,12 @@ type backend int
 
 const (
 	memstore backend = iota
+	sqlitestore
+	// unaudited and unauditedSQLite are a memstore and a sqlitestore
+	// whose audit logs fail to append records of changes other than the
+	// creation of groups.
+	unaudited
+	unauditedSQLite
 )
 
 func Fatalf(t *testing.T, format string, args ...interface{}) {
@@ -103,19 +120,69 @@ func (reservedAuthorizer) Authorize(_ *context.T, call security.Call) error {
 	return nil
 }
 
-func newServer(ctx *context.T, be backend) (string, func()) {
+// security.Authorizer implementation that allows only the client used by the
+// tests to invoke the administrative methods.
+type adminAuthorizer struct{}
+
+func (adminAuthorizer) Authorize(ctx *context.T, call security.Call) error {
+	names, _ := security.RemoteBlessingNames(ctx, call)
+	if security.BlessingPattern("idp:client").MatchedBy(names...) {
+		return nil
+	}
+	return fmt.Errorf("%v are not administrators", names)
+}
+
+// audit.Log implementation that fails to append records of changes other than
+// the creation of groups. Those records are still appended in the transaction
+// of the change, if any, which must then be rolled back.
+type failingLog struct {
+	audit.Log
+}
+
+func (l failingLog) Append(tx *sql.Tx, r wire.AuditRecord) error {
+	if tx == nil && r.Change.Type != wire.ChangeTypeCreate {
+		return fmt.Errorf("audit log unavailable")
+	}
+	if err := l.Log.Append(tx, r); err != nil || r.Change.Type == wire.ChangeTypeCreate {
+		return err
+	}
+	return fmt.Errorf("audit log unavailable")
+}
+
+func newServer(ctx *context.T, be backend, quotas server.Quotas) (string, func()) {
 	var st store.Store
+	var auditLog audit.Log
 	var path string
 	var err error
 
 	switch be {
 	case memstore:
-		st = mem.New()
+		st, auditLog = mem.New(), audit.NewMem()
+	case unaudited:
+		st, auditLog = mem.New(), failingLog{audit.NewMem()}
+	case sqlitestore, unauditedSQLite:
+		if path, err = os.MkdirTemp("", "groups-sqlstore-"); err != nil {
+			ctx.Fatal("MkdirTemp() failed: ", err)
+		}
+		db, err := sql.Open("sqlite3", filepath.Join(path, "groups.db"))
+		if err != nil {
+			ctx.Fatal("sql.Open() failed: ", err)
+		}
+		db.SetMaxOpenConns(1)
+		if st, err = sqlstore.New("sqlite3", db); err != nil {
+			ctx.Fatal("sqlstore.New() failed: ", err)
+		}
+		if auditLog, err = audit.NewSQL(db); err != nil {
+			ctx.Fatal("audit.NewSQL() failed: ", err)
+		}
+		if be == unauditedSQLite {
+			auditLog = failingLog{auditLog}
+		}
 	default:
 		ctx.Fatal("unknown backend: ", be)
 	}
 
-	m := server.NewManager(st, reservedAuthorizer{})
+	m := server.NewManager(st, auditLog, reservedAuthorizer{}, adminAuthorizer{}, quotas)
 
 	ctx, cancel := context.WithCancel(ctx)
 	ctx, server, err := v23.WithNewDispatchingServer(ctx, "", m)
@@ -127,6 +194,7 @@ func newServer(ctx *context.T, be backend) (string, func()) {
 	return name, func() {
 		cancel()
 		<-server.Closed()
+		st.Close()
 		if path != "" {
 			os.RemoveAll(path)
 		}
@@ -134,6 +202,10 @@ func newServer(ctx *context.T, be backend) (string, func()) {
 }
 
 func setupOrDie(be backend) (clientCtx *context.T, serverName string, cleanup func()) {
+	return setupWithQuotasOrDie(be, server.Quotas{})
+}
+
+func setupWithQuotasOrDie(be backend, quotas server.Quotas) (clientCtx *context.T, serverName string, cleanup func()) {
 	ctx, shutdown := test.V23Init()
 	serverCtx, err := v23.WithPrincipal(ctx, testutil.NewPrincipal())
 	if err != nil {
@@ -151,7 +223,7 @@ func setupOrDie(be backend) (clientCtx *context.T, serverName string, cleanup fu
 	if err := idp.Bless(v23.GetPrincipal(serverCtx), "server"); err != nil {
 		ctx.Fatal(err)
 	}
-	serverName, stopServer := newServer(serverCtx, be)
+	serverName, stopServer := newServer(serverCtx, be, quotas)
 	cleanup = func() {
 		stopServer()
 		shutdown()
@@ -166,6 +238,10 @@ func TestCreateMemStore(t *testing.T) {
 	testCreateHelper(t, memstore)
 }
 
+func TestCreateSQLiteStore(t *testing.T) {
+	testCreateHelper(t, sqlitestore)
+}
+
 func testCreateHelper(t *testing.T, be backend) {
 	ctx, serverName, cleanup := setupOrDie(be)
 	defer cleanup()
@@ -227,6 +303,10 @@ func TestDeleteMemStore(t *testing.T) {
 	testDeleteHelper(t, memstore)
 }
 
+func TestDeleteSQLiteStore(t *testing.T) {
+	testDeleteHelper(t, sqlitestore)
+}
+
 func testDeleteHelper(t *testing.T, be backend) {
 	ctx, serverName, cleanup := setupOrDie(be)
 	defer cleanup()
@@ -291,6 +371,10 @@ func TestPermsMemStore(t *testing.T) {
 	testPermsHelper(t, memstore)
 }
 
+func TestPermsSQLiteStore(t *testing.T) {
+	testPermsHelper(t, sqlitestore)
+}
+
 func testPermsHelper(t *testing.T, be backend) { //nolint:gocyclo
 	ctx, serverName, cleanup := setupOrDie(be)
 	defer cleanup()
@@ -400,6 +484,10 @@ func TestAddMemStore(t *testing.T) {
 	testAddHelper(t, memstore)
 }
 
+func TestAddSQLiteStore(t *testing.T) {
+	testAddHelper(t, sqlitestore)
+}
+
 // testAddHelper tests mirror testRemoveHelper tests.
 func testAddHelper(t *testing.T, be backend) { //nolint:gocyclo
 	ctx, serverName, cleanup := setupOrDie(be)
@@ -489,6 +577,10 @@ func TestRemoveMemStore(t *testing.T) {
 	testRemoveHelper(t, memstore)
 }
 
+func TestRemoveSQLiteStore(t *testing.T) {
+	testRemoveHelper(t, sqlitestore)
+}
+
 // testRemoveHelper tests mirror testAddHelper tests.
 func testRemoveHelper(t *testing.T, be backend) { //nolint:gocyclo
 	ctx, serverName, cleanup := setupOrDie(be)
@@ -574,6 +666,804 @@ func testRemoveHelper(t *testing.T, be backend) { //nolint:gocyclo
 	}
 }
 
+func TestApplyMemStore(t *testing.T) {
+	testApplyHelper(t, memstore)
+}
+
+func TestApplySQLiteStore(t *testing.T) {
+	testApplyHelper(t, sqlitestore)
+}
+
+func testApplyHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	// Create a group with a default perms and two entries.
+	g := wire.GroupClient(naming.JoinAddressName(serverName, "grpA"))
+	if err := g.Create(ctx, nil, bpcSlice("foo", "bar")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// Apply with bad version should fail.
+	versionBefore := getVersionOrDie(t, ctx, g)
+	req := wire.BatchRequest{Add: bpcSlice("baz", "qux", "foo"), Remove: bpcSlice("foo", "bar")}
+	if _, err := g.Apply(ctx, req, "20"); !errors.Is(err, verror.ErrBadVersion) {
+		t.Fatalf("Apply should have failed with version error: %v", err)
+	}
+	if got, want := getVersionOrDie(t, ctx, g), versionBefore; got != want {
+		t.Errorf("Versions do not match: got %v, want %v", got, want)
+	}
+
+	// Apply with correct version should make all changes in one version bump.
+	versionAfter, err := g.Apply(ctx, req, versionBefore)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	got, want := getEntriesOrDie(t, ctx, g), bpcSet("foo", "baz", "qux")
+	if !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+	if got, want := getVersionOrDie(t, ctx, g), versionAfter; got != want {
+		t.Errorf("Versions do not match: got %v, want %v", got, want)
+	}
+	if versionBefore == versionAfter {
+		t.Errorf("Versions should not match: %v", versionBefore)
+	}
+
+	// Apply can replace the permissions along with the entries.
+	perms := access.Permissions{}
+	for _, tag := range []access.Tag{access.Admin, access.Read} {
+		perms.Add(security.BlessingPattern("idp:client"), string(tag))
+	}
+	req = wire.BatchRequest{Remove: bpcSlice("qux"), SetPerms: true, Perms: perms}
+	if _, err := g.Apply(ctx, req, ""); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	got, want = getEntriesOrDie(t, ctx, g), bpcSet("foo", "baz")
+	if !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+	if got, want := getPermsOrDie(t, ctx, g), perms; !reflect.DeepEqual(got, want) {
+		t.Errorf("Permissions do not match: got %v, want %v", got, want)
+	}
+	// Without Write access, Apply should fail.
+	if _, err := g.Apply(ctx, wire.BatchRequest{Add: bpcSlice("quux")}, ""); !errors.Is(err, verror.ErrNoAccess) {
+		t.Fatalf("Apply should have failed with access error: %v", err)
+	}
+
+	// Create a group with perms that allow Write but not Admin, check that
+	// replacing the permissions fails and leaves the entries untouched.
+	g = wire.GroupClient(naming.JoinAddressName(serverName, "grpB"))
+	perms = access.Permissions{}
+	for _, tag := range []access.Tag{access.Write, access.Read} {
+		perms.Add(security.BlessingPattern("idp:client"), string(tag))
+	}
+	if err := g.Create(ctx, perms, bpcSlice("foo")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	req = wire.BatchRequest{Add: bpcSlice("bar"), SetPerms: true, Perms: access.Permissions{}}
+	if _, err := g.Apply(ctx, req, ""); !errors.Is(err, verror.ErrNoAccess) {
+		t.Fatalf("Apply should have failed with access error: %v", err)
+	}
+	got, want = getEntriesOrDie(t, ctx, g), bpcSet("foo")
+	if !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+}
+
+// watchOrDie starts a Watch call on g and returns a channel of the changes it
+// receives. It does not return until the watch is known to be active on the
+// server, which it determines by changing probe, a group whose changes are
+// visible to the watch, until one such change is received.
+func watchOrDie(t *testing.T, ctx *context.T, g, probe wire.GroupClientStub, prefix bool) (<-chan wire.Change, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	call, err := g.Watch(ctx, prefix)
+	if err != nil {
+		Fatalf(t, "Watch failed: %v", err)
+	}
+	ch := make(chan wire.Change, 100)
+	go func() {
+		defer close(ch)
+		for call.RecvStream().Advance() {
+			ch <- call.RecvStream().Value()
+		}
+	}()
+	for synced := false; !synced; {
+		if err := probe.Add(ctx, bpc("probe"), ""); err != nil {
+			Fatalf(t, "Add failed: %v", err)
+		}
+		select {
+		case <-ch:
+			synced = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	// Discard the changes made by any other probes.
+	if err := probe.Remove(ctx, bpc("probe"), ""); err != nil {
+		Fatalf(t, "Remove failed: %v", err)
+	}
+	for change := range ch {
+		if change.Type == wire.ChangeTypeRemove {
+			return ch, cancel
+		}
+	}
+	Fatalf(t, "Watch ended unexpectedly: %v", call.Finish())
+	return nil, nil
+}
+
+func nextChangeOrDie(t *testing.T, ch <-chan wire.Change) wire.Change {
+	select {
+	case change, ok := <-ch:
+		if !ok {
+			Fatalf(t, "Watch ended unexpectedly")
+		}
+		return change
+	case <-time.After(10 * time.Second):
+		Fatalf(t, "timed out waiting for a change")
+	}
+	return wire.Change{}
+}
+
+func TestWatchMemStore(t *testing.T) {
+	testWatchHelper(t, memstore)
+}
+
+func TestWatchSQLiteStore(t *testing.T) {
+	testWatchHelper(t, sqlitestore)
+}
+
+func testWatchHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	// Watching a group that doesn't exist should fail.
+	g := wire.GroupClient(naming.JoinAddressName(serverName, "grpA"))
+	if call, err := g.Watch(ctx, false); err == nil {
+		if err := call.Finish(); !errors.Is(err, verror.ErrNoExist) {
+			t.Fatalf("Watch should have failed with no exist error: %v", err)
+		}
+	}
+
+	// Watch a single group.
+	if err := g.Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	ch, cancel := watchOrDie(t, ctx, g, g, false)
+	defer cancel()
+
+	if err := g.Add(ctx, bpc("foo"), ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	version := getVersionOrDie(t, ctx, g)
+	want := wire.Change{Name: "grpA", Type: wire.ChangeTypeAdd, Entries: bpcSlice("foo"), Version: version}
+	if got := nextChangeOrDie(t, ch); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A batch is reported as one change per type of change.
+	perms := getPermsOrDie(t, ctx, g)
+	req := wire.BatchRequest{Add: bpcSlice("bar"), Remove: bpcSlice("foo"), SetPerms: true, Perms: perms}
+	if version, err := g.Apply(ctx, req, ""); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	} else {
+		for _, want := range []wire.Change{
+			{Name: "grpA", Type: wire.ChangeTypeRemove, Entries: bpcSlice("foo"), Version: version},
+			{Name: "grpA", Type: wire.ChangeTypeAdd, Entries: bpcSlice("bar"), Version: version},
+			{Name: "grpA", Type: wire.ChangeTypeSetPermissions, Version: version},
+		} {
+			if got := nextChangeOrDie(t, ch); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		}
+	}
+
+	if err := g.Delete(ctx, ""); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	want = wire.Change{Name: "grpA", Type: wire.ChangeTypeDelete}
+	if got := nextChangeOrDie(t, ch); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Watch every group under a prefix that is not itself a group.
+	probe := wire.GroupClient(naming.JoinAddressName(serverName, "team/probe"))
+	if err := probe.Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	team := wire.GroupClient(naming.JoinAddressName(serverName, "team"))
+	ch, cancel = watchOrDie(t, ctx, team, probe, true)
+	defer cancel()
+
+	// Changes to groups outside the prefix, or that the caller can't
+	// access, are not sent.
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "teamB")).Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	perms = access.Permissions{}
+	perms.Add(security.BlessingPattern("idp:client"), string(access.Admin))
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "team/hidden")).Create(ctx, perms, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	// Changes to groups that the caller can resolve but not read are sent
+	// without their entries.
+	perms = access.Permissions{}
+	for _, tag := range []access.Tag{access.Admin, access.Write, access.Resolve} {
+		perms.Add(security.BlessingPattern("idp:client"), string(tag))
+	}
+	opaque := groups.GroupClient(naming.JoinAddressName(serverName, "team/opaque"))
+	if err := opaque.Create(ctx, perms, bpcSlice("secret")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := opaque.Add(ctx, bpc("another-secret"), ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	for _, want := range []wire.Change{
+		{Name: "team/opaque", Type: wire.ChangeTypeCreate},
+		{Name: "team/opaque", Type: wire.ChangeTypeAdd},
+	} {
+		got := nextChangeOrDie(t, ch)
+		if got.Version == "" {
+			t.Errorf("got %v, want a version", got)
+		}
+		if got.Version = ""; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+	x := groups.GroupClient(naming.JoinAddressName(serverName, "team/x"))
+	if err := x.Create(ctx, nil, bpcSlice("b", "a")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	want = wire.Change{Name: "team/x", Type: wire.ChangeTypeCreate, Entries: bpcSlice("a", "b"), Version: getVersionOrDie(t, ctx, x)}
+	if got := nextChangeOrDie(t, ch); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Concurrent changes are sent in the order in which they were
+	// committed. Some of them may fail because of conflicts.
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added int
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := x.Add(ctx, bpc(fmt.Sprintf("c%d", i)), "")
+			if err != nil && !errors.Is(err, verror.ErrBadVersion) {
+				t.Errorf("Add failed: %v", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				added++
+			}
+		}(i)
+	}
+	wg.Wait()
+	prev := want.Version
+	for i := 0; i < added; i++ {
+		got := nextChangeOrDie(t, ch)
+		if prevVersion, _ := strconv.Atoi(prev); got.Version != strconv.Itoa(prevVersion+1) {
+			t.Errorf("got %v after version %v", got, prev)
+		}
+		prev = got.Version
+	}
+}
+
+func TestQuotasMemStore(t *testing.T) {
+	testQuotasHelper(t, memstore)
+}
+
+func TestQuotasSQLiteStore(t *testing.T) {
+	testQuotasHelper(t, sqlitestore)
+}
+
+func testQuotasHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupWithQuotasOrDie(be, server.Quotas{MaxGroupsPerUser: 2, MaxEntriesPerGroup: 2})
+	defer cleanup()
+
+	grpA := wire.GroupClient(naming.JoinAddressName(serverName, "grpA"))
+	grpB := wire.GroupClient(naming.JoinAddressName(serverName, "grpB"))
+	grpC := wire.GroupClient(naming.JoinAddressName(serverName, "grpC"))
+
+	// Creating a group with too many entries should fail.
+	if err := grpA.Create(ctx, nil, bpcSlice("a", "b", "c")); !errors.Is(err, wire.ErrQuotaExceeded) {
+		t.Fatalf("Create should have failed with quota exceeded error: %v", err)
+	}
+	if err := grpA.Create(ctx, nil, bpcSlice("a", "b", "a")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// Adding an entry to a full group should fail, unless it is already
+	// present.
+	if err := grpA.Add(ctx, bpc("c"), ""); !errors.Is(err, wire.ErrQuotaExceeded) {
+		t.Fatalf("Add should have failed with quota exceeded error: %v", err)
+	}
+	if err := grpA.Add(ctx, bpc("a"), ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	// A batch is checked once all of its changes have been applied.
+	if _, err := grpA.Apply(ctx, wire.BatchRequest{Add: bpcSlice("c", "d")}, ""); !errors.Is(err, wire.ErrQuotaExceeded) {
+		t.Fatalf("Apply should have failed with quota exceeded error: %v", err)
+	}
+	if _, err := grpA.Apply(ctx, wire.BatchRequest{Remove: bpcSlice("a", "b"), Add: bpcSlice("c", "d")}, ""); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if got, want := getEntriesOrDie(t, ctx, grpA), bpcSet("c", "d"); !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+
+	// The caller may create at most two groups. Failing to create an
+	// existing group does not use any quota.
+	if err := grpA.Create(ctx, nil, nil); !errors.Is(err, verror.ErrExist) {
+		t.Fatalf("Create should have failed with exist error: %v", err)
+	}
+	if err := grpB.Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := grpC.Create(ctx, nil, nil); !errors.Is(err, wire.ErrQuotaExceeded) {
+		t.Fatalf("Create should have failed with quota exceeded error: %v", err)
+	}
+
+	// Deleting a group releases its quota.
+	if err := grpA.Delete(ctx, ""); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := grpC.Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := grpA.Create(ctx, nil, nil); !errors.Is(err, wire.ErrQuotaExceeded) {
+		t.Fatalf("Create should have failed with quota exceeded error: %v", err)
+	}
+}
+
+func TestNamesMemStore(t *testing.T) {
+	testNamesHelper(t, memstore)
+}
+
+func TestNamesSQLiteStore(t *testing.T) {
+	testNamesHelper(t, sqlitestore)
+}
+
+func testNamesHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	for _, name := range []string{"", "a/../b", "a/./b", "..", strings.Repeat("a", 768)} {
+		g := groups.GroupClient(naming.JoinAddressName(serverName, name))
+		if err := g.Create(ctx, nil, nil); !errors.Is(err, verror.ErrBadArg) {
+			t.Errorf("Create(%q) should have failed with bad arg error: %v", name, err)
+		}
+	}
+	name := strings.Repeat("a", 767)
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, name)).Create(ctx, nil, nil); err != nil {
+		t.Errorf("Create(%q) failed: %v", name, err)
+	}
+
+	// Only the Admin permission is granted on the groups below, so the
+	// client can neither resolve nor read them.
+	perms := access.Permissions{}
+	perms.Add(security.BlessingPattern("idp:client"), string(access.Admin))
+	for _, name := range []string{"secret", "team", "team/secret", "team/sub/secret"} {
+		if err := groups.GroupClient(naming.JoinAddressName(serverName, name)).Create(ctx, perms, nil); err != nil {
+			t.Fatalf("Create(%q) failed: %v", name, err)
+		}
+	}
+	checkErrors := func(cases map[string]error) {
+		t.Helper()
+		for name, want := range cases {
+			g := groups.GroupClient(naming.JoinAddressName(serverName, name))
+			if _, _, err := g.Get(ctx, groups.GetRequest{}, ""); !errors.Is(err, want) {
+				t.Errorf("Get(%q) should have failed with %v: %v", name, want, err)
+			}
+			if err := g.Add(ctx, bpc("foo"), ""); !errors.Is(err, want) {
+				t.Errorf("Add(%q) should have failed with %v: %v", name, want, err)
+			}
+		}
+	}
+
+	// Everyone may resolve the root, so the existence of top-level groups is
+	// not hidden.
+	checkErrors(map[string]error{
+		"secret":  verror.ErrNoAccess,
+		"missing": verror.ErrNoExist,
+	})
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "secret")).Create(ctx, nil, nil); !errors.Is(err, verror.ErrExist) {
+		t.Errorf("Create should have failed with exist error: %v", err)
+	}
+
+	// The client cannot resolve team, so the existence of groups under it,
+	// including those under non-group prefixes, is hidden.
+	checkErrors(map[string]error{
+		"team/secret":      verror.ErrNoExistOrNoAccess,
+		"team/missing":     verror.ErrNoExistOrNoAccess,
+		"team/sub/secret":  verror.ErrNoExistOrNoAccess,
+		"team/sub/missing": verror.ErrNoExistOrNoAccess,
+	})
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "team/secret")).Create(ctx, nil, nil); !errors.Is(err, verror.ErrNoExistOrNoAccess) {
+		t.Errorf("Create should have failed with no exist or no access error: %v", err)
+	}
+
+	// Once the client may resolve team, the errors are no longer hidden.
+	perms.Add(security.BlessingPattern("idp:client"), string(access.Resolve))
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "team")).SetPermissions(ctx, perms, ""); err != nil {
+		t.Fatalf("SetPermissions failed: %v", err)
+	}
+	checkErrors(map[string]error{
+		"team/secret":      verror.ErrNoAccess,
+		"team/missing":     verror.ErrNoExist,
+		"team/sub/secret":  verror.ErrNoAccess,
+		"team/sub/missing": verror.ErrNoExist,
+	})
+}
+
+func TestSnapshotMemStore(t *testing.T) {
+	testSnapshotHelper(t, memstore)
+}
+
+func TestSnapshotSQLiteStore(t *testing.T) {
+	testSnapshotHelper(t, sqlitestore)
+}
+
+func testSnapshotHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	admin := wire.AdminClient(naming.JoinAddressName(serverName, ""))
+	grpA := groups.GroupClient(naming.JoinAddressName(serverName, "grpA"))
+	grpB := groups.GroupClient(naming.JoinAddressName(serverName, "team/grpB"))
+	if err := grpA.Create(ctx, nil, bpcSlice("b", "a")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := grpA.Add(ctx, bpc("c"), ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := grpB.Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	snapshot, err := admin.ExportSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("ExportSnapshot failed: %v", err)
+	}
+	perms := getPermsOrDie(t, ctx, grpA)
+	versionA, versionB := getVersionOrDie(t, ctx, grpA), getVersionOrDie(t, ctx, grpB)
+	creators := []string{"idp"}
+	want := wire.Snapshot{Groups: []wire.GroupSnapshot{
+		{Name: "grpA", Perms: perms, Entries: bpcSlice("a", "b", "c"), Creators: creators, Version: versionA},
+		{Name: "team/grpB", Perms: perms, Creators: creators, Version: versionB},
+	}}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Fatalf("got %v, want %v", snapshot, want)
+	}
+
+	// Restore the snapshot into an empty server, preserving versions.
+	for _, g := range []groups.GroupClientStub{grpA, grpB} {
+		if err := g.Delete(ctx, ""); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+	if err := admin.ImportSnapshot(ctx, snapshot, true); err != nil {
+		t.Fatalf("ImportSnapshot failed: %v", err)
+	}
+	if got, err := admin.ExportSnapshot(ctx); err != nil {
+		t.Fatalf("ExportSnapshot failed: %v", err)
+	} else if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Versions of existing groups can't be preserved, since they could be
+	// rolled back or reused.
+	if err := grpA.Remove(ctx, bpc("a"), ""); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := admin.ImportSnapshot(ctx, snapshot, true); !errors.Is(err, verror.ErrExist) {
+		t.Fatalf("ImportSnapshot should have failed with exist error: %v", err)
+	}
+	if got, want := getEntriesOrDie(t, ctx, grpA), bpcSet("b", "c"); !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+
+	// Importing without preserving versions replaces existing groups, which
+	// are given new versions.
+	if err := admin.ImportSnapshot(ctx, snapshot, false); err != nil {
+		t.Fatalf("ImportSnapshot failed: %v", err)
+	}
+	if got, want := getEntriesOrDie(t, ctx, grpA), bpcSet("a", "b", "c"); !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+	if version := getVersionOrDie(t, ctx, grpA); version == versionA {
+		t.Errorf("Version should have changed: %v", version)
+	}
+
+	// Invalid snapshots are rejected.
+	for _, gs := range []wire.GroupSnapshot{{Name: "a/../b", Version: "0"}, {Name: "grpC", Version: "bad"}} {
+		bad := wire.Snapshot{Groups: []wire.GroupSnapshot{gs}}
+		if err := admin.ImportSnapshot(ctx, bad, true); !errors.Is(err, verror.ErrBadArg) {
+			t.Errorf("ImportSnapshot(%v) should have failed with bad arg error: %v", bad, err)
+		}
+	}
+
+	// Only administrators may export and import snapshots.
+	otherCtx, err := v23.WithPrincipal(ctx, testutil.NewPrincipal("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.ExportSnapshot(otherCtx, options.ServerAuthorizer{Authorizer: security.AllowEveryone()}); !errors.Is(err, verror.ErrNoAccess) {
+		t.Errorf("ExportSnapshot should have failed with no access error: %v", err)
+	}
+	if err := admin.ImportSnapshot(otherCtx, snapshot, true, options.ServerAuthorizer{Authorizer: security.AllowEveryone()}); !errors.Is(err, verror.ErrNoAccess) {
+		t.Errorf("ImportSnapshot should have failed with no access error: %v", err)
+	}
+}
+
+func TestHistoryMemStore(t *testing.T) {
+	testHistoryHelper(t, memstore)
+}
+
+func TestHistorySQLiteStore(t *testing.T) {
+	testHistoryHelper(t, sqlitestore)
+}
+
+func testHistoryHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	g := wire.GroupClient(naming.JoinAddressName(serverName, "grpA"))
+	start := time.Now()
+	if err := g.Create(ctx, nil, bpcSlice("foo")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	perms := getPermsOrDie(t, ctx, g)
+	if err := g.Add(ctx, bpc("bar"), ""); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := g.Apply(ctx, wire.BatchRequest{Add: bpcSlice("baz"), Remove: bpcSlice("foo")}, ""); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if err := g.SetPermissions(ctx, perms, ""); err != nil {
+		t.Fatalf("SetPermissions failed: %v", err)
+	}
+	// Failed changes are not recorded.
+	if err := g.Add(ctx, bpc("qux"), "bad"); !errors.Is(err, verror.ErrBadVersion) {
+		t.Fatalf("Add should have failed with version error: %v", err)
+	}
+	if err := g.Delete(ctx, ""); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// The history of a deleted group is available to administrators.
+	records, err := g.History(ctx)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	want := []wire.AuditRecord{
+		{OldVersion: "", Change: wire.Change{Type: wire.ChangeTypeCreate, Entries: bpcSlice("foo"), Version: "0"}, Perms: perms},
+		{OldVersion: "0", Change: wire.Change{Type: wire.ChangeTypeAdd, Entries: bpcSlice("bar"), Version: "1"}},
+		{OldVersion: "1", Change: wire.Change{Type: wire.ChangeTypeRemove, Entries: bpcSlice("foo"), Version: "2"}},
+		{OldVersion: "1", Change: wire.Change{Type: wire.ChangeTypeAdd, Entries: bpcSlice("baz"), Version: "2"}},
+		{OldVersion: "2", Change: wire.Change{Type: wire.ChangeTypeSetPermissions, Version: "3"}, Perms: perms},
+		{OldVersion: "3", Change: wire.Change{Type: wire.ChangeTypeDelete}},
+	}
+	if got, want := len(records), len(want); got != want {
+		t.Fatalf("got %v records, want %v: %v", got, want, records)
+	}
+	last := start
+	for i, got := range records {
+		if got.Timestamp.Before(last) || got.Timestamp.After(time.Now()) {
+			t.Errorf("record %v has timestamp %v, want between %v and now", i, got.Timestamp, last)
+		}
+		last = got.Timestamp
+		want[i].Blessings = []string{"idp:client"}
+		want[i].Timestamp = got.Timestamp
+		want[i].Change.Name = "grpA"
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("got record %v, want %v", got, want[i])
+		}
+	}
+
+	// Otherwise, History requires Admin access to the group.
+	if err := g.Create(ctx, nil, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	otherCtx, err := v23.WithPrincipal(ctx, testutil.NewPrincipal("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.History(otherCtx, options.ServerAuthorizer{Authorizer: security.AllowEveryone()}); !errors.Is(err, verror.ErrNoAccess) {
+		t.Errorf("History should have failed with no access error: %v", err)
+	}
+}
+
+func TestUnauditedMemStore(t *testing.T) {
+	testUnauditedHelper(t, unaudited)
+}
+
+func TestUnauditedSQLiteStore(t *testing.T) {
+	testUnauditedHelper(t, unauditedSQLite)
+}
+
+func testUnauditedHelper(t *testing.T, be backend) {
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	g := wire.GroupClient(naming.JoinAddressName(serverName, "grpA"))
+	if err := g.Create(ctx, nil, bpcSlice("foo")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	// Changes that can't be audited are not made.
+	if err := g.Add(ctx, bpc("bar"), ""); !errors.Is(err, verror.ErrInternal) {
+		t.Fatalf("Add should have failed with internal error: %v", err)
+	}
+	if err := g.Delete(ctx, ""); !errors.Is(err, verror.ErrInternal) {
+		t.Fatalf("Delete should have failed with internal error: %v", err)
+	}
+	if got, want := getEntriesOrDie(t, ctx, g), bpcSet("foo"); !entriesEqual(got, want) {
+		t.Errorf("Entries do not match: got %v, want %v", got, want)
+	}
+	// Nor are their audit records.
+	records, err := g.History(ctx)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Change.Type != wire.ChangeTypeCreate {
+		t.Errorf("got records %v, want only the creation of the group", records)
+	}
+}
+
+func TestExpandMemStore(t *testing.T) {
+	testExpandHelper(t, memstore)
+}
+
+func TestExpandSQLiteStore(t *testing.T) {
+	testExpandHelper(t, sqlitestore)
+}
+
+func testExpandHelper(t *testing.T, be backend) { //nolint:gocyclo
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	// The server expands referenced groups on behalf of the client, with its
+	// own blessings, so both are granted Read access to the groups.
+	perms := access.Permissions{}
+	for _, tag := range access.AllTypicalTags() {
+		perms.Add(security.BlessingPattern("idp:client"), string(tag))
+	}
+	perms.Add(security.BlessingPattern("idp:server"), string(access.Read))
+	ref := func(name string) string {
+		return groups.GroupStart + naming.JoinAddressName(serverName, name) + groups.GroupEnd
+	}
+	for name, entries := range map[string][]string{
+		"grpA": {"idp:alice", ref("grpB") + ":phone", "idp:" + ref("grpC")},
+		"grpB": {"idp:bob", "idp:carol", ref("grpA")},
+		"grpD": {ref("grpE") + ":" + ref("grpF")},
+		"grpE": {"idp:bob", "idp:carol"},
+		"grpF": {"laptop", "phone"},
+		"grpG": {"idp:erin", ref("grpH")},
+	} {
+		if err := groups.GroupClient(naming.JoinAddressName(serverName, name)).Create(ctx, perms, bpcSlice(entries...)); err != nil {
+			t.Fatalf("Create(%v) failed: %v", name, err)
+		}
+	}
+	// The client may not read grpH, although the server may.
+	hPerms := access.Permissions{}
+	hPerms.Add(security.BlessingPattern("idp:client"), string(access.Admin))
+	hPerms.Add(security.BlessingPattern("idp:server"), string(access.Read))
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "grpH")).Create(ctx, hPerms, bpcSlice("idp:dave")); err != nil {
+		t.Fatalf("Create(grpH) failed: %v", err)
+	}
+
+	expand := func(name string, hint groups.ApproximationType) ([]groups.BlessingPatternChunk, []string) {
+		t.Helper()
+		name = naming.JoinAddressName(serverName, name)
+		patterns, apprxs, version, err := wire.GroupClient(name).Expand(ctx, hint, map[string]struct{}{name: {}}, nil)
+		if err != nil {
+			t.Fatalf("Expand failed: %v", err)
+		}
+		if version == "" {
+			t.Errorf("Expand returned no version")
+		}
+		var reasons []string
+		for _, apprx := range apprxs {
+			reasons = append(reasons, apprx.Reason)
+		}
+		sort.Strings(reasons)
+		return patterns, reasons
+	}
+
+	// The reference from grpB back to grpA is a cycle, and grpC does not
+	// exist, so both are approximated.
+	wantReasons := []string{string(groups.ErrCycleFound.ID), string(verror.ErrNoExist.ID)}
+	sort.Strings(wantReasons)
+	patterns, reasons := expand("grpA", groups.ApproximationTypeUnder)
+	if got, want := patterns, bpcSlice("idp:alice", "idp:bob:phone", "idp:carol:phone"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := reasons, wantReasons; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	patterns, reasons = expand("grpA", groups.ApproximationTypeOver)
+	if got, want := patterns, bpcSlice("...", "idp", "idp:alice", "idp:bob:phone", "idp:carol:phone"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := reasons, wantReasons; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// An entry may contain several group references.
+	patterns, _ = expand("grpD", groups.ApproximationTypeUnder)
+	if got, want := patterns, bpcSlice("idp:bob:laptop", "idp:bob:phone", "idp:carol:laptop", "idp:carol:phone"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// The members of grpH are not revealed to the client through grpG.
+	patterns, reasons = expand("grpG", groups.ApproximationTypeUnder)
+	if got, want := patterns, bpcSlice("idp:erin"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := reasons, []string{string(verror.ErrNoAccess.ID)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGlobMemStore(t *testing.T) {
+	testGlobHelper(t, memstore)
+}
+
+func TestGlobSQLiteStore(t *testing.T) {
+	testGlobHelper(t, sqlitestore)
+}
+
+func testGlobHelper(t *testing.T, be backend) {
+	ctx, serverName, cleanup := setupOrDie(be)
+	defer cleanup()
+
+	// Groups with the default perms are visible to the client.
+	for _, name := range []string{"alice/a", "alice/team/b", "alice/team/c", "carol"} {
+		if err := groups.GroupClient(naming.JoinAddressName(serverName, name)).Create(ctx, nil, nil); err != nil {
+			t.Fatalf("Create(%v) failed: %v", name, err)
+		}
+	}
+	// Groups without Resolve or Read access for the client are not.
+	for _, name := range []string{"alice/hidden", "bob/d"} {
+		perms := access.Permissions{}
+		perms.Add(security.BlessingPattern("idp:client"), string(access.Admin))
+		if err := groups.GroupClient(naming.JoinAddressName(serverName, name)).Create(ctx, perms, nil); err != nil {
+			t.Fatalf("Create(%v) failed: %v", name, err)
+		}
+	}
+	// Resolve access alone suffices.
+	perms := access.Permissions{}
+	perms.Add(security.BlessingPattern("idp:client"), string(access.Resolve))
+	if err := groups.GroupClient(naming.JoinAddressName(serverName, "dave/e")).Create(ctx, perms, nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name, pattern string
+		want          []string
+	}{
+		{"", "*", []string{"alice", "carol", "dave"}},
+		{"alice", "*", []string{"a", "team"}},
+		{"alice", "t*", []string{"team"}},
+		{"alice/team", "*", []string{"b", "c"}},
+		{"", "*/*", []string{"alice/a", "alice/team", "dave/e"}},
+		{"bob", "*", []string{}},
+		{"nonexistent", "*", []string{}},
+	} {
+		got, _, err := testutil.GlobName(ctx, naming.JoinAddressName(serverName, tc.name), tc.pattern)
+		if err != nil {
+			t.Errorf("Glob(%q, %q) failed: %v", tc.name, tc.pattern, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Glob(%q, %q): got %v, want %v", tc.name, tc.pattern, got, tc.want)
+		}
+	}
+}
+
 func TestGet(t *testing.T) {
 	// TODO(sadovsky): Implement.
 }
@@ -8,7 +8,10 @@
 package mem
 
 import (
+	"database/sql"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/vanadium/services/groups/internal/store"
@@ -22,6 +25,7 @@ type entry struct {
 }
 
 type memstore struct {
+	txMu sync.Mutex // Serializes transactions, so that they can be rolled back.
 	mu   sync.Mutex
 	err  error
 	data map[string]*entry
@@ -49,33 +53,47 @@ func (st *memstore) Get(k string, v interface{}) (version string, err error) {
 	return strconv.FormatUint(e.Version, 10), nil
 }
 
-func (st *memstore) Insert(k string, v interface{}) error {
+func (st *memstore) Insert(k string, v interface{}) (string, error) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 	if st.err != nil {
-		return convertError(st.err)
+		return "", convertError(st.err)
 	}
 	if _, ok := st.data[k]; ok {
-		return store.ErrKeyExists.Errorf(nil, "key exists %s", k)
+		return "", store.ErrKeyExists.Errorf(nil, "key exists %s", k)
 	}
 	st.data[k] = &entry{Value: v}
-	return nil
+	return strconv.FormatUint(0, 10), nil
 }
 
-func (st *memstore) Update(k string, v interface{}, version string) error {
+func (st *memstore) Update(k string, v interface{}, version string) (string, error) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 	if st.err != nil {
-		return convertError(st.err)
+		return "", convertError(st.err)
 	}
 	e, ok := st.data[k]
 	if !ok {
-		return store.ErrUnknownKey.Errorf(nil, "unknown key %s", k)
+		return "", store.ErrUnknownKey.Errorf(nil, "unknown key %s", k)
 	}
 	if err := e.checkVersion(version); err != nil {
-		return err
+		return "", err
 	}
 	st.data[k] = &entry{Value: v, Version: e.Version + 1}
+	return strconv.FormatUint(e.Version+1, 10), nil
+}
+
+func (st *memstore) Put(k string, v interface{}, version string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.err != nil {
+		return convertError(st.err)
+	}
+	ver, err := strconv.ParseUint(version, 10, 64)
+	if err != nil {
+		return verror.ErrBadVersion.Errorf(nil, "invalid version %q", version)
+	}
+	st.data[k] = &entry{Value: v, Version: ver}
 	return nil
 }
 
@@ -96,6 +114,22 @@ func (st *memstore) Delete(k string, version string) error {
 	return nil
 }
 
+func (st *memstore) List(prefix string) ([]string, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.err != nil {
+		return nil, convertError(st.err)
+	}
+	var keys []string
+	for k := range st.data {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
 func (st *memstore) Close() error {
 	st.mu.Lock()
 	defer st.mu.Unlock()
@@ -106,6 +140,77 @@ func (st *memstore) Close() error {
 	return nil
 }
 
+// Transact rolls back the writes made by fn by restoring the entries that they
+// replaced. Transactions are serialized, and tx is always nil.
+func (st *memstore) Transact(fn func(st store.Store, tx *sql.Tx) error) error {
+	st.txMu.Lock()
+	defer st.txMu.Unlock()
+	tx := &memtx{memstore: st, undo: map[string]*entry{}}
+	if err := fn(tx, nil); err != nil {
+		tx.rollback()
+		return err
+	}
+	return nil
+}
+
+// memtx is the store.Store passed to the function run by Transact.
+type memtx struct {
+	*memstore
+	// undo holds the entry of each written key before its first write in
+	// the transaction, or nil if it did not exist.
+	undo map[string]*entry
+}
+
+func (tx *memtx) Insert(k string, v interface{}) (string, error) {
+	tx.save(k)
+	return tx.memstore.Insert(k, v)
+}
+
+func (tx *memtx) Update(k string, v interface{}, version string) (string, error) {
+	tx.save(k)
+	return tx.memstore.Update(k, v, version)
+}
+
+func (tx *memtx) Put(k string, v interface{}, version string) error {
+	tx.save(k)
+	return tx.memstore.Put(k, v, version)
+}
+
+func (tx *memtx) Delete(k string, version string) error {
+	tx.save(k)
+	return tx.memstore.Delete(k, version)
+}
+
+func (tx *memtx) Transact(func(store.Store, *sql.Tx) error) error {
+	return verror.ErrBadState.Errorf(nil, "transactions cannot be nested")
+}
+
+func (tx *memtx) Close() error {
+	return verror.ErrBadState.Errorf(nil, "a transaction cannot be closed")
+}
+
+func (tx *memtx) save(k string) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if _, ok := tx.undo[k]; !ok {
+		// Entries are never modified in place, so the current one can
+		// be restored.
+		tx.undo[k] = tx.data[k]
+	}
+}
+
+func (tx *memtx) rollback() {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	for k, e := range tx.undo {
+		if e == nil {
+			delete(tx.data, k)
+		} else {
+			tx.data[k] = e
+		}
+	}
+}
+
 // Internal helpers
 
 func (e *entry) checkVersion(version string) error {
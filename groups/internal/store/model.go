@@ -4,6 +4,8 @@
 
 package store
 
+import "database/sql"
+
 // Store is a key-value store that uses versions for optimistic concurrency
 // control. The versions passed to Update and Delete must come from Get. If in
 // the meantime some client has called Update or Delete on the same key, the
@@ -16,20 +18,41 @@ type Store interface {
 	// Fails if the given key is unknown (ErrUnknownKey).
 	Get(k string, v interface{}) (version string, err error)
 
-	// Insert writes the given value for the given key.
+	// Insert writes the given value for the given key and returns the version
+	// of the new entry.
 	// Fails if an entry already exists for the given key (ErrKeyExists).
-	Insert(k string, v interface{}) error
+	Insert(k string, v interface{}) (version string, err error)
 
-	// Update writes the given value for the given key.
+	// Update writes the given value for the given key and returns the new
+	// version of the entry.
 	// Fails if the given key is unknown (ErrUnknownKey).
 	// Fails if version doesn't match (ErrBadVersion).
-	Update(k string, v interface{}, version string) error
+	Update(k string, v interface{}, version string) (newVersion string, err error)
+
+	// Put writes the given value for the given key with the given version,
+	// replacing any existing entry regardless of its version. It is used to
+	// restore entries along with versions previously returned by a Store.
+	// Fails if version is not a valid version (ErrBadVersion).
+	Put(k string, v interface{}, version string) error
 
 	// Delete deletes the entry for the given key.
 	// Fails if the given key is unknown (ErrUnknownKey).
 	// Fails if version doesn't match (ErrBadVersion).
 	Delete(k string, version string) error
 
+	// List returns the keys that begin with the given prefix, in
+	// lexicographic order.
+	List(prefix string) ([]string, error)
+
+	// Transact calls fn with a Store whose writes are made in a single
+	// transaction, which is committed iff fn returns nil. If the store is
+	// backed by a SQL database, tx is the underlying transaction, in which
+	// fn may write to other tables of the database so that those writes
+	// are committed or rolled back along with the others; otherwise tx is
+	// nil. The Store passed to fn must not be used after fn returns, and
+	// its Transact and Close methods fail.
+	Transact(fn func(st Store, tx *sql.Tx) error) error
+
 	// Close closes the store. All subsequent method calls will fail.
 	Close() error
 }
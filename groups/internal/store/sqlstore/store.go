@@ -0,0 +1,328 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sqlstore provides a persistent implementation of store.Store
+// backed by a SQL database (sqlite3 or MySQL). Values are VOM-encoded and
+// versions are maintained by the database, so that the optimistic concurrency
+// control semantics of store.Store hold across process restarts.
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vanadium/services/groups/internal/store"
+	"v.io/v23/verror"
+	"v.io/v23/vom"
+)
+
+const driverSqlite3 = "sqlite3"
+
+type sqlstore struct {
+	db *sql.DB
+
+	selectEntry, insertEntry, updateEntry, deleteEntry *sql.Stmt
+	listEntries, putInsertEntry, putUpdateEntry        *sql.Stmt
+}
+
+var _ store.Store = (*sqlstore)(nil)
+
+// New returns a store.Store that persists its entries in the provided
+// database, creating the required table if it does not already exist.
+// driver is the name of the database/sql driver used to open db.
+func New(driver string, db *sql.DB) (store.Store, error) {
+	st := &sqlstore{db: db}
+	if err := st.initDB(driver); err != nil {
+		return nil, err
+	}
+	if err := st.initStmts(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func (st *sqlstore) Get(k string, v interface{}) (version string, err error) {
+	return get(st.selectEntry, k, v)
+}
+
+func (st *sqlstore) Insert(k string, v interface{}) (version string, err error) {
+	err = st.inTx(func(tx *sql.Tx) error {
+		version, err = txStore{st, tx}.Insert(k, v)
+		return err
+	})
+	return version, err
+}
+
+func (st *sqlstore) Update(k string, v interface{}, version string) (newVersion string, err error) {
+	err = st.inTx(func(tx *sql.Tx) error {
+		newVersion, err = txStore{st, tx}.Update(k, v, version)
+		return err
+	})
+	return newVersion, err
+}
+
+func (st *sqlstore) Put(k string, v interface{}, version string) error {
+	return st.inTx(func(tx *sql.Tx) error {
+		return txStore{st, tx}.Put(k, v, version)
+	})
+}
+
+func (st *sqlstore) Delete(k string, version string) error {
+	return st.inTx(func(tx *sql.Tx) error {
+		return txStore{st, tx}.Delete(k, version)
+	})
+}
+
+func (st *sqlstore) List(prefix string) ([]string, error) {
+	return list(st.listEntries, prefix)
+}
+
+func (st *sqlstore) Transact(fn func(st store.Store, tx *sql.Tx) error) error {
+	return st.inTx(func(tx *sql.Tx) error {
+		return fn(txStore{st, tx}, tx)
+	})
+}
+
+func (st *sqlstore) Close() error {
+	if err := st.db.Close(); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+// txStore is the store.Store passed to the function run by Transact, which
+// makes its reads and writes in tx.
+type txStore struct {
+	st *sqlstore
+	tx *sql.Tx
+}
+
+func (t txStore) Get(k string, v interface{}) (version string, err error) {
+	return get(t.tx.Stmt(t.st.selectEntry), k, v)
+}
+
+func (t txStore) Insert(k string, v interface{}) (string, error) {
+	value, err := vom.Encode(v)
+	if err != nil {
+		return "", convertError(err)
+	}
+	if _, err := t.getVersion(k); err == nil {
+		return "", store.ErrKeyExists.Errorf(nil, "key exists %s", k)
+	} else if !errors.Is(err, store.ErrUnknownKey) {
+		return "", err
+	}
+	if _, err := t.tx.Stmt(t.st.insertEntry).Exec([]byte(k), value); err != nil {
+		return "", convertError(err)
+	}
+	return strconv.FormatUint(0, 10), nil
+}
+
+func (t txStore) Update(k string, v interface{}, version string) (string, error) {
+	value, err := vom.Encode(v)
+	if err != nil {
+		return "", convertError(err)
+	}
+	ver, err := t.checkVersion(k, version)
+	if err != nil {
+		return "", err
+	}
+	if err := checkAffected(t.tx.Stmt(t.st.updateEntry).Exec(value, []byte(k), ver)); err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(ver+1, 10), nil
+}
+
+func (t txStore) Put(k string, v interface{}, version string) error {
+	ver, err := strconv.ParseUint(version, 10, 64)
+	if err != nil {
+		return verror.ErrBadVersion.Errorf(nil, "invalid version %q", version)
+	}
+	value, err := vom.Encode(v)
+	if err != nil {
+		return convertError(err)
+	}
+	stmt := t.st.putUpdateEntry
+	if _, err := t.getVersion(k); errors.Is(err, store.ErrUnknownKey) {
+		stmt = t.st.putInsertEntry
+	} else if err != nil {
+		return err
+	}
+	if _, err := t.tx.Stmt(stmt).Exec(value, ver, []byte(k)); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+func (t txStore) Delete(k string, version string) error {
+	ver, err := t.checkVersion(k, version)
+	if err != nil {
+		return err
+	}
+	return checkAffected(t.tx.Stmt(t.st.deleteEntry).Exec([]byte(k), ver))
+}
+
+func (t txStore) List(prefix string) ([]string, error) {
+	return list(t.tx.Stmt(t.st.listEntries), prefix)
+}
+
+func (t txStore) Transact(func(store.Store, *sql.Tx) error) error {
+	return verror.ErrBadState.Errorf(nil, "transactions cannot be nested")
+}
+
+func (t txStore) Close() error {
+	return verror.ErrBadState.Errorf(nil, "a transaction cannot be closed")
+}
+
+// Internal helpers
+
+// inTx runs fn in a transaction, committing it iff fn returns nil.
+func (st *sqlstore) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := st.db.Begin()
+	if err != nil {
+		return convertError(err)
+	}
+	// If tx.Commit is called, then this tx.Rollback is a no-op
+	defer tx.Rollback() //nolint:errcheck
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+func get(stmt *sql.Stmt, k string, v interface{}) (string, error) {
+	var (
+		value []byte
+		ver   uint64
+	)
+	if err := stmt.QueryRow([]byte(k)).Scan(&value, &ver); err != nil {
+		if err == sql.ErrNoRows {
+			return "", store.ErrUnknownKey.Errorf(nil, "unknown key %s", k)
+		}
+		return "", convertError(err)
+	}
+	if err := vom.Decode(value, v); err != nil {
+		return "", convertError(err)
+	}
+	return strconv.FormatUint(ver, 10), nil
+}
+
+func list(stmt *sql.Stmt, prefix string) ([]string, error) {
+	rows, err := stmt.Query([]byte(prefix))
+	if err != nil {
+		return nil, convertError(err)
+	}
+	defer rows.Close()
+	var keys []string
+	for rows.Next() {
+		var k []byte
+		if err := rows.Scan(&k); err != nil {
+			return nil, convertError(err)
+		}
+		// Rows are ordered by name, so the first key without the prefix
+		// marks the end of the range.
+		if !strings.HasPrefix(string(k), prefix) {
+			break
+		}
+		keys = append(keys, string(k))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, convertError(err)
+	}
+	return keys, nil
+}
+
+func (t txStore) getVersion(k string) (uint64, error) {
+	var (
+		value []byte
+		ver   uint64
+	)
+	if err := t.tx.Stmt(t.st.selectEntry).QueryRow([]byte(k)).Scan(&value, &ver); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, store.ErrUnknownKey.Errorf(nil, "unknown key %s", k)
+		}
+		return 0, convertError(err)
+	}
+	return ver, nil
+}
+
+func (t txStore) checkVersion(k, version string) (uint64, error) {
+	ver, err := t.getVersion(k)
+	if err != nil {
+		return 0, err
+	}
+	if version != strconv.FormatUint(ver, 10) {
+		return 0, verror.ErrBadVersion.Errorf(nil, "version is out of date")
+	}
+	return ver, nil
+}
+
+// checkAffected verifies that a conditional UPDATE or DELETE modified exactly
+// one row. Zero rows means that a concurrent writer bumped the version between
+// our read and write.
+func checkAffected(result sql.Result, err error) error {
+	if err != nil {
+		return convertError(err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return convertError(err)
+	}
+	if n != 1 {
+		return verror.ErrBadVersion.Errorf(nil, "version is out of date")
+	}
+	return nil
+}
+
+func (st *sqlstore) initDB(driver string) error {
+	if driver == driverSqlite3 {
+		// Make committed transactions durable across crashes.
+		// https://www.sqlite.org/pragma.html#pragma_synchronous
+		if _, err := st.db.Exec("PRAGMA synchronous=FULL"); err != nil {
+			return err
+		}
+	}
+	// Name is a VARBINARY so that the primary key fits within the MySQL
+	// index size limit while still permitting long group names. Keys are
+	// always bound as []byte so that sqlite3 neither applies numeric affinity
+	// to them nor compares them using a text collation.
+	_, err := st.db.Exec(`
+CREATE TABLE IF NOT EXISTS GroupEntry (
+	Name VARBINARY(767) NOT NULL PRIMARY KEY,
+	Value MEDIUMBLOB NOT NULL,
+	Version BIGINT NOT NULL
+)`)
+	return err
+}
+
+func (st *sqlstore) initStmts() error {
+	stmts := []struct {
+		stmt **sql.Stmt
+		sql  string
+	}{
+		{&st.selectEntry, "SELECT Value, Version FROM GroupEntry WHERE Name = ?"},
+		{&st.insertEntry, "INSERT INTO GroupEntry (Name, Value, Version) VALUES (?, ?, 0)"},
+		{&st.updateEntry, "UPDATE GroupEntry SET Value = ?, Version = Version + 1 WHERE Name = ? AND Version = ?"},
+		{&st.deleteEntry, "DELETE FROM GroupEntry WHERE Name = ? AND Version = ?"},
+		{&st.listEntries, "SELECT Name FROM GroupEntry WHERE Name >= ? ORDER BY Name"},
+		{&st.putInsertEntry, "INSERT INTO GroupEntry (Value, Version, Name) VALUES (?, ?, ?)"},
+		{&st.putUpdateEntry, "UPDATE GroupEntry SET Value = ?, Version = ? WHERE Name = ?"},
+	}
+	for _, stmt := range stmts {
+		var err error
+		if *stmt.stmt, err = st.db.Prepare(stmt.sql); err != nil {
+			return fmt.Errorf("failed to prepare [%s]: %v", strings.Join(strings.Fields(stmt.sql), " "), err)
+		}
+	}
+	return nil
+}
+
+func convertError(err error) error {
+	return verror.ErrUnknown.Errorf(nil, "store.sqlstore: %v", err)
+}
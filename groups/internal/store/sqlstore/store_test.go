@@ -0,0 +1,203 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlstore_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/vanadium/services/groups/internal/store"
+	"github.com/vanadium/services/groups/internal/store/sqlstore"
+	"v.io/v23/verror"
+)
+
+func openOrDie(t *testing.T, path string) store.Store {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := sqlstore.New("sqlite3", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func TestVersions(t *testing.T) {
+	st := openOrDie(t, filepath.Join(t.TempDir(), "groups.db"))
+	defer st.Close()
+
+	var v string
+	if _, err := st.Get("a", &v); !errors.Is(err, store.ErrUnknownKey) {
+		t.Fatalf("Get should have failed with unknown key: %v", err)
+	}
+	insertedVersion, err := st.Insert("a", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.Insert("a", "y"); !errors.Is(err, store.ErrKeyExists) {
+		t.Fatalf("Insert should have failed with key exists: %v", err)
+	}
+	version, err := st.Get("a", &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v, "x"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := version, insertedVersion; got != want {
+		t.Errorf("got version %v, want %v", got, want)
+	}
+	if _, err := st.Update("a", "y", version+"1"); !errors.Is(err, verror.ErrBadVersion) {
+		t.Fatalf("Update should have failed with version error: %v", err)
+	}
+	updatedVersion, err := st.Update("a", "y", version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The old version is now stale.
+	if _, err := st.Update("a", "z", version); !errors.Is(err, verror.ErrBadVersion) {
+		t.Fatalf("Update should have failed with version error: %v", err)
+	}
+	if err := st.Delete("a", version); !errors.Is(err, verror.ErrBadVersion) {
+		t.Fatalf("Delete should have failed with version error: %v", err)
+	}
+	newVersion, err := st.Get("a", &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v, "y"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if newVersion == version {
+		t.Errorf("Versions should not match: %v", version)
+	}
+	if newVersion != updatedVersion {
+		t.Errorf("Versions do not match: got %v, want %v", newVersion, updatedVersion)
+	}
+	if err := st.Delete("a", newVersion); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Delete("a", newVersion); !errors.Is(err, store.ErrUnknownKey) {
+		t.Fatalf("Delete should have failed with unknown key: %v", err)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.db")
+	st := openOrDie(t, path)
+	version, err := st.Insert("a", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version, err = st.Update("a", "y", version); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var v string
+	if _, err := st.Get("a", &v); err == nil {
+		t.Fatal("Get should fail on a closed store")
+	}
+
+	// Both the value and its version must survive reopening the database.
+	st = openOrDie(t, path)
+	defer st.Close()
+	gotVersion, err := st.Get("a", &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v, "y"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := gotVersion, version; got != want {
+		t.Errorf("got version %v, want %v", got, want)
+	}
+}
+
+func TestPut(t *testing.T) {
+	st := openOrDie(t, filepath.Join(t.TempDir(), "groups.db"))
+	defer st.Close()
+
+	if err := st.Put("a", "x", "bad"); !errors.Is(err, verror.ErrBadVersion) {
+		t.Fatalf("Put should have failed with version error: %v", err)
+	}
+	// Put inserts missing entries and replaces existing ones, regardless of
+	// their versions.
+	for _, test := range []struct{ value, version string }{{"x", "7"}, {"y", "3"}} {
+		if err := st.Put("a", test.value, test.version); err != nil {
+			t.Fatal(err)
+		}
+		var v string
+		version, err := st.Get("a", &v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := v, test.value; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if got, want := version, test.version; got != want {
+			t.Errorf("got version %v, want %v", got, want)
+		}
+	}
+	// Versions continue from the restored version.
+	if version, err := st.Update("a", "z", "3"); err != nil {
+		t.Fatal(err)
+	} else if got, want := version, "4"; got != want {
+		t.Errorf("got version %v, want %v", got, want)
+	}
+}
+
+func TestTransact(t *testing.T) {
+	st := openOrDie(t, filepath.Join(t.TempDir(), "groups.db"))
+	defer st.Close()
+
+	if _, err := st.Insert("a", "x"); err != nil {
+		t.Fatal(err)
+	}
+	// The writes of a failed transaction are rolled back, including those
+	// made to other tables in tx.
+	errAbort := errors.New("abort")
+	if err := st.Transact(func(tx store.Store, sqlTx *sql.Tx) error {
+		if _, err := tx.Update("a", "y", "0"); err != nil {
+			return err
+		}
+		if _, err := tx.Insert("b", "z"); err != nil {
+			return err
+		}
+		if _, err := sqlTx.Exec("CREATE TABLE Other (Name TEXT)"); err != nil {
+			return err
+		}
+		return errAbort
+	}); err != errAbort {
+		t.Fatalf("Transact should have failed with %v: %v", errAbort, err)
+	}
+	var v string
+	if version, err := st.Get("a", &v); err != nil || v != "x" || version != "0" {
+		t.Errorf("got (%v, %v, %v), want (x, 0, <nil>)", v, version, err)
+	}
+	if _, err := st.Get("b", &v); !errors.Is(err, store.ErrUnknownKey) {
+		t.Errorf("Get should have failed with unknown key: %v", err)
+	}
+	// Reads in the transaction see its writes, which are committed.
+	if err := st.Transact(func(tx store.Store, sqlTx *sql.Tx) error {
+		if _, err := tx.Update("a", "y", "0"); err != nil {
+			return err
+		}
+		if _, err := tx.Get("a", &v); err != nil || v != "y" {
+			t.Errorf("got (%v, %v), want (y, <nil>)", v, err)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := st.Get("a", &v); err != nil || v != "y" || version != "1" {
+		t.Errorf("got (%v, %v, %v), want (y, 1, <nil>)", v, version, err)
+	}
+}
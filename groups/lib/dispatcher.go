@@ -5,11 +5,18 @@
 package lib
 
 import (
+	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/vanadium/services/groups/internal/audit"
 	"github.com/vanadium/services/groups/internal/server"
+	"github.com/vanadium/services/groups/internal/store"
 	"github.com/vanadium/services/groups/internal/store/mem"
+	"github.com/vanadium/services/groups/internal/store/sqlstore"
 	"v.io/v23/context"
 	"v.io/v23/conventions"
 	"v.io/v23/rpc"
@@ -39,17 +46,63 @@ func (createAuthorizer) Authorize(ctx *context.T, call security.Call) error {
 	return fmt.Errorf("creator user ids %v are not authorized to create group %v, group name must begin with one of the user ids", userids, call.Suffix())
 }
 
+// Quotas limits the resources that may be consumed by the groups managed by
+// the groups service. A zero limit means that the resource is unlimited.
+type Quotas = server.Quotas
+
 // NewGroupsDispatcher creates a new dispatcher for the groups service.
 //
 // rootDir is the directory for persisting groups.
 //
-// engine is the storage engine for groups.  Currently, only "memstore"
-// is supported.
-func NewGroupsDispatcher(rootDir, engine string) (rpc.Dispatcher, error) {
+// engine is the storage engine for groups.  Currently, "memstore" and
+// "sqlite3" are supported.  The sqlite3 engine keeps its database in
+// rootDir/groups.db so that groups survive server restarts. The audit log of
+// the changes made to the groups is kept by the same engine, and each change is
+// committed along with its audit records.
+//
+// quotas limits the number of groups each user may create and the number of
+// entries in each group.
+//
+// Snapshots of the groups may be exported and imported by the callers allowed
+// by the default authorization policy, i.e. those whose blessings are
+// delegates or delegators of the server's.
+func NewGroupsDispatcher(rootDir, engine string, quotas Quotas) (rpc.Dispatcher, error) {
+	st, auditLog, err := newStore(rootDir, engine)
+	if err != nil {
+		return nil, err
+	}
+	return server.NewManager(st, auditLog, createAuthorizer{}, security.DefaultAuthorizer(), quotas), nil
+}
+
+// newStore returns the store for the given engine, along with an audit log
+// that is kept in the same storage.
+func newStore(rootDir, engine string) (store.Store, audit.Log, error) {
 	switch engine {
 	case "memstore":
-		return server.NewManager(mem.New(), createAuthorizer{}), nil
+		return mem.New(), audit.NewMem(), nil
+	case "sqlite3":
+		if err := os.MkdirAll(rootDir, 0700); err != nil {
+			return nil, nil, fmt.Errorf("failed to create root dir %v: %v", rootDir, err)
+		}
+		db, err := sql.Open(engine, filepath.Join(rootDir, "groups.db"))
+		if err != nil {
+			return nil, nil, err
+		}
+		// sqlite3 serializes writers; a single connection avoids spurious
+		// "database is locked" errors under concurrent RPCs.
+		db.SetMaxOpenConns(1)
+		st, err := sqlstore.New(engine, db)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		auditLog, err := audit.NewSQL(db)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return st, auditLog, nil
 	default:
-		return nil, fmt.Errorf("unknown storage engine %v", engine)
+		return nil, nil, fmt.Errorf("unknown storage engine %v", engine)
 	}
 }
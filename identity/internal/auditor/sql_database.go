@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/vanadium/services/internal/dbutil"
 	"v.io/v23/context"
 )
 
@@ -24,14 +25,22 @@ type databaseEntry struct {
 	decodeErr          error
 }
 
+// sqlMigrations returns the migrations of the schema of the given table (see
+// dbutil.Migrate).
+func sqlMigrations(table string) []dbutil.Migration {
+	return []dbutil.Migration{
+		{
+			Description: "Create the " + table + " table",
+			Statements:  []string{fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( Email VARBINARY(256), Caveats BLOB, Timestamp DATETIME, Blessings BLOB, KEY (Email, Timestamp) );", table)},
+		},
+	}
+}
+
 // newSQLDatabase returns a SQL implementation of the database interface.
-// If the table does not exist it creates it.
+// If the table does not exist, or has an older schema, it is migrated to the
+// latest schema.
 func newSQLDatabase(ctx *context.T, db *sql.DB, table string) (database, error) {
-	createStmt, err := db.Prepare(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( Email VARBINARY(256), Caveats BLOB, Timestamp DATETIME, Blessings BLOB, KEY (Email, Timestamp) );", table))
-	if err != nil {
-		return nil, err
-	}
-	if _, err = createStmt.Exec(); err != nil {
+	if _, err := dbutil.Migrate(db, "auditor:"+table, sqlMigrations(table)); err != nil {
 		return nil, err
 	}
 	insertStmt, err := db.Prepare(fmt.Sprintf("INSERT INTO %s (Email, Caveats, Timestamp, Blessings) VALUES (?, ?, ?, ?)", table))
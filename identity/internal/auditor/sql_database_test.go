@@ -23,9 +23,19 @@ func TestSQLDatabaseQuery(t *testing.T) {
 		t.Fatalf("failed to create new mock database stub: %v", err)
 	}
 	columns := []string{"Email", "Caveat", "Timestamp", "Blessings"}
-	mock.ExpectPrepare("CREATE TABLE IF NOT EXISTS tableName (.+)").
-		ExpectExec().
+	// The table is created by the first migration of its schema.
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS SchemaVersion (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT Version FROM SchemaVersion").
+		WithArgs("auditor:tableName").
+		WillReturnRows(sqlmock.NewRows([]string{"Version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS tableName (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO SchemaVersion (.+) VALUES (.+)").
+		WithArgs("auditor:tableName", 1, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 	insertStmt := mock.ExpectPrepare("INSERT INTO tableName (.+) VALUES (.+)")
 	queryStmt := mock.ExpectPrepare("SELECT Email, Caveats, Timestamp, Blessings FROM tableName")
 	d, err := newSQLDatabase(ctx, db, "tableName")
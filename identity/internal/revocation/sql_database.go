@@ -9,6 +9,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"time"
+
+	"github.com/vanadium/services/internal/dbutil"
 )
 
 type database interface {
@@ -61,12 +63,19 @@ func (s *sqlDatabase) RevocationTime(thirdPartyCaveatID string) (*time.Time, err
 	return nil, fmt.Errorf("the caveat (%v) was not revoked", thirdPartyCaveatID)
 }
 
-func newSQLDatabase(db *sql.DB, table string) (database, error) {
-	createStmt, err := db.Prepare(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( ThirdPartyCaveatID NVARCHAR(255), RevocationCaveatID NVARCHAR(255), RevocationTime DATETIME, PRIMARY KEY (ThirdPartyCaveatID), KEY (RevocationCaveatID) );", table))
-	if err != nil {
-		return nil, err
+// sqlMigrations returns the migrations of the schema of the given table (see
+// dbutil.Migrate).
+func sqlMigrations(table string) []dbutil.Migration {
+	return []dbutil.Migration{
+		{
+			Description: "Create the " + table + " table",
+			Statements:  []string{fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( ThirdPartyCaveatID NVARCHAR(255), RevocationCaveatID NVARCHAR(255), RevocationTime DATETIME, PRIMARY KEY (ThirdPartyCaveatID), KEY (RevocationCaveatID) );", table)},
+		},
 	}
-	if _, err = createStmt.Exec(); err != nil {
+}
+
+func newSQLDatabase(db *sql.DB, table string) (database, error) {
+	if _, err := dbutil.Migrate(db, "revocation:"+table, sqlMigrations(table)); err != nil {
 		return nil, err
 	}
 	insertCaveatStmt, err := db.Prepare(fmt.Sprintf("INSERT INTO %s (ThirdPartyCaveatID, RevocationCaveatID, RevocationTime) VALUES (?, ?, NULL)", table))
@@ -19,9 +19,19 @@ func TestSQLDatabase(t *testing.T) {
 		t.Fatalf("failed to create new mock database stub: %v", err)
 	}
 	columns := []string{"ThirdPartyCaveatID", "RevocationCaveatID", "RevocationTime"}
-	mock.ExpectPrepare("CREATE TABLE IF NOT EXISTS tableName (.+)").
-		ExpectExec().
+	// The table is created by the first migration of its schema.
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS SchemaVersion (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT Version FROM SchemaVersion").
+		WithArgs("revocation:tableName").
+		WillReturnRows(sqlmock.NewRows([]string{"Version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS tableName (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO SchemaVersion (.+) VALUES (.+)").
+		WithArgs("revocation:tableName", 1, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 	insertCaveatStmt := mock.ExpectPrepare("INSERT INTO tableName (.+) VALUES (.+)")
 	revokeStmt := mock.ExpectPrepare("UPDATE tableName SET RevocationTime=.+")
 	isRevokedStmt := mock.ExpectPrepare("SELECT 1 FROM tableName")
@@ -0,0 +1,123 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbutil
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SchemaVersionTable is the table in which Migrate records the version of
+// each schema in a database. It is created if it does not exist.
+const SchemaVersionTable = "SchemaVersion"
+
+// Migration is a step in the evolution of a schema, i.e., of a set of tables
+// that are managed together. The version of a schema is the number of its
+// migrations that have been applied to the database.
+//
+// Databases that predate the recording of schema versions are at version 0,
+// so the first migrations of a schema that used to be created with CREATE
+// TABLE IF NOT EXISTS statements should be those same statements.
+type Migration struct {
+	// Description is a one-line summary of the migration.
+	Description string
+	// Statements are executed in order to apply the migration, in the
+	// dialect of the database being migrated.
+	Statements []string
+}
+
+// SchemaVersion returns the version of the named schema in db, which is zero
+// if no migrations of the schema have been applied.
+func SchemaVersion(db *sql.DB, schema string) (int, error) {
+	if err := createSchemaVersionTable(db); err != nil {
+		return 0, err
+	}
+	var version int
+	err := db.QueryRow("SELECT Version FROM "+SchemaVersionTable+" WHERE Name = ?", schema).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return version, err
+}
+
+// PendingMigrations returns the version of the named schema in db and those
+// of migrations that have not been applied to db yet, i.e., the migrations
+// that Migrate would apply. It fails if db has a newer version of the schema
+// than migrations know about.
+func PendingMigrations(db *sql.DB, schema string, migrations []Migration) (int, []Migration, error) {
+	version, err := SchemaVersion(db, schema)
+	if err != nil {
+		return 0, nil, err
+	}
+	if version > len(migrations) {
+		return version, nil, fmt.Errorf("schema %q is at version %d, which is newer than the latest known version %d", schema, version, len(migrations))
+	}
+	return version, migrations[version:], nil
+}
+
+// Migrate applies the migrations of the named schema that have not been
+// applied to db yet, in order, and returns the number of migrations applied.
+//
+// Each migration is applied in a transaction along with the update of the
+// schema version, so a failed migration is rolled back and can be retried.
+// Note that MySQL implicitly commits data definition statements (e.g., CREATE
+// TABLE), so the migrations of schemas used with MySQL should only have one
+// such statement each, or be safe to re-apply. If another process migrates
+// the schema concurrently, the transaction of one of them fails.
+func Migrate(db *sql.DB, schema string, migrations []Migration) (int, error) {
+	version, pending, err := PendingMigrations(db, schema, migrations)
+	if err != nil {
+		return 0, err
+	}
+	for i, m := range pending {
+		if err := migrate(db, schema, version+i, m); err != nil {
+			return i, fmt.Errorf("failed to migrate schema %q to version %d (%v): %v", schema, version+i+1, m.Description, err)
+		}
+	}
+	return len(pending), nil
+}
+
+// migrate applies m to db, in which the schema is at the given version.
+func migrate(db *sql.DB, schema string, version int, m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	// If tx.Commit is called, then this tx.Rollback is a no-op
+	defer tx.Rollback() //nolint:errcheck
+	for _, stmt := range m.Statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("[%s]: %v", strings.Join(strings.Fields(stmt), " "), err)
+		}
+	}
+	// The conditions on the current version make concurrent migrations
+	// fail rather than apply a migration twice.
+	var result sql.Result
+	if version == 0 {
+		result, err = tx.Exec("INSERT INTO "+SchemaVersionTable+" (Name, Version, Updated) VALUES (?, ?, ?)", schema, version+1, time.Now().UTC())
+	} else {
+		result, err = tx.Exec("UPDATE "+SchemaVersionTable+" SET Version = ?, Updated = ? WHERE Name = ? AND Version = ?", version+1, time.Now().UTC(), schema, version)
+	}
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n != 1 {
+		return fmt.Errorf("schema version changed concurrently")
+	}
+	return tx.Commit()
+}
+
+func createSchemaVersionTable(db *sql.DB) error {
+	_, err := db.Exec("CREATE TABLE IF NOT EXISTS " + SchemaVersionTable + ` (
+Name    VARCHAR(255) PRIMARY KEY,
+Version INTEGER NOT NULL,
+Updated DATETIME
+)`)
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 The Vanadium Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbutil_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/vanadium/services/internal/dbutil"
+)
+
+func TestMigrate(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// Each connection would have its own in-memory database.
+	db.SetMaxOpenConns(1)
+
+	migrations := []dbutil.Migration{
+		{"Create A", []string{"CREATE TABLE A (X INTEGER)"}},
+		{"Add Y to A", []string{"ALTER TABLE A ADD COLUMN Y INTEGER", "UPDATE A SET Y = X"}},
+	}
+	check := func(schema string, wantVersion, wantPending int) {
+		t.Helper()
+		version, pending, err := dbutil.PendingMigrations(db, schema, migrations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != wantVersion || len(pending) != wantPending {
+			t.Errorf("%v: got version %d and %d pending migrations, want %d and %d", schema, version, len(pending), wantVersion, wantPending)
+		}
+	}
+	check("a", 0, 2)
+	if n, err := dbutil.Migrate(db, "a", migrations[:1]); err != nil || n != 1 {
+		t.Fatalf("got (%d, %v), want (1, nil)", n, err)
+	}
+	if _, err := db.Exec("INSERT INTO A (X) VALUES (1)"); err != nil {
+		t.Fatal(err)
+	}
+	check("a", 1, 1)
+	if n, err := dbutil.Migrate(db, "a", migrations); err != nil || n != 1 {
+		t.Fatalf("got (%d, %v), want (1, nil)", n, err)
+	}
+	var y int
+	if err := db.QueryRow("SELECT Y FROM A").Scan(&y); err != nil || y != 1 {
+		t.Errorf("got (%d, %v), want (1, nil)", y, err)
+	}
+	check("a", 2, 0)
+	if n, err := dbutil.Migrate(db, "a", migrations); err != nil || n != 0 {
+		t.Fatalf("got (%d, %v), want (0, nil)", n, err)
+	}
+	// Schemas are versioned independently.
+	check("b", 0, 2)
+
+	// Failed migrations are rolled back.
+	migrations = append(migrations, dbutil.Migration{"Add B and fail", []string{"CREATE TABLE B (X INTEGER)", "INSERT INTO Unknown VALUES (1)"}})
+	if n, err := dbutil.Migrate(db, "a", migrations); err == nil || n != 0 || !strings.Contains(err.Error(), "Unknown") {
+		t.Errorf("got (%d, %v), want (0, error about Unknown)", n, err)
+	}
+	check("a", 2, 1)
+	if _, err := db.Exec("SELECT * FROM B"); err == nil {
+		t.Errorf("table B was created by a failed migration")
+	}
+
+	// Binaries that only know about older versions of a schema fail.
+	if _, err := dbutil.Migrate(db, "a", migrations[:1]); err == nil {
+		t.Errorf("migrating to an older version unexpectedly succeeded")
+	}
+}
@@ -3,7 +3,8 @@
 // license that can be found in the LICENSE file.
 
 // Package dbutil implements utilities for opening and configuring connections
-// to MySQL-like databases, with optional TLS support.
+// to MySQL-like databases, with optional TLS support, and for versioning and
+// migrating the schemas of SQL databases (see Migrate).
 //
 // Functions in this file are not thread-safe. However, the returned *sql.DB is.
 // Sane defaults are assumed: utf8mb4 encoding, UTC timezone, parsing date/time